@@ -14,8 +14,12 @@ import (
 
 // BarChart is a chart that draws bars on a range.
 type BarChart struct {
-	Title      string
-	TitleStyle render.Style
+	Title         string
+	TitleStyle    render.Style
+	Subtitle      string
+	SubtitleStyle render.Style
+	Caption       string
+	CaptionStyle  render.Style
 
 	Font         render.Font
 	Background   render.Style
@@ -35,6 +39,11 @@ type BarChart struct {
 	Bars     []dataset.Value
 	Elements []render.Renderable
 
+	// ErrorBars holds the optional errors of the bar values, using the Y
+	// errors. The error bars are drawn along the value axis of the bars.
+	// Rendering fails if there are more errors than bars, or negative ones.
+	ErrorBars dataset.ErrorBars
+
 	width  int
 	height int
 	dpi    float64
@@ -105,6 +114,9 @@ func (bc *BarChart) Render(rp render.RendererProvider, w io.Writer) error {
 	if len(bc.Bars) == 0 {
 		return errors.New("please provide at least one bar")
 	}
+	if err := bc.ErrorBars.Validate(len(bc.Bars)); err != nil {
+		return err
+	}
 
 	r, err := rp(bc.Width(), bc.Height())
 	if err != nil {
@@ -119,7 +131,7 @@ func (bc *BarChart) Render(rp render.RendererProvider, w io.Writer) error {
 	var yr sequence.Range
 	var yf dataset.ValueFormatter
 
-	canvasBox = bc.getDefaultCanvasBox()
+	canvasBox = bc.getDefaultCanvasBox(r)
 	yr = bc.getRanges()
 	if yr.GetMax()-yr.GetMin() == 0 {
 		return fmt.Errorf("invalid data range; cannot be zero")
@@ -132,12 +144,19 @@ func (bc *BarChart) Render(rp render.RendererProvider, w io.Writer) error {
 
 		// Adjust domain range before adjusting the canvas box
 		// if the generated max tick value is exceeding the original max range.
-		if len(yt) > 0 {
+		if _, snap := bc.YAxis.RangePolicy.rounding(false); snap && len(yt) > 0 {
 			if yr.IsDescending() {
 				yr.SetMax(yt[0].Value)
 			} else {
 				yr.SetMax(yt[len(yt)-1].Value)
 			}
+
+			if bc.YAxis.RangePolicy.isClamped() {
+				min, max := bc.YAxis.RangePolicy.clamp(yr.GetMin(), yr.GetMax())
+				yr.SetMin(min)
+				yr.SetMax(max)
+				yt = ticksWithin(yr, yt)
+			}
 		}
 
 		yr = bc.setRangeDomains(canvasBox, yr)
@@ -180,7 +199,7 @@ func (bc *BarChart) getRanges() sequence.Range {
 	if bc.YAxis.Range != nil && !bc.YAxis.Range.IsZero() {
 		yrange = bc.YAxis.Range
 	} else {
-		yrange = &sequence.ContinuousRange{}
+		yrange = &sequence.ContinuousRange{Descending: bc.YAxis.Reversed}
 	}
 
 	if !yrange.IsZero() {
@@ -199,11 +218,14 @@ func (bc *BarChart) getRanges() sequence.Range {
 	}
 
 	min, max := math.MaxFloat64, -math.MaxFloat64
-	for _, b := range bc.Bars {
-		min = math.Min(b.Value, min)
-		max = math.Max(b.Value, max)
+	for index, b := range bc.Bars {
+		low, high := bc.ErrorBars.GetYBounds(index, b.Value)
+		min = math.Min(math.Min(b.Value, low), min)
+		max = math.Max(math.Max(b.Value, high), max)
 	}
 
+	round, _ := bc.YAxis.RangePolicy.rounding(false)
+	min, max = bc.YAxis.RangePolicy.apply(min, max, round)
 	yrange.SetMin(min)
 	yrange.SetMax(max)
 
@@ -233,29 +255,26 @@ func (bc *BarChart) drawBars(r render.Renderer, canvasBox render.Box, yr sequenc
 		strokeWidth := barStyle.GetStrokeWidth()
 		strokeOffset := int(strokeWidth / 2)
 
-		height := yr.Translate(bar.Value)
-		if height == 0 {
-			height = int(strokeWidth)
+		origin, end := bc.getBarExtent(yr, bar.Value, strokeWidth)
+		by = canvasBox.Bottom - end
+
+		// Bars below their origin, as on reversed value axes, grow down.
+		barBox = render.Box{
+			Top:    by,
+			Left:   bxl,
+			Right:  bxr,
+			Bottom: canvasBox.Bottom - origin - strokeOffset,
 		}
-		by = canvasBox.Bottom - height
-
-		if bc.UseBaseValue {
-			barBox = render.Box{
-				Top:    by,
-				Left:   bxl,
-				Right:  bxr,
-				Bottom: canvasBox.Bottom - yr.Translate(bc.BaseValue) - strokeOffset,
-			}
-		} else {
-			barBox = render.Box{
-				Top:    by,
-				Left:   bxl,
-				Right:  bxr,
-				Bottom: canvasBox.Bottom - strokeOffset,
-			}
+		if by > canvasBox.Bottom-origin {
+			barBox.Top, barBox.Bottom = canvasBox.Bottom-origin+strokeOffset, by
 		}
 
 		barBox.Draw(r, barStyle)
+
+		if low, high := bc.ErrorBars.GetYBounds(index, bar.Value); low != high {
+			bx := (bxl + bxr) >> 1
+			render.DrawErrorBar(r, bx, canvasBox.Bottom-yr.Translate(low), bx, canvasBox.Bottom-yr.Translate(high), barStyle)
+		}
 		xoffset += width + spacing
 	}
 }
@@ -271,7 +290,7 @@ func (bc *BarChart) drawHorizontalBars(r render.Renderer, canvasBox render.Box,
 
 	maxTextWidth := 0
 	for _, bar := range bc.Bars {
-		tb := r.MeasureText(bar.Label)
+		tb := render.Text.Measure(r, bar.Label, axisStyle)
 
 		maxTextWidth = mathutil.MaxInt(maxTextWidth, tb.Width())
 	}
@@ -286,33 +305,52 @@ func (bc *BarChart) drawHorizontalBars(r render.Renderer, canvasBox render.Box,
 		strokeWidth := barStyle.GetStrokeWidth()
 		strokeOffset := int(strokeWidth / 2)
 
-		width := yr.Translate(bar.Value)
-		if width == 0 {
-			width = int(strokeWidth)
+		origin, end := bc.getBarExtent(yr, bar.Value, strokeWidth)
+		bx = canvasBox.Left + end
+
+		// Bars left of their origin, as on reversed value axes, grow left.
+		barBox = render.Box{
+			Top:    byt,
+			Left:   canvasBox.Left + origin + strokeOffset,
+			Right:  bx,
+			Bottom: byb,
 		}
-		bx = canvasBox.Left + defaultYAxisMargin + width
-
-		if bc.UseBaseValue {
-			barBox = render.Box{
-				Top:    byt,
-				Left:   canvasBox.Left + yr.Translate(bc.BaseValue) - strokeOffset,
-				Right:  bx,
-				Bottom: byb,
-			}
-		} else {
-			barBox = render.Box{
-				Top:    byt,
-				Left:   canvasBox.Left + strokeOffset,
-				Right:  bx,
-				Bottom: byb,
-			}
+		if bx < canvasBox.Left+origin {
+			barBox.Left, barBox.Right = bx, canvasBox.Left+origin-strokeOffset
 		}
 
 		barBox.Draw(r, barStyle)
+
+		if low, high := bc.ErrorBars.GetYBounds(index, bar.Value); low != high {
+			ey := (byt + byb) >> 1
+			render.DrawErrorBar(r, canvasBox.Left+yr.Translate(low), ey, canvasBox.Left+yr.Translate(high), ey, barStyle)
+		}
 		yoffset += height + spacing
 	}
 }
 
+// getBarExtent returns the positions of the origin and the end of a bar
+// along the value axis, from the start of the axis. Bars originate from the
+// base value, if used, or from the minimum of the range, which is at the far
+// end of reversed axes. Bars ending at their origin are drawn with the
+// stroke width, in the direction of the axis.
+func (bc *BarChart) getBarExtent(yr sequence.Range, value, strokeWidth float64) (origin, end int) {
+	origin = yr.Translate(math.Min(yr.GetMin(), yr.GetMax()))
+	if bc.UseBaseValue {
+		origin = yr.Translate(bc.BaseValue)
+	}
+
+	end = yr.Translate(value)
+	if end == origin {
+		if yr.IsDescending() {
+			end -= int(strokeWidth)
+		} else {
+			end += int(strokeWidth)
+		}
+	}
+	return origin, end
+}
+
 func (bc *BarChart) drawXAxis(r render.Renderer, canvasBox render.Box) {
 	if !bc.XAxis.Hidden {
 		axisStyle := bc.XAxis.InheritFrom(bc.styleDefaultsAxes())
@@ -376,7 +414,7 @@ func (bc *BarChart) drawHorizontalXAxis(r render.Renderer, canvasBox render.Box,
 			r.Stroke()
 
 			axisStyle.GetTextOptions().WriteToRenderer(r)
-			tb = r.MeasureText(t.Label)
+			tb = render.Text.Measure(r, t.Label, axisStyle)
 			render.Text.Draw(r, t.Label, tx-(tb.Width()>>1), canvasBox.Bottom+defaultXAxisMargin+5, axisStyle)
 		}
 	}
@@ -387,29 +425,17 @@ func (bc *BarChart) drawYAxis(r render.Renderer, canvasBox render.Box, yr sequen
 		axisStyle := bc.YAxis.Style.InheritFrom(bc.styleDefaultsAxes())
 		axisStyle.WriteToRenderer(r)
 
-		r.MoveTo(canvasBox.Right, canvasBox.Top)
-		r.LineTo(canvasBox.Right, canvasBox.Bottom)
+		lx := bc.YAxis.getLineX(canvasBox)
+		r.MoveTo(lx, canvasBox.Top)
+		r.LineTo(lx, canvasBox.Bottom)
 		r.Stroke()
 
-		r.MoveTo(canvasBox.Right, canvasBox.Bottom)
-		r.LineTo(canvasBox.Right+defaultHorizontalTickWidth, canvasBox.Bottom)
+		r.MoveTo(lx, canvasBox.Bottom)
+		r.LineTo(lx+defaultHorizontalTickWidth, canvasBox.Bottom)
 		r.Stroke()
 
-		var ty int
-		var tb render.Box
-		for _, t := range ticks {
-			ty = canvasBox.Bottom - yr.Translate(t.Value)
-
-			axisStyle.GetStrokeOptions().WriteToRenderer(r)
-			r.MoveTo(canvasBox.Right, ty)
-			r.LineTo(canvasBox.Right+defaultHorizontalTickWidth, ty)
-			r.Stroke()
-
-			axisStyle.GetTextOptions().WriteToRenderer(r)
-			tb = r.MeasureText(t.Label)
-			render.Text.Draw(r, t.Label, canvasBox.Right+defaultYAxisMargin+5, ty+(tb.Height()>>1), axisStyle)
-		}
-
+		tickStyle := bc.YAxis.TickStyle.InheritFrom(axisStyle)
+		bc.YAxis.renderTicks(r, canvasBox, yr, tickStyle, ticks)
 	}
 }
 
@@ -432,7 +458,7 @@ func (bc *BarChart) drawHorizontalYAxis(r render.Renderer, canvasBox render.Box)
 		r.Stroke()
 
 		for index, bar := range bc.Bars {
-			tb := r.MeasureText(bar.Label)
+			tb := render.Text.Measure(r, bar.Label, axisStyle)
 
 			barLabelBox := render.Box{
 				Top:    cursor + spacing,
@@ -457,22 +483,12 @@ func (bc *BarChart) drawHorizontalYAxis(r render.Renderer, canvasBox render.Box)
 }
 
 func (bc *BarChart) drawTitle(r render.Renderer) {
-	if len(bc.Title) > 0 && !bc.TitleStyle.Hidden {
-		r.SetFont(bc.TitleStyle.GetFont(bc.GetFont()))
-		r.SetFontColor(bc.TitleStyle.GetFontColor(bc.GetColorPalette().TextColor()))
-		titleFontSize := bc.TitleStyle.GetFontSize(bc.getTitleFontSize())
-		r.SetFontSize(titleFontSize)
-
-		textBox := r.MeasureText(bc.Title)
-
-		textWidth := textBox.Width()
-		textHeight := textBox.Height()
-
-		titleX := (bc.Width() >> 1) - (textWidth >> 1)
-		titleY := bc.TitleStyle.Padding.GetTop(defaultTitleTop) + textHeight
+	bc.getTitles().draw(r, bc.box())
+}
 
-		r.Text(bc.Title, titleX, titleY)
-	}
+func (bc *BarChart) getTitles() chartTitles {
+	return newChartTitles(bc.styleDefaultsTitle(),
+		bc.Title, bc.TitleStyle, bc.Subtitle, bc.SubtitleStyle, bc.Caption, bc.CaptionStyle)
 }
 
 func (bc *BarChart) getCanvasStyle() render.Style {
@@ -500,8 +516,8 @@ func (bc *BarChart) setRangeDomains(canvasBox render.Box, yr sequence.Range) seq
 	return yr
 }
 
-func (bc *BarChart) getDefaultCanvasBox() render.Box {
-	return bc.box()
+func (bc *BarChart) getDefaultCanvasBox(r render.Renderer) render.Box {
+	return bc.getTitles().adjust(r, bc.box())
 }
 
 func (bc *BarChart) getValueFormatters() dataset.ValueFormatter {
@@ -514,6 +530,7 @@ func (bc *BarChart) getValueFormatters() dataset.ValueFormatter {
 func (bc *BarChart) getAxesTicks(r render.Renderer, yr sequence.Range, yf dataset.ValueFormatter) (yticks []Tick) {
 	if !bc.YAxis.Style.Hidden {
 		yticks = bc.YAxis.GetTicks(r, yr, bc.styleDefaultsAxes(), yf)
+		yticks = bc.YAxis.RangePolicy.filterTicks(yr, yticks)
 	}
 	return
 }
@@ -604,33 +621,13 @@ func (bc *BarChart) getAdjustedCanvasBox(r render.Renderer, canvasBox render.Box
 		axesOuterBox = axesOuterBox.Grow(axesBounds)
 	}
 
-	return canvasBox.OuterConstrain(bc.box(), axesOuterBox)
+	return canvasBox.OuterConstrain(bc.getDefaultCanvasBox(r), axesOuterBox)
 }
 
 func (bc *BarChart) getAdjustedHorizontalCanvasBox(r render.Renderer, canvasBox render.Box, yrange sequence.Range, yticks []Tick) render.Box {
 	axesOuterBox := canvasBox.Clone()
 	size, spacing, totalHeight := bc.calculateScaledTotalSize(canvasBox)
 
-	if len(bc.Title) > 0 && !bc.TitleStyle.Hidden {
-		r.SetFont(bc.TitleStyle.GetFont(bc.GetFont()))
-		r.SetFontColor(bc.TitleStyle.GetFontColor(bc.GetColorPalette().TextColor()))
-		titleFontSize := bc.TitleStyle.GetFontSize(bc.getTitleFontSize())
-		r.SetFontSize(titleFontSize)
-
-		textBox := r.MeasureText(bc.Title)
-
-		tbox := render.Box{
-			Top:    canvasBox.Top - textBox.Height() - bc.TitleStyle.Padding.Height(),
-			Left:   canvasBox.Left,
-			Right:  canvasBox.Right,
-			Bottom: canvasBox.Top,
-		}
-
-		axesOuterBox = axesOuterBox.Grow(tbox)
-
-		r.ResetStyle()
-	}
-
 	if !bc.YAxis.Style.Hidden {
 		yAxisWidth := defaultHorizontalTickWidth
 		axisStyle := bc.YAxis.Style.InheritFrom(bc.styleDefaultsAxes())
@@ -692,7 +689,7 @@ func (bc *BarChart) getAdjustedHorizontalCanvasBox(r render.Renderer, canvasBox
 		axesOuterBox = axesOuterBox.Grow(xbox)
 	}
 
-	return canvasBox.OuterConstrain(bc.box(), axesOuterBox)
+	return canvasBox.OuterConstrain(bc.getDefaultCanvasBox(r), axesOuterBox)
 }
 
 // box returns the chart bounds as a box.
@@ -722,8 +719,9 @@ func (bc *BarChart) styleDefaultsBackground() render.Style {
 
 func (bc *BarChart) styleDefaultsBar(index int) render.Style {
 	return render.Style{
-		StrokeColor: bc.GetColorPalette().GetSeriesColor(index),
-		FillColor:   bc.GetColorPalette().GetSeriesColor(index),
+		StrokeColor:   bc.GetColorPalette().GetSeriesColor(index),
+		FillColor:     bc.GetColorPalette().GetSeriesColor(index),
+		ErrorBarColor: bc.GetColorPalette().TextColor(),
 	}
 }
 
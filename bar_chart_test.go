@@ -0,0 +1,59 @@
+package unichart
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/unidoc/unichart/dataset"
+	"github.com/unidoc/unichart/render/raster"
+	"github.com/unidoc/unichart/render/svg"
+)
+
+func TestBarChartErrorBars(t *testing.T) {
+	bc := BarChart{
+		Bars: []dataset.Value{
+			{Label: "a", Value: 1},
+			{Label: "b", Value: 2},
+		},
+		ErrorBars: dataset.ErrorBars{YErrors: []float64{0.5, 1}},
+	}
+	require.NoError(t, bc.Render(raster.NewRenderer, bytes.NewBuffer(nil)))
+
+	bc.ErrorBars.YErrors = []float64{0.5, 1, 2}
+	require.EqualError(t, bc.Render(raster.NewRenderer, bytes.NewBuffer(nil)),
+		"error bars; must not have more errors than values")
+
+	bc.ErrorBars.YErrors = []float64{0.5, -1}
+	require.EqualError(t, bc.Render(raster.NewRenderer, bytes.NewBuffer(nil)),
+		"error bars; errors must not be negative")
+}
+
+func TestBarChartYAxisTicks(t *testing.T) {
+	bc := BarChart{
+		Bars: []dataset.Value{
+			{Label: "a", Value: 1},
+			{Label: "b", Value: 2},
+		},
+		YAxis: YAxis{Ticks: []Tick{{Value: 0, Label: "0"}, {Value: 1, Label: "1"}, {Value: 2, Label: "2"}}},
+	}
+	paths := func() int {
+		buf := bytes.NewBuffer(nil)
+		require.NoError(t, bc.Render(svg.NewRenderer, buf))
+		return strings.Count(buf.String(), "<path")
+	}
+	major := paths()
+
+	bc.YAxis.MinorTickCount = 3
+	require.Equal(t, major+6, paths())
+
+	bc.YAxis.MinorTickCount = 0
+	bc.YAxis.TickLength = 12
+	bc.YAxis.TickDirection = TickDirectionCross
+	buf := bytes.NewBuffer(nil)
+	require.NoError(t, bc.Render(svg.NewRenderer, buf))
+	require.Equal(t, major, strings.Count(buf.String(), "<path"))
+	require.Contains(t, buf.String(), `<path d="M 996 195 L 1008 195"`)
+}
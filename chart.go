@@ -14,8 +14,12 @@ import (
 
 // Chart represents a line, curve or histogram chart.
 type Chart struct {
-	Title      string
-	TitleStyle render.Style
+	Title         string
+	TitleStyle    render.Style
+	Subtitle      string
+	SubtitleStyle render.Style
+	Caption       string
+	CaptionStyle  render.Style
 
 	Font         render.Font
 	Background   render.Style
@@ -26,6 +30,15 @@ type Chart struct {
 	YAxis          YAxis
 	YAxisSecondary YAxis
 
+	// XAxisSecondary is drawn above the canvas, if it has a Transform or if
+	// series are bound to it through their XAxis field.
+	XAxisSecondary XAxis
+
+	// YAxes holds additional y-axes, which series reference by ID through
+	// their YAxis field. Axes of type dataset.YAxisSecondary are stacked
+	// left of the secondary axis, and the others right of the primary axis.
+	YAxes []YAxis
+
 	Series   []dataset.Series
 	Elements []render.Renderable
 
@@ -86,11 +99,24 @@ func (c *Chart) Render(rp render.RendererProvider, w io.Writer) error {
 	if len(c.Series) == 0 {
 		return errors.New("please provide at least one series")
 	}
+
+	// Series whose values change while rendering are replaced by snapshots,
+	// so that the ranges and the drawing use the same values.
+	if series, ok := c.getSeriesSnapshots(); ok {
+		sc := *c
+		sc.Series = series
+		return sc.Render(rp, w)
+	}
+
 	if err := c.checkHasVisibleSeries(); err != nil {
 		return err
 	}
 
+	c.XAxisSecondary.AxisType = dataset.XAxisSecondary
 	c.YAxisSecondary.AxisType = dataset.YAxisSecondary
+	if err := c.checkYAxes(); err != nil {
+		return err
+	}
 
 	r, err := rp(c.Width(), c.Height())
 	if err != nil {
@@ -100,85 +126,65 @@ func (c *Chart) Render(rp render.RendererProvider, w io.Writer) error {
 
 	c.drawBackground(r)
 
-	var xt, yt, yta []Tick
-	xr, yr, yra := c.getRanges()
-	canvasBox := c.getDefaultCanvasBox()
-	xf, yf, yfa := c.getValueFormatters()
-	xr, yr, yra = c.setRangeDomains(canvasBox, xr, yr, yra)
+	var xt, xta, yt, yta []Tick
+	var yts [][]Tick
+	xr, xra, yr, yra, yrs := c.getRanges()
+	canvasBox := c.getDefaultCanvasBox(r)
+	xf, xfa, yf, yfa, yfs := c.getValueFormatters()
+	xr, yr, yra = c.setRangeDomains(canvasBox, xr, xra, yr, yra, yrs)
 
-	err = c.checkRanges(xr, yr, yra)
+	err = c.checkRanges(xr, xra, yr, yra, yrs)
 	if err != nil {
 		r.Save(w)
 		return err
 	}
 
 	if c.hasAxes() {
-		xt, yt, yta = c.getAxesTicks(r, xr, yr, yra, xf, yf, yfa)
+		xt, xta, yt, yta, yts = c.getAxesTicks(r, xr, xra, yr, yra, yrs, xf, xfa, yf, yfa, yfs)
 
 		// Adjust domain range before adjusting the canvas box
 		// if the generated max tick value is exceeding the original max range.
-		if len(xt) > 0 {
-			firstXTick := xt[0].Value
-			lastXTick := xt[len(xt)-1].Value
-
-			if xr.IsDescending() {
-				xr.SetMin(lastXTick)
-				xr.SetMax(firstXTick)
-			} else {
-				xr.SetMin(firstXTick)
-				xr.SetMax(lastXTick)
-			}
+		xt = c.XAxis.RangePolicy.fitRange(xr, xt)
+		yt = c.YAxis.RangePolicy.fitRange(yr, yt)
+		yta = c.YAxisSecondary.RangePolicy.fitRange(yra, yta)
+		for index, ticks := range yts {
+			yts[index] = c.YAxes[index].RangePolicy.fitRange(yrs[index], ticks)
 		}
-
-		if len(yt) > 0 {
-			firstYTick := yt[0].Value
-			lastYTick := yt[len(yt)-1].Value
-
-			if yr.IsDescending() {
-				yr.SetMin(lastYTick)
-				yr.SetMax(firstYTick)
-			} else {
-				yr.SetMin(firstYTick)
-				yr.SetMax(lastYTick)
-			}
+		if c.XAxisSecondary.Transform != nil {
+			// The transformed axis follows the adjusted primary range.
+			xta = c.getSecondaryXAxisTicks(r, xra, xfa)
+		} else {
+			xta = c.XAxisSecondary.RangePolicy.fitRange(xra, xta)
 		}
 
-		if len(yta) > 0 {
-			firstYTick := yta[0].Value
-			lastYTick := yta[len(yta)-1].Value
-
-			if yra.IsDescending() {
-				yra.SetMin(lastYTick)
-				yra.SetMax(firstYTick)
-			} else {
-				yra.SetMin(firstYTick)
-				yra.SetMax(lastYTick)
-			}
-		}
+		xr, yr, yra = c.setRangeDomains(canvasBox, xr, xra, yr, yra, yrs)
 
-		xr, yr, yra = c.setRangeDomains(canvasBox, xr, yr, yra)
-
-		canvasBox = c.getAxesAdjustedCanvasBox(r, canvasBox, xr, yr, yra, xt, yt, yta)
-		xr, yr, yra = c.setRangeDomains(canvasBox, xr, yr, yra)
+		canvasBox = c.getAxesAdjustedCanvasBox(r, canvasBox, xr, xra, yr, yra, yrs, xt, xta, yt, yta, yts)
+		xr, yr, yra = c.setRangeDomains(canvasBox, xr, xra, yr, yra, yrs)
 
 		// do a second pass in case things haven't settled yet.
-		canvasBox = c.getAxesAdjustedCanvasBox(r, canvasBox, xr, yr, yra, xt, yt, yta)
-		xr, yr, yra = c.setRangeDomains(canvasBox, xr, yr, yra)
+		canvasBox = c.getAxesAdjustedCanvasBox(r, canvasBox, xr, xra, yr, yra, yrs, xt, xta, yt, yta, yts)
+		xr, yr, yra = c.setRangeDomains(canvasBox, xr, xra, yr, yra, yrs)
 	}
 
 	if c.hasAnnotationSeries() {
-		canvasBox = c.getAnnotationAdjustedCanvasBox(r, canvasBox, xr, yr, yra, xf, yf, yfa)
-		xr, yr, yra = c.setRangeDomains(canvasBox, xr, yr, yra)
-		xt, yt, yta = c.getAxesTicks(r, xr, yr, yra, xf, yf, yfa)
+		canvasBox = c.getAnnotationAdjustedCanvasBox(r, canvasBox, xr, yr, yra, yrs)
+		xr, yr, yra = c.setRangeDomains(canvasBox, xr, xra, yr, yra, yrs)
+		xt, xta, yt, yta, yts = c.getAxesTicks(r, xr, xra, yr, yra, yrs, xf, xfa, yf, yfa, yfs)
 	}
 
 	c.drawCanvas(r, canvasBox)
-	c.drawAxes(r, canvasBox, xr, yr, yra, xt, yt, yta)
+	c.drawAxes(r, canvasBox, xr, xra, yr, yra, yrs, xt, xta, yt, yta, yts)
+	placements := c.getAnnotationPlacements(r, canvasBox, xr, yr, yra, yrs)
 	for index, series := range c.Series {
-		c.drawSeries(r, canvasBox, xr, yr, yra, series, index)
+		if boxes, isPlaced := placements[index]; isPlaced {
+			c.drawAnnotationSeries(r, canvasBox, xr, yr, yra, yrs, series.(dataset.AnnotationSeries), index, boxes)
+			continue
+		}
+		c.drawSeries(r, canvasBox, xr, xra, yr, yra, yrs, series, index)
 	}
 
-	c.drawYAxisLine(r, canvasBox, xr, yr, yra, xt, yt, yta)
+	c.drawYAxisLine(r, canvasBox, yr, yra, yrs, yt, yta, yts)
 	c.drawTitle(r)
 
 	for _, a := range c.Elements {
@@ -188,6 +194,21 @@ func (c *Chart) Render(rp render.RendererProvider, w io.Writer) error {
 	return r.Save(w)
 }
 
+// getSeriesSnapshots returns the series of the chart, with the snapshot
+// providers replaced by their snapshots, and if there were any.
+func (c *Chart) getSeriesSnapshots() ([]dataset.Series, bool) {
+	var found bool
+	series := make([]dataset.Series, len(c.Series))
+	for i, s := range c.Series {
+		series[i] = s
+		if sp, ok := s.(dataset.SnapshotProvider); ok {
+			series[i] = sp.GetSnapshot()
+			found = true
+		}
+	}
+	return series, found
+}
+
 func (c *Chart) checkHasVisibleSeries() error {
 	var style render.Style
 	for _, s := range c.Series {
@@ -210,74 +231,137 @@ func (c *Chart) validateSeries() error {
 	return nil
 }
 
-func (c *Chart) getRanges() (xrange, yrange, yrangeAlt sequence.Range) {
+func (c *Chart) getRanges() (xrange, xrangeAlt, yrange, yrangeAlt sequence.Range, yranges []sequence.Range) {
 	var minx, maxx float64 = math.MaxFloat64, -math.MaxFloat64
+	var minxa, maxxa float64 = math.MaxFloat64, -math.MaxFloat64
 	var miny, maxy float64 = math.MaxFloat64, -math.MaxFloat64
 	var minya, maxya float64 = math.MaxFloat64, -math.MaxFloat64
 
+	minys, maxys := make([]float64, len(c.YAxes)), make([]float64, len(c.YAxes))
+	for index := range c.YAxes {
+		minys[index], maxys[index] = math.MaxFloat64, -math.MaxFloat64
+	}
+
 	seriesMappedToSecondaryAxis := false
+	addX := func(seriesAxis dataset.XAxisType, vx float64) {
+		if seriesAxis == dataset.XAxisSecondary {
+			minxa = math.Min(minxa, vx)
+			maxxa = math.Max(maxxa, vx)
+		} else {
+			minx = math.Min(minx, vx)
+			maxx = math.Max(maxx, vx)
+		}
+	}
+	addY := func(seriesAxis dataset.YAxisType, vy float64) {
+		if seriesAxis == dataset.YAxisSecondary {
+			minya = math.Min(minya, vy)
+			maxya = math.Max(maxya, vy)
+			seriesMappedToSecondaryAxis = true
+		} else if index := c.getYAxisIndex(seriesAxis); index >= 0 {
+			minys[index] = math.Min(minys[index], vy)
+			maxys[index] = math.Max(maxys[index], vy)
+		} else {
+			miny = math.Min(miny, vy)
+			maxy = math.Max(maxy, vy)
+		}
+	}
 
 	// Note: a possible future optimization is to not scan the series values
 	// if all axis are represented by either custom ticks or custom ranges.
 	for _, s := range c.Series {
 		if !s.GetStyle().Hidden {
 			seriesAxis := s.GetYAxis()
+			seriesXAxis := c.getSeriesXAxis(s)
 			if bvp, isBoundedValuesProvider := s.(dataset.BoundedValuesProvider); isBoundedValuesProvider {
 				seriesLength := bvp.Len()
 				for index := 0; index < seriesLength; index++ {
 					vx, vy1, vy2 := bvp.GetBoundedValues(index)
+					if !mathutil.IsFinite(vx) {
+						continue
+					}
+
+					addX(seriesXAxis, vx)
 
-					minx = math.Min(minx, vx)
-					maxx = math.Max(maxx, vx)
-
-					if seriesAxis == dataset.YAxisPrimary {
-						miny = math.Min(miny, vy1)
-						miny = math.Min(miny, vy2)
-						maxy = math.Max(maxy, vy1)
-						maxy = math.Max(maxy, vy2)
-					} else if seriesAxis == dataset.YAxisSecondary {
-						minya = math.Min(minya, vy1)
-						minya = math.Min(minya, vy2)
-						maxya = math.Max(maxya, vy1)
-						maxya = math.Max(maxya, vy2)
-						seriesMappedToSecondaryAxis = true
+					for _, vy := range []float64{vy1, vy2} {
+						if !mathutil.IsFinite(vy) {
+							continue
+						}
+						addY(seriesAxis, vy)
 					}
 				}
 			} else if vp, isValuesProvider := s.(dataset.ValuesProvider); isValuesProvider {
+				ebp, isErrorBoundsProvider := s.(dataset.ErrorBoundsProvider)
+				zeroGaps := s.GetStyle().LineGapMode == render.LineGapModeZero
+
 				seriesLength := vp.Len()
 				for index := 0; index < seriesLength; index++ {
 					vx, vy := vp.GetValues(index)
+					if !mathutil.IsFinite(vx) {
+						continue
+					}
+					if !mathutil.IsFinite(vy) {
+						// Missing values of lines drawn as zero are part of
+						// the ranges.
+						if zeroGaps {
+							addX(seriesXAxis, vx)
+							addY(seriesAxis, 0)
+						}
+						continue
+					}
 
-					minx = math.Min(minx, vx)
-					maxx = math.Max(maxx, vx)
+					xvalues, yvalues := []float64{vx}, []float64{vy}
+					if isErrorBoundsProvider {
+						xlow, xhigh, ylow, yhigh := ebp.GetErrorBounds(index)
+						xvalues = append(xvalues, xlow, xhigh)
+						yvalues = append(yvalues, ylow, yhigh)
+					}
+
+					for _, vx := range xvalues {
+						addX(seriesXAxis, vx)
+					}
 
-					if seriesAxis == dataset.YAxisPrimary {
-						miny = math.Min(miny, vy)
-						maxy = math.Max(maxy, vy)
-					} else if seriesAxis == dataset.YAxisSecondary {
-						minya = math.Min(minya, vy)
-						maxya = math.Max(maxya, vy)
-						seriesMappedToSecondaryAxis = true
+					for _, vy := range yvalues {
+						addY(seriesAxis, vy)
+					}
+				}
+			}
+
+			if abp, isAxisBoundsProvider := s.(dataset.AxisBoundsProvider); isAxisBoundsProvider {
+				xvalues, yvalues := abp.GetAxisBounds()
+				for _, vx := range xvalues {
+					if mathutil.IsFinite(vx) {
+						addX(seriesXAxis, vx)
+					}
+				}
+				for _, vy := range yvalues {
+					if mathutil.IsFinite(vy) {
+						addY(seriesAxis, vy)
 					}
 				}
 			}
 		}
 	}
 
+	// Charts whose series are all bound to the secondary x-axis show the
+	// same values on the primary axis.
+	if minx > maxx {
+		minx, maxx = minxa, maxxa
+	}
+
 	if c.XAxis.Range == nil {
-		xrange = &sequence.ContinuousRange{}
+		xrange = &sequence.ContinuousRange{Descending: c.XAxis.Reversed}
 	} else {
 		xrange = c.XAxis.Range
 	}
 
 	if c.YAxis.Range == nil {
-		yrange = &sequence.ContinuousRange{}
+		yrange = &sequence.ContinuousRange{Descending: c.YAxis.Reversed}
 	} else {
 		yrange = c.YAxis.Range
 	}
 
 	if c.YAxisSecondary.Range == nil {
-		yrangeAlt = &sequence.ContinuousRange{}
+		yrangeAlt = &sequence.ContinuousRange{Descending: c.YAxisSecondary.Reversed}
 	} else {
 		yrangeAlt = c.YAxisSecondary.Range
 	}
@@ -291,8 +375,10 @@ func (c *Chart) getRanges() (xrange, yrange, yrangeAlt sequence.Range) {
 		xrange.SetMin(tickMin)
 		xrange.SetMax(tickMax)
 	} else if xrange.IsZero() {
-		xrange.SetMin(minx)
-		xrange.SetMax(maxx)
+		round, _ := c.XAxis.RangePolicy.rounding(false)
+		min, max := c.XAxis.RangePolicy.apply(minx, maxx, round)
+		xrange.SetMin(min)
+		xrange.SetMax(max)
 	}
 
 	if len(c.YAxis.Ticks) > 0 {
@@ -304,17 +390,10 @@ func (c *Chart) getRanges() (xrange, yrange, yrangeAlt sequence.Range) {
 		yrange.SetMin(tickMin)
 		yrange.SetMax(tickMax)
 	} else if yrange.IsZero() {
-		yrange.SetMin(miny)
-		yrange.SetMax(maxy)
-
-		if !c.YAxis.Style.Hidden {
-			delta := yrange.GetDelta()
-			roundTo := mathutil.RoundTo(delta)
-			rmin, rmax := mathutil.RoundDown(yrange.GetMin(), roundTo), mathutil.RoundUp(yrange.GetMax(), roundTo)
-
-			yrange.SetMin(rmin)
-			yrange.SetMax(rmax)
-		}
+		round, _ := c.YAxis.RangePolicy.rounding(!c.YAxis.Style.Hidden)
+		min, max := c.YAxis.RangePolicy.apply(miny, maxy, round)
+		yrange.SetMin(min)
+		yrange.SetMax(max)
 	}
 
 	if len(c.YAxisSecondary.Ticks) > 0 {
@@ -326,22 +405,136 @@ func (c *Chart) getRanges() (xrange, yrange, yrangeAlt sequence.Range) {
 		yrangeAlt.SetMin(tickMin)
 		yrangeAlt.SetMax(tickMax)
 	} else if seriesMappedToSecondaryAxis && yrangeAlt.IsZero() {
-		yrangeAlt.SetMin(minya)
-		yrangeAlt.SetMax(maxya)
+		round, _ := c.YAxisSecondary.RangePolicy.rounding(!c.YAxisSecondary.Style.Hidden)
+		min, max := c.YAxisSecondary.RangePolicy.apply(minya, maxya, round)
+		yrangeAlt.SetMin(min)
+		yrangeAlt.SetMax(max)
+	}
 
-		if !c.YAxisSecondary.Style.Hidden {
-			delta := yrangeAlt.GetDelta()
-			roundTo := mathutil.RoundTo(delta)
-			rmin, rmax := mathutil.RoundDown(yrangeAlt.GetMin(), roundTo), mathutil.RoundUp(yrangeAlt.GetMax(), roundTo)
-			yrangeAlt.SetMin(rmin)
-			yrangeAlt.SetMax(rmax)
-		}
+	yranges = make([]sequence.Range, len(c.YAxes))
+	for index, ya := range c.YAxes {
+		yranges[index] = getAxisRange(ya.Range, ya.Reversed, ya.Ticks, ya.RangePolicy, !ya.Style.Hidden, minys[index], maxys[index])
 	}
 
+	if c.XAxisSecondary.Transform != nil {
+		xrangeAlt = &sequence.TransformedRange{Base: xrange, Transform: c.XAxisSecondary.Transform}
+	} else if c.hasSecondaryXAxis() {
+		xrangeAlt = getAxisRange(c.XAxisSecondary.Range, c.XAxisSecondary.Reversed, c.XAxisSecondary.Ticks, c.XAxisSecondary.RangePolicy, false, minxa, maxxa)
+	}
 	return
 }
 
-func (c *Chart) checkRanges(xr, yr, yra sequence.Range) error {
+// getAxisRange returns the range of an additional axis, given the smallest
+// and largest values of its series. Ranges derived from values are adjusted
+// by the range policy, and rounded by default if specified.
+func getAxisRange(ra sequence.Range, reversed bool, ticks []Tick, rp RangePolicy, roundsByDefault bool, min, max float64) sequence.Range {
+	if ra == nil {
+		ra = &sequence.ContinuousRange{Descending: reversed}
+	}
+
+	if len(ticks) > 0 {
+		tickMin, tickMax := math.MaxFloat64, -math.MaxFloat64
+		for _, t := range ticks {
+			tickMin = math.Min(tickMin, t.Value)
+			tickMax = math.Max(tickMax, t.Value)
+		}
+		ra.SetMin(tickMin)
+		ra.SetMax(tickMax)
+	} else if ra.IsZero() && min <= max {
+		round, _ := rp.rounding(roundsByDefault)
+		min, max = rp.apply(min, max, round)
+		ra.SetMin(min)
+		ra.SetMax(max)
+	}
+	return ra
+}
+
+// setRangeToTicks sets the bounds of a range to the first and last ticks,
+// if any.
+func setRangeToTicks(ra sequence.Range, ticks []Tick) {
+	if ra == nil || len(ticks) == 0 {
+		return
+	}
+
+	first, last := ticks[0].Value, ticks[len(ticks)-1].Value
+	if ra.IsDescending() {
+		ra.SetMin(last)
+		ra.SetMax(first)
+	} else {
+		ra.SetMin(first)
+		ra.SetMax(last)
+	}
+}
+
+// checkYAxes checks that the additional y-axes have unique IDs, which do
+// not refer to the primary and secondary axes.
+func (c *Chart) checkYAxes() error {
+	ids := map[dataset.YAxisType]bool{}
+	for _, ya := range c.YAxes {
+		if ya.ID == dataset.YAxisPrimary || ya.ID == dataset.YAxisSecondary {
+			return fmt.Errorf("invalid y-axis id %d; ids of additional y-axes must not refer to the primary or secondary axes", ya.ID)
+		}
+		if ids[ya.ID] {
+			return fmt.Errorf("duplicate y-axis id %d", ya.ID)
+		}
+		ids[ya.ID] = true
+	}
+	return nil
+}
+
+// getYAxisIndex returns the index of the additional y-axis with the
+// specified ID, or -1 if there is none.
+func (c *Chart) getYAxisIndex(id dataset.YAxisType) int {
+	if id == dataset.YAxisPrimary || id == dataset.YAxisSecondary {
+		return -1
+	}
+	for index, ya := range c.YAxes {
+		if ya.ID == id {
+			return index
+		}
+	}
+	return -1
+}
+
+// getSeriesXAxis returns the x-axis a series draws on. Series are drawn on
+// the primary axis, unless they are bound to a secondary axis which is not
+// derived from the primary one.
+func (c *Chart) getSeriesXAxis(s dataset.Series) dataset.XAxisType {
+	if xap, isXAxisProvider := s.(dataset.XAxisProvider); isXAxisProvider && c.XAxisSecondary.Transform == nil {
+		if xap.GetXAxis() == dataset.XAxisSecondary {
+			return dataset.XAxisSecondary
+		}
+	}
+	return dataset.XAxisPrimary
+}
+
+// hasSecondaryXAxis returns true if the chart has a secondary x-axis, either
+// derived from the primary axis or showing the range of its series.
+func (c *Chart) hasSecondaryXAxis() bool {
+	if c.XAxisSecondary.Transform != nil {
+		return true
+	}
+	for _, s := range c.Series {
+		if !s.GetStyle().Hidden && c.getSeriesXAxis(s) == dataset.XAxisSecondary {
+			return true
+		}
+	}
+	return false
+}
+
+// getYRange returns the range of the y-axis a series draws on. Series
+// referring to unknown axes draw on the primary axis.
+func (c *Chart) getYRange(seriesAxis dataset.YAxisType, yr, yra sequence.Range, yrs []sequence.Range) sequence.Range {
+	if seriesAxis == dataset.YAxisSecondary {
+		return yra
+	}
+	if index := c.getYAxisIndex(seriesAxis); index >= 0 {
+		return yrs[index]
+	}
+	return yr
+}
+
+func (c *Chart) checkRanges(xr, xra, yr, yra sequence.Range, yrs []sequence.Range) error {
 	xDelta := xr.GetDelta()
 	if math.IsInf(xDelta, 0) {
 		return errors.New("infinite x-range delta")
@@ -371,29 +564,65 @@ func (c *Chart) checkRanges(xr, yr, yra sequence.Range) error {
 		}
 	}
 
+	if xra != nil {
+		xraDelta := xra.GetDelta()
+		if math.IsInf(xraDelta, 0) {
+			return errors.New("infinite secondary x-range delta")
+		}
+		if math.IsNaN(xraDelta) {
+			return errors.New("nan secondary x-range delta")
+		}
+	}
+
+	for index, yrange := range yrs {
+		delta := yrange.GetDelta()
+		if math.IsInf(delta, 0) {
+			return fmt.Errorf("infinite y-range delta for y-axis %d", c.YAxes[index].ID)
+		}
+		if math.IsNaN(delta) {
+			return fmt.Errorf("nan y-range delta for y-axis %d", c.YAxes[index].ID)
+		}
+	}
+
 	return nil
 }
 
-func (c *Chart) getDefaultCanvasBox() render.Box {
-	return c.Box()
+func (c *Chart) getDefaultCanvasBox(r render.Renderer) render.Box {
+	return c.getTitles().adjust(r, c.Box())
 }
 
-func (c *Chart) getValueFormatters() (x, y, ya dataset.ValueFormatter) {
+func (c *Chart) getValueFormatters() (x, xa, y, ya dataset.ValueFormatter, ys []dataset.ValueFormatter) {
+	ys = make([]dataset.ValueFormatter, len(c.YAxes))
 	for _, s := range c.Series {
 		if vfp, isVfp := s.(dataset.ValueFormatterProvider); isVfp {
 			sx, sy := vfp.GetValueFormatters()
-			if s.GetYAxis() == dataset.YAxisPrimary {
-				x = sx
+			// Series referencing unknown axes are drawn on the primary axis.
+			if s.GetYAxis() == dataset.YAxisSecondary {
+				ya = sy
+			} else if index := c.getYAxisIndex(s.GetYAxis()); index >= 0 {
+				ys[index] = sy
+			} else {
 				y = sy
-			} else if s.GetYAxis() == dataset.YAxisSecondary {
+			}
+
+			if c.getSeriesXAxis(s) == dataset.XAxisSecondary {
+				xa = sx
+			} else {
 				x = sx
-				ya = sy
 			}
 		}
 	}
+	for index, axis := range c.YAxes {
+		if axis.ValueFormatter != nil {
+			ys[index] = axis.GetValueFormatter()
+		}
+	}
 	if c.XAxis.ValueFormatter != nil {
 		x = c.XAxis.GetValueFormatter()
 	}
+	if c.XAxisSecondary.ValueFormatter != nil {
+		xa = c.XAxisSecondary.GetValueFormatter()
+	}
 	if c.YAxis.ValueFormatter != nil {
 		y = c.YAxis.GetValueFormatter()
 	}
@@ -404,51 +633,139 @@ func (c *Chart) getValueFormatters() (x, y, ya dataset.ValueFormatter) {
 }
 
 func (c *Chart) hasAxes() bool {
+	for _, ya := range c.YAxes {
+		if !ya.Style.Hidden {
+			return true
+		}
+	}
+	if !c.XAxisSecondary.Style.Hidden && c.hasSecondaryXAxis() {
+		return true
+	}
 	return !c.XAxis.Style.Hidden || !c.YAxis.Style.Hidden || !c.YAxisSecondary.Style.Hidden
 }
 
-func (c *Chart) getAxesTicks(r render.Renderer, xr, yr, yar sequence.Range, xf, yf, yfa dataset.ValueFormatter) (xticks, yticks, yticksAlt []Tick) {
+func (c *Chart) getAxesTicks(r render.Renderer, xr, xra, yr, yar sequence.Range, yrs []sequence.Range,
+	xf, xfa, yf, yfa dataset.ValueFormatter, yfs []dataset.ValueFormatter) (xticks, xticksAlt, yticks, yticksAlt []Tick, yticksAdditional [][]Tick) {
 	if !c.XAxis.Style.Hidden {
 		xticks = c.XAxis.GetTicks(r, xr, c.styleDefaultsAxes(), xf)
+		xticks = c.XAxis.RangePolicy.filterTicks(xr, xticks)
 	}
+	xticksAlt = c.getSecondaryXAxisTicks(r, xra, xfa)
 	if !c.YAxis.Style.Hidden {
-		yticks = c.YAxis.GetTicks(r, yr, c.styleDefaultsAxes(), yf)
+		yticks = c.YAxis.GetTicks(r, yr, c.styleDefaultsYAxis(dataset.YAxisPrimary), yf)
+		yticks = c.YAxis.RangePolicy.filterTicks(yr, yticks)
 	}
 	if !c.YAxisSecondary.Style.Hidden {
-		yticksAlt = c.YAxisSecondary.GetTicks(r, yar, c.styleDefaultsAxes(), yfa)
+		yticksAlt = c.YAxisSecondary.GetTicks(r, yar, c.styleDefaultsYAxis(dataset.YAxisSecondary), yfa)
+		yticksAlt = c.YAxisSecondary.RangePolicy.filterTicks(yar, yticksAlt)
+	}
+
+	yticksAdditional = make([][]Tick, len(c.YAxes))
+	for index, ya := range c.YAxes {
+		if !ya.Style.Hidden {
+			ticks := ya.GetTicks(r, yrs[index], c.styleDefaultsYAxis(ya.ID), yfs[index])
+			yticksAdditional[index] = ya.RangePolicy.filterTicks(yrs[index], ticks)
+		}
 	}
 	return
 }
 
-func (c *Chart) getAxesAdjustedCanvasBox(r render.Renderer, canvasBox render.Box, xr, yr, yra sequence.Range, xticks, yticks, yticksAlt []Tick) render.Box {
+// getSecondaryXAxisTicks returns the ticks of the secondary x-axis, if the
+// chart has one. As transformed ranges cannot be adjusted to their ticks,
+// ticks outside of them are left out.
+func (c *Chart) getSecondaryXAxisTicks(r render.Renderer, xra sequence.Range, xfa dataset.ValueFormatter) []Tick {
+	if xra == nil || c.XAxisSecondary.Style.Hidden {
+		return nil
+	}
+
+	ticks := c.XAxisSecondary.GetTicks(r, xra, c.styleDefaultsAxes(), xfa)
+	if c.XAxisSecondary.Transform == nil {
+		return c.XAxisSecondary.RangePolicy.filterTicks(xra, ticks)
+	}
+	return ticksWithin(xra, ticks)
+}
+
+func (c *Chart) getAxesAdjustedCanvasBox(r render.Renderer, canvasBox render.Box, xr, xra, yr, yra sequence.Range, yrs []sequence.Range,
+	xticks, xticksAlt, yticks, yticksAlt []Tick, yticksAdditional [][]Tick) render.Box {
 	axesOuterBox := canvasBox.Clone()
 	if !c.XAxis.Style.Hidden {
 		axesBounds := c.XAxis.Measure(r, canvasBox, xr, c.styleDefaultsAxes(), xticks)
 		axesOuterBox = axesOuterBox.Grow(axesBounds)
 	}
+	if xra != nil && !c.XAxisSecondary.Style.Hidden {
+		axesBounds := c.XAxisSecondary.Measure(r, canvasBox, xra, c.styleDefaultsAxes(), xticksAlt)
+		axesOuterBox = axesOuterBox.Grow(axesBounds)
+	}
 	if !c.YAxis.Style.Hidden {
-		axesBounds := c.YAxis.Measure(r, canvasBox, yr, c.styleDefaultsAxes(), yticks)
+		axesBounds := c.YAxis.Measure(r, canvasBox, yr, c.styleDefaultsYAxis(dataset.YAxisPrimary), yticks)
 		axesOuterBox = axesOuterBox.Grow(axesBounds)
 	}
 	if !c.YAxisSecondary.Style.Hidden {
-		axesBounds := c.YAxisSecondary.Measure(r, canvasBox, yra, c.styleDefaultsAxes(), yticksAlt)
+		axesBounds := c.YAxisSecondary.Measure(r, canvasBox, yra, c.styleDefaultsYAxis(dataset.YAxisSecondary), yticksAlt)
 		axesOuterBox = axesOuterBox.Grow(axesBounds)
 	}
+	for index, ya := range c.getStackedYAxes(r, canvasBox, yr, yra, yrs, yticks, yticksAlt, yticksAdditional) {
+		if !ya.Style.Hidden {
+			axesBounds := ya.Measure(r, canvasBox, yrs[index], c.styleDefaultsYAxis(ya.ID), yticksAdditional[index])
+			axesOuterBox = axesOuterBox.Grow(axesBounds)
+		}
+	}
 
-	return canvasBox.OuterConstrain(c.Box(), axesOuterBox)
+	return canvasBox.OuterConstrain(c.getDefaultCanvasBox(r), axesOuterBox)
 }
 
-func (c *Chart) setRangeDomains(canvasBox render.Box, xr, yr, yra sequence.Range) (sequence.Range, sequence.Range, sequence.Range) {
+// getStackedYAxes returns the additional y-axes, with their offsets set so
+// that they are stacked outside of the primary and secondary axes.
+func (c *Chart) getStackedYAxes(r render.Renderer, canvasBox render.Box, yr, yra sequence.Range, yrs []sequence.Range,
+	yticks, yticksAlt []Tick, yticksAdditional [][]Tick) []YAxis {
+	var right, left int
+	if !c.YAxis.Style.Hidden {
+		box := c.YAxis.Measure(r, canvasBox, yr, c.styleDefaultsYAxis(dataset.YAxisPrimary), yticks)
+		right = mathutil.MaxInt(0, box.Right-canvasBox.Right) + defaultYAxisMargin
+	}
+	if !c.YAxisSecondary.Style.Hidden {
+		box := c.YAxisSecondary.Measure(r, canvasBox, yra, c.styleDefaultsYAxis(dataset.YAxisSecondary), yticksAlt)
+		left = mathutil.MaxInt(0, canvasBox.Left-box.Left) + defaultYAxisMargin
+	}
+
+	axes := make([]YAxis, len(c.YAxes))
+	for index, ya := range c.YAxes {
+		if ya.AxisType != dataset.YAxisSecondary {
+			ya.AxisType = dataset.YAxisPrimary
+		}
+		if !ya.Style.Hidden {
+			if ya.AxisType == dataset.YAxisSecondary {
+				ya.Offset += left
+				box := ya.Measure(r, canvasBox, yrs[index], c.styleDefaultsYAxis(ya.ID), yticksAdditional[index])
+				left = mathutil.MaxInt(ya.Offset, canvasBox.Left-box.Left) + defaultYAxisMargin
+			} else {
+				ya.Offset += right
+				box := ya.Measure(r, canvasBox, yrs[index], c.styleDefaultsYAxis(ya.ID), yticksAdditional[index])
+				right = mathutil.MaxInt(ya.Offset, box.Right-canvasBox.Right) + defaultYAxisMargin
+			}
+		}
+		axes[index] = ya
+	}
+	return axes
+}
+
+func (c *Chart) setRangeDomains(canvasBox render.Box, xr, xra, yr, yra sequence.Range, yrs []sequence.Range) (sequence.Range, sequence.Range, sequence.Range) {
 	xr.SetDomain(canvasBox.Width())
+	if xra != nil {
+		xra.SetDomain(canvasBox.Width())
+	}
 	yr.SetDomain(canvasBox.Height())
 	yra.SetDomain(canvasBox.Height())
+	for _, yrange := range yrs {
+		yrange.SetDomain(canvasBox.Height())
+	}
 	return xr, yr, yra
 }
 
 func (c *Chart) hasAnnotationSeries() bool {
 	for _, s := range c.Series {
-		if as, isAnnotationSeries := s.(dataset.AnnotationSeries); isAnnotationSeries {
-			if !as.GetStyle().Hidden {
+		if ms, isMeasurable := s.(dataset.MeasurableSeries); isMeasurable {
+			if !ms.GetStyle().Hidden {
 				return true
 			}
 		}
@@ -465,25 +782,56 @@ func (c *Chart) hasSecondarySeries() bool {
 	return false
 }
 
-func (c *Chart) getAnnotationAdjustedCanvasBox(r render.Renderer, canvasBox render.Box, xr, yr, yra sequence.Range, xf, yf, yfa dataset.ValueFormatter) render.Box {
+func (c *Chart) getAnnotationAdjustedCanvasBox(r render.Renderer, canvasBox render.Box, xr, yr, yra sequence.Range, yrs []sequence.Range) render.Box {
 	annotationSeriesBox := canvasBox.Clone()
+	for _, boxes := range c.getAnnotationPlacements(r, canvasBox, xr, yr, yra, yrs) {
+		for _, box := range boxes {
+			annotationSeriesBox = annotationSeriesBox.Grow(box)
+		}
+	}
+
 	for seriesIndex, s := range c.Series {
-		if as, isAnnotationSeries := s.(dataset.AnnotationSeries); isAnnotationSeries {
-			if !as.GetStyle().Hidden {
+		if _, isAnnotationSeries := s.(dataset.AnnotationSeries); isAnnotationSeries {
+			continue
+		}
+		if ms, isMeasurable := s.(dataset.MeasurableSeries); isMeasurable {
+			if !ms.GetStyle().Hidden {
 				style := c.styleDefaultsSeries(seriesIndex)
-				var annotationBounds render.Box
-				if as.YAxis == dataset.YAxisPrimary {
-					annotationBounds = as.Measure(r, canvasBox, xr, yr, style)
-				} else if as.YAxis == dataset.YAxisSecondary {
-					annotationBounds = as.Measure(r, canvasBox, xr, yra, style)
-				}
-
+				annotationBounds := ms.Measure(r, canvasBox, xr, c.getYRange(ms.GetYAxis(), yr, yra, yrs), style)
 				annotationSeriesBox = annotationSeriesBox.Grow(annotationBounds)
 			}
 		}
 	}
 
-	return canvasBox.OuterConstrain(c.Box(), annotationSeriesBox)
+	return canvasBox.OuterConstrain(c.getDefaultCanvasBox(r), annotationSeriesBox)
+}
+
+// getAnnotationPlacements returns the annotation boxes of the annotation
+// series, by series index, placed so that the annotations of all the series
+// don't overlap each other.
+func (c *Chart) getAnnotationPlacements(r render.Renderer, canvasBox render.Box, xr, yr, yra sequence.Range, yrs []sequence.Range) map[int][]render.Box {
+	var boxes []render.Box
+	var seriesIndices []int
+	for seriesIndex, s := range c.Series {
+		as, isAnnotationSeries := s.(dataset.AnnotationSeries)
+		if !isAnnotationSeries || as.Style.Hidden {
+			continue
+		}
+
+		style := c.styleDefaultsSeries(seriesIndex)
+		seriesBoxes := as.MeasureAnnotations(r, canvasBox, xr, c.getYRange(as.YAxis, yr, yra, yrs), style)
+
+		for range seriesBoxes {
+			seriesIndices = append(seriesIndices, seriesIndex)
+		}
+		boxes = append(boxes, seriesBoxes...)
+	}
+
+	placements := map[int][]render.Box{}
+	for i, box := range dataset.PlaceAnnotationBoxes(boxes, canvasBox) {
+		placements[seriesIndices[i]] = append(placements[seriesIndices[i]], box)
+	}
+	return placements
 }
 
 func (c *Chart) getBackgroundStyle() render.Style {
@@ -505,54 +853,64 @@ func (c *Chart) drawCanvas(r render.Renderer, canvasBox render.Box) {
 	canvasBox.Draw(r, c.getCanvasStyle())
 }
 
-func (c *Chart) drawAxes(r render.Renderer, canvasBox render.Box, xrange, yrange, yrangeAlt sequence.Range, xticks, yticks, yticksAlt []Tick) {
+func (c *Chart) drawAxes(r render.Renderer, canvasBox render.Box, xrange, xrangeAlt, yrange, yrangeAlt sequence.Range, yranges []sequence.Range,
+	xticks, xticksAlt, yticks, yticksAlt []Tick, yticksAdditional [][]Tick) {
 	if !c.XAxis.Style.Hidden {
 		c.XAxis.Render(r, canvasBox, xrange, c.styleDefaultsAxes(), xticks)
 	}
+	if xrangeAlt != nil && !c.XAxisSecondary.Style.Hidden {
+		c.XAxisSecondary.Render(r, canvasBox, xrangeAlt, c.styleDefaultsAxes(), xticksAlt)
+	}
 	if !c.YAxis.Style.Hidden {
-		c.YAxis.Render(r, canvasBox, yrange, c.styleDefaultsAxes(), yticks)
+		c.YAxis.Render(r, canvasBox, yrange, c.styleDefaultsYAxis(dataset.YAxisPrimary), yticks)
 	}
 	if !c.YAxisSecondary.Style.Hidden {
-		c.YAxisSecondary.Render(r, canvasBox, yrangeAlt, c.styleDefaultsAxes(), yticksAlt)
+		c.YAxisSecondary.Render(r, canvasBox, yrangeAlt, c.styleDefaultsYAxis(dataset.YAxisSecondary), yticksAlt)
+	}
+	for index, ya := range c.getStackedYAxes(r, canvasBox, yrange, yrangeAlt, yranges, yticks, yticksAlt, yticksAdditional) {
+		if !ya.Style.Hidden {
+			ya.Render(r, canvasBox, yranges[index], c.styleDefaultsYAxis(ya.ID), yticksAdditional[index])
+		}
 	}
 }
 
-func (c *Chart) drawYAxisLine(r render.Renderer, canvasBox render.Box, xrange, yrange, yrangeAlt sequence.Range, xticks, yticks, yticksAlt []Tick) {
+func (c *Chart) drawYAxisLine(r render.Renderer, canvasBox render.Box, yrange, yrangeAlt sequence.Range, yranges []sequence.Range,
+	yticks, yticksAlt []Tick, yticksAdditional [][]Tick) {
 	if !c.YAxis.Style.Hidden {
-		c.YAxis.RenderAxisLine(r, canvasBox, yrange, c.styleDefaultsAxes(), yticks)
+		c.YAxis.RenderAxisLine(r, canvasBox, yrange, c.styleDefaultsYAxis(dataset.YAxisPrimary), yticks)
 	}
 	if !c.YAxisSecondary.Style.Hidden {
-		c.YAxisSecondary.RenderAxisLine(r, canvasBox, yrangeAlt, c.styleDefaultsAxes(), yticksAlt)
+		c.YAxisSecondary.RenderAxisLine(r, canvasBox, yrangeAlt, c.styleDefaultsYAxis(dataset.YAxisSecondary), yticksAlt)
+	}
+	for index, ya := range c.getStackedYAxes(r, canvasBox, yrange, yrangeAlt, yranges, yticks, yticksAlt, yticksAdditional) {
+		if !ya.Style.Hidden {
+			ya.RenderAxisLine(r, canvasBox, yranges[index], c.styleDefaultsYAxis(ya.ID), yticksAdditional[index])
+		}
 	}
 }
 
-func (c *Chart) drawSeries(r render.Renderer, canvasBox render.Box, xrange, yrange, yrangeAlt sequence.Range, s dataset.Series, seriesIndex int) {
+func (c *Chart) drawSeries(r render.Renderer, canvasBox render.Box, xrange, xrangeAlt, yrange, yrangeAlt sequence.Range, yranges []sequence.Range,
+	s dataset.Series, seriesIndex int) {
 	if !s.GetStyle().Hidden {
-		if s.GetYAxis() == dataset.YAxisPrimary {
-			s.Render(r, canvasBox, xrange, yrange, c.styleDefaultsSeries(seriesIndex))
-		} else if s.GetYAxis() == dataset.YAxisSecondary {
-			s.Render(r, canvasBox, xrange, yrangeAlt, c.styleDefaultsSeries(seriesIndex))
+		if c.getSeriesXAxis(s) == dataset.XAxisSecondary {
+			xrange = xrangeAlt
 		}
+		s.Render(r, canvasBox, xrange, c.getYRange(s.GetYAxis(), yrange, yrangeAlt, yranges), c.styleDefaultsSeries(seriesIndex))
 	}
 }
 
-func (c *Chart) drawTitle(r render.Renderer) {
-	if len(c.Title) > 0 && !c.TitleStyle.Hidden {
-		r.SetFont(c.TitleStyle.GetFont(c.GetFont()))
-		r.SetFontColor(c.TitleStyle.GetFontColor(c.GetColorPalette().TextColor()))
-		titleFontSize := c.TitleStyle.GetFontSize(defaultTitleFontSize)
-		r.SetFontSize(titleFontSize)
-
-		textBox := r.MeasureText(c.Title)
-
-		textWidth := textBox.Width()
-		textHeight := textBox.Height()
+func (c *Chart) drawAnnotationSeries(r render.Renderer, canvasBox render.Box, xrange, yrange, yrangeAlt sequence.Range, yranges []sequence.Range,
+	as dataset.AnnotationSeries, seriesIndex int, boxes []render.Box) {
+	as.RenderPlaced(r, canvasBox, xrange, c.getYRange(as.YAxis, yrange, yrangeAlt, yranges), c.styleDefaultsSeries(seriesIndex), boxes)
+}
 
-		titleX := (c.Width() >> 1) - (textWidth >> 1)
-		titleY := c.TitleStyle.Padding.GetTop(defaultTitleTop) + textHeight
+func (c *Chart) drawTitle(r render.Renderer) {
+	c.getTitles().draw(r, c.Box())
+}
 
-		r.Text(c.Title, titleX, titleY)
-	}
+func (c *Chart) getTitles() chartTitles {
+	return newChartTitles(c.styleDefaultsTitle(),
+		c.Title, c.TitleStyle, c.Subtitle, c.SubtitleStyle, c.Caption, c.CaptionStyle)
 }
 
 func (c *Chart) styleDefaultsBackground() render.Style {
@@ -591,6 +949,40 @@ func (c *Chart) styleDefaultsAxes() render.Style {
 	}
 }
 
+// styleDefaultsYAxis returns the default style of a y-axis. Charts with
+// additional y-axes color each axis like the first series drawn on it.
+func (c *Chart) styleDefaultsYAxis(axis dataset.YAxisType) render.Style {
+	style := c.styleDefaultsAxes()
+	if len(c.YAxes) == 0 {
+		return style
+	}
+
+	for index, s := range c.Series {
+		seriesAxis := s.GetYAxis()
+		if seriesAxis != dataset.YAxisSecondary && c.getYAxisIndex(seriesAxis) < 0 {
+			seriesAxis = dataset.YAxisPrimary
+		}
+		if seriesAxis != axis || s.GetStyle().Hidden {
+			continue
+		}
+
+		color := s.GetStyle().GetStrokeColor(c.GetColorPalette().GetSeriesColor(index))
+		style.StrokeColor = color
+		style.FontColor = color
+		break
+	}
+	return style
+}
+
+func (c *Chart) styleDefaultsTitle() render.Style {
+	return render.Style{
+		Font:                c.GetFont(),
+		FontColor:           c.GetColorPalette().TextColor(),
+		FontSize:            defaultTitleFontSize,
+		TextHorizontalAlign: render.TextHorizontalAlignCenter,
+	}
+}
+
 func (c *Chart) styleDefaultsElements() render.Style {
 	return render.Style{
 		Font: c.GetFont(),
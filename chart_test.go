@@ -0,0 +1,361 @@
+package unichart
+
+import (
+	"bytes"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/unidoc/unichart/dataset"
+	"github.com/unidoc/unichart/dataset/sequence"
+	"github.com/unidoc/unichart/render"
+	"github.com/unidoc/unichart/render/raster"
+)
+
+func TestChartGetRangesIgnoresNaN(t *testing.T) {
+	c := Chart{
+		Series: []dataset.Series{
+			dataset.ContinuousSeries{
+				XValues: []float64{1, 2, 3, math.NaN(), 5},
+				YValues: []float64{2, math.NaN(), 4, 10, math.Inf(-1)},
+			},
+		},
+	}
+
+	xr, _, yr, _, _ := c.getRanges()
+	require.Equal(t, 1.0, xr.GetMin())
+	require.Equal(t, 3.0, xr.GetMax())
+	require.Equal(t, 2.0, yr.GetMin())
+	require.Equal(t, 4.0, yr.GetMax())
+
+	// Missing values drawn as zero are part of the ranges.
+	c.Series[0] = dataset.ContinuousSeries{
+		Style:   render.Style{LineGapMode: render.LineGapModeZero},
+		XValues: []float64{1, 2, 3, math.NaN(), 5},
+		YValues: []float64{2, math.NaN(), 4, 10, math.Inf(-1)},
+	}
+	xr, _, yr, _, _ = c.getRanges()
+	require.Equal(t, 1.0, xr.GetMin())
+	require.Equal(t, 5.0, xr.GetMax())
+	require.Equal(t, 0.0, yr.GetMin())
+	require.Equal(t, 4.0, yr.GetMax())
+}
+
+func TestChartAnnotationPlacementsDoNotOverlap(t *testing.T) {
+	c := Chart{
+		Series: []dataset.Series{
+			dataset.AnnotationSeries{Annotations: []dataset.Value2{{XValue: 10, YValue: 5.0, Label: "a"}}},
+			dataset.ContinuousSeries{XValues: []float64{0, 10}, YValues: []float64{0, 10}},
+			dataset.AnnotationSeries{Annotations: []dataset.Value2{
+				{XValue: 10, YValue: 5.1, Label: "b"},
+				{XValue: 10, YValue: 5.2, Label: "c"},
+			}},
+		},
+	}
+
+	r, err := raster.NewRenderer(200, 200)
+	require.NoError(t, err)
+
+	canvasBox := render.Box{Top: 10, Left: 10, Right: 110, Bottom: 110}
+	xr := &sequence.ContinuousRange{Min: 0, Max: 10, Domain: 100}
+	yr := &sequence.ContinuousRange{Min: 0, Max: 10, Domain: 100}
+
+	placements := c.getAnnotationPlacements(r, canvasBox, xr, yr, yr, nil)
+	require.Len(t, placements, 2)
+	require.Len(t, placements[0], 1)
+	require.Len(t, placements[2], 2)
+
+	boxes := append(placements[0], placements[2]...)
+	for i := range boxes {
+		require.GreaterOrEqual(t, boxes[i].Top, canvasBox.Top)
+		require.LessOrEqual(t, boxes[i].Bottom, canvasBox.Bottom)
+		for j := i + 1; j < len(boxes); j++ {
+			overlaps := boxes[i].Top < boxes[j].Bottom && boxes[j].Top < boxes[i].Bottom
+			require.False(t, overlaps, "boxes %v and %v overlap", boxes[i], boxes[j])
+		}
+	}
+}
+
+func TestChartGetRangesIncludesErrorBars(t *testing.T) {
+	c := Chart{
+		Series: []dataset.Series{
+			dataset.ContinuousSeries{
+				XValues: []float64{1, 2, 3},
+				YValues: []float64{2, 3, 4},
+				ErrorBars: dataset.ErrorBars{
+					XErrors:      []float64{0.5},
+					YErrors:      []float64{0, 0, 1},
+					YLowerErrors: []float64{1.5},
+				},
+			},
+		},
+	}
+
+	xr, _, yr, _, _ := c.getRanges()
+	require.Equal(t, 0.5, xr.GetMin())
+	require.Equal(t, 3.0, xr.GetMax())
+	require.Equal(t, 0.5, yr.GetMin())
+	require.Equal(t, 5.0, yr.GetMax())
+}
+
+func TestChartGetRangesAdditionalYAxes(t *testing.T) {
+	c := Chart{
+		YAxes: []YAxis{
+			{ID: 2, Style: render.Style{Hidden: true}},
+			{ID: 3, Style: render.Style{Hidden: true}, AxisType: dataset.YAxisSecondary},
+		},
+		Series: []dataset.Series{
+			dataset.ContinuousSeries{XValues: []float64{1, 2}, YValues: []float64{10, 20}},
+			dataset.ContinuousSeries{XValues: []float64{1, 2}, YValues: []float64{1000, 1500}, YAxis: 2},
+			dataset.ContinuousSeries{XValues: []float64{1, 2}, YValues: []float64{-5, 5}, YAxis: 3},
+			dataset.ContinuousSeries{XValues: []float64{1, 2}, YValues: []float64{0, 30}, YAxis: 7},
+		},
+	}
+
+	_, _, yr, _, yrs := c.getRanges()
+	require.Equal(t, 0.0, yr.GetMin())
+	require.Equal(t, 30.0, yr.GetMax())
+	require.Len(t, yrs, 2)
+	require.Equal(t, 1000.0, yrs[0].GetMin())
+	require.Equal(t, 1500.0, yrs[0].GetMax())
+	require.Equal(t, -5.0, yrs[1].GetMin())
+	require.Equal(t, 5.0, yrs[1].GetMax())
+}
+
+func TestChartValueFormattersUnknownYAxis(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := Chart{
+		YAxes: []YAxis{{ID: 2}},
+		Series: []dataset.Series{
+			&dataset.ROCSeries{
+				InnerSeries: dataset.TimeSeries{XValues: []time.Time{start, start.AddDate(0, 0, 1)}, YValues: []float64{1, 2}},
+				YAxis:       7,
+			},
+		},
+	}
+
+	// Series referencing unknown axes use the formatters of the primary axis.
+	x, _, y, _, ys := c.getValueFormatters()
+	require.Equal(t, dataset.TimeValueFormatter(start), x(start))
+	require.Equal(t, "50.00%", y(0.5))
+	require.Len(t, ys, 1)
+	require.Nil(t, ys[0])
+}
+
+func TestChartYAxesValidation(t *testing.T) {
+	c := Chart{YAxes: []YAxis{{ID: dataset.YAxisSecondary}}}
+	require.Error(t, c.checkYAxes())
+
+	c = Chart{YAxes: []YAxis{{ID: 2}, {ID: 2}}}
+	require.Error(t, c.checkYAxes())
+
+	c = Chart{YAxes: []YAxis{{ID: 2}, {ID: 3}}}
+	require.NoError(t, c.checkYAxes())
+}
+
+func TestChartStackedYAxes(t *testing.T) {
+	c := Chart{
+		YAxes: []YAxis{
+			{ID: 2},
+			{ID: 3, AxisType: dataset.YAxisSecondary},
+			{ID: 4, Offset: 10},
+		},
+		Series: []dataset.Series{
+			dataset.ContinuousSeries{XValues: []float64{1, 2}, YValues: []float64{10, 20}},
+			dataset.ContinuousSeries{XValues: []float64{1, 2}, YValues: []float64{1, 2}, YAxis: 2},
+			dataset.ContinuousSeries{XValues: []float64{1, 2}, YValues: []float64{3, 4}, YAxis: 3},
+			dataset.ContinuousSeries{XValues: []float64{1, 2}, YValues: []float64{5, 6}, YAxis: 4},
+		},
+	}
+
+	r, err := raster.NewRenderer(c.Width(), c.Height())
+	require.NoError(t, err)
+
+	canvasBox := render.Box{Top: 10, Left: 100, Right: 400, Bottom: 300}
+	_, _, yr, yra, yrs := c.getRanges()
+	c.setRangeDomains(canvasBox, &sequence.ContinuousRange{}, nil, yr, yra, yrs)
+	_, _, _, _, yfs := c.getValueFormatters()
+	_, _, yt, yta, yts := c.getAxesTicks(r, &sequence.ContinuousRange{}, nil, yr, yra, yrs, nil, nil, nil, nil, yfs)
+
+	axes := c.getStackedYAxes(r, canvasBox, yr, yra, yrs, yt, yta, yts)
+	require.Len(t, axes, 3)
+
+	// Axes are stacked outside of the primary and secondary axes, without
+	// overlapping each other.
+	primary := c.YAxis.Measure(r, canvasBox, yr, c.styleDefaultsYAxis(dataset.YAxisPrimary), yt)
+	first := axes[0].Measure(r, canvasBox, yrs[0], c.styleDefaultsYAxis(2), yts[0])
+	require.Equal(t, dataset.YAxisPrimary, axes[0].AxisType)
+	require.Greater(t, first.Left, primary.Right)
+
+	last := axes[2].Measure(r, canvasBox, yrs[2], c.styleDefaultsYAxis(4), yts[2])
+	require.Greater(t, axes[2].Offset, axes[0].Offset+first.Width()+10)
+	require.Greater(t, last.Left, first.Right)
+
+	secondary := c.YAxisSecondary.Measure(r, canvasBox, yra, c.styleDefaultsYAxis(dataset.YAxisSecondary), yta)
+	left := axes[1].Measure(r, canvasBox, yrs[1], c.styleDefaultsYAxis(3), yts[1])
+	require.Equal(t, dataset.YAxisSecondary, axes[1].AxisType)
+	require.Less(t, left.Right, secondary.Left)
+
+	// Axes are colored like their series.
+	require.Equal(t, c.GetColorPalette().GetSeriesColor(1), c.styleDefaultsYAxis(2).StrokeColor)
+	require.Equal(t, c.GetColorPalette().GetSeriesColor(3), c.styleDefaultsYAxis(4).FontColor)
+}
+
+func TestChartSecondaryXAxis(t *testing.T) {
+	c := Chart{
+		Series: []dataset.Series{
+			dataset.ContinuousSeries{XValues: []float64{0, 10}, YValues: []float64{1, 2}},
+			dataset.ContinuousSeries{XValues: []float64{100, 300}, YValues: []float64{1, 2}, XAxis: dataset.XAxisSecondary},
+		},
+	}
+
+	// Series bound to the secondary axis have a separate range.
+	xr, xra, _, _, _ := c.getRanges()
+	require.Equal(t, 0.0, xr.GetMin())
+	require.Equal(t, 10.0, xr.GetMax())
+	require.NotNil(t, xra)
+	require.Equal(t, 100.0, xra.GetMin())
+	require.Equal(t, 300.0, xra.GetMax())
+
+	// Transformed axes derive their range from the primary axis, and series
+	// bound to them are drawn on the primary axis.
+	c.XAxisSecondary.Transform = func(v float64) float64 { return v * 2 }
+	xr, xra, _, _, _ = c.getRanges()
+	require.Equal(t, 0.0, xr.GetMin())
+	require.Equal(t, 300.0, xr.GetMax())
+	require.Equal(t, 600.0, xra.GetMax())
+
+	// The secondary axis is drawn above the canvas.
+	r, err := raster.NewRenderer(c.Width(), c.Height())
+	require.NoError(t, err)
+
+	canvasBox := render.Box{Top: 50, Left: 10, Right: 310, Bottom: 250}
+	xr.SetDomain(canvasBox.Width())
+	c.XAxisSecondary.AxisType = dataset.XAxisSecondary
+	c.XAxisSecondary.Name = "name"
+	ticks := c.getSecondaryXAxisTicks(r, xra, nil)
+	require.NotEmpty(t, ticks)
+	for _, tick := range ticks {
+		require.GreaterOrEqual(t, tick.Value, 0.0)
+		require.LessOrEqual(t, tick.Value, 600.0)
+	}
+
+	box := c.XAxisSecondary.Measure(r, canvasBox, xra, c.styleDefaultsAxes(), ticks)
+	require.Equal(t, canvasBox.Top, box.Bottom)
+	require.Less(t, box.Top, canvasBox.Top)
+
+	// Charts without secondary series or transform have no secondary axis.
+	c = Chart{Series: c.Series[:1]}
+	_, xra, _, _, _ = c.getRanges()
+	require.Nil(t, xra)
+
+	// The primary axis shows the range of the secondary axis if it has no
+	// series of its own.
+	c = Chart{
+		Series: []dataset.Series{
+			dataset.ContinuousSeries{XValues: []float64{1, 2, 3}, YValues: []float64{1, 2, 3}, XAxis: dataset.XAxisSecondary},
+		},
+	}
+	xr, xra, _, _, _ = c.getRanges()
+	require.Equal(t, 1.0, xr.GetMin())
+	require.Equal(t, 3.0, xr.GetMax())
+	require.Equal(t, 1.0, xra.GetMin())
+	require.Equal(t, 3.0, xra.GetMax())
+	require.NoError(t, c.Render(raster.NewRenderer, bytes.NewBuffer(nil)))
+}
+
+func TestChartReversedAxes(t *testing.T) {
+	c := Chart{
+		XAxis: XAxis{Reversed: true},
+		YAxis: YAxis{Reversed: true},
+		Series: []dataset.Series{
+			dataset.ContinuousSeries{XValues: []float64{0, 10}, YValues: []float64{0, 10}},
+		},
+	}
+
+	xr, _, yr, yra, _ := c.getRanges()
+	require.True(t, xr.IsDescending())
+	require.True(t, yr.IsDescending())
+	require.False(t, yra.IsDescending())
+
+	// The largest values are drawn at the origin of reversed ranges.
+	xr.SetDomain(100)
+	require.Equal(t, 100, xr.Translate(0))
+	require.Equal(t, 0, xr.Translate(10))
+}
+
+func TestBarChartReversedBarExtent(t *testing.T) {
+	bc := BarChart{}
+
+	yr := &sequence.ContinuousRange{Min: 0, Max: 10, Domain: 100}
+	origin, end := bc.getBarExtent(yr, 4, 1)
+	require.Equal(t, 0, origin)
+	require.Equal(t, 40, end)
+
+	// Bars on reversed ranges extend from the top of the canvas.
+	yr.Descending = true
+	origin, end = bc.getBarExtent(yr, 4, 1)
+	require.Equal(t, 100, origin)
+	require.Equal(t, 60, end)
+}
+
+func TestChartRollingSeriesSnapshots(t *testing.T) {
+	rts := dataset.NewRollingTimeSeries(50, 0)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 50; i++ {
+		rts.Append(start.Add(time.Duration(i)*time.Second), float64(i))
+	}
+
+	c := Chart{Series: []dataset.Series{rts}}
+	series, ok := c.getSeriesSnapshots()
+	require.True(t, ok)
+	require.IsType(t, dataset.TimeSeries{}, series[0])
+	require.Equal(t, rts, c.Series[0])
+
+	// Rendering while values are evicted draws a consistent snapshot.
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 50; i < 5000; i++ {
+			rts.Append(start.Add(time.Duration(i)*time.Second), float64(i))
+		}
+	}()
+	for i := 0; i < 5; i++ {
+		require.NoError(t, c.Render(raster.NewRenderer, bytes.NewBuffer(nil)))
+	}
+	wg.Wait()
+}
+
+func TestChartGetRangesIncludesReferenceLines(t *testing.T) {
+	c := Chart{
+		Series: []dataset.Series{
+			dataset.ContinuousSeries{XValues: []float64{1, 2}, YValues: []float64{10, 20}},
+			dataset.AnnotationLayer{
+				Annotations: []dataset.Annotation{
+					dataset.HorizontalLine{Value: 99, Label: "SLO"},
+					dataset.VerticalLine{Value: 5},
+				},
+			},
+		},
+	}
+
+	// Lines outside of the data extend the ranges, so that they are drawn.
+	xr, _, yr, _, _ := c.getRanges()
+	require.Equal(t, 1.0, xr.GetMin())
+	require.Equal(t, 5.0, xr.GetMax())
+	require.Equal(t, 10.0, yr.GetMin())
+	require.GreaterOrEqual(t, yr.GetMax(), 99.0)
+
+	r, err := raster.NewRenderer(c.Width(), c.Height())
+	require.NoError(t, err)
+
+	canvasBox := render.Box{Right: 100, Bottom: 100}
+	xr.SetDomain(canvasBox.Width())
+	yr.SetDomain(canvasBox.Height())
+	box := c.Series[1].(dataset.AnnotationLayer).Measure(r, canvasBox, xr, yr, render.Style{})
+	require.False(t, box.IsZero())
+}
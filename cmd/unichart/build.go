@@ -0,0 +1,355 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"io"
+	"strconv"
+
+	"github.com/unidoc/unichart"
+	"github.com/unidoc/unichart/dataset"
+	"github.com/unidoc/unichart/dataset/sequence"
+	"github.com/unidoc/unichart/render"
+)
+
+// chart is implemented by all the charts which can be built by the command.
+type chart interface {
+	Render(rp render.RendererProvider, w io.Writer) error
+	SetWidth(width int)
+	SetHeight(height int)
+	SetDPI(dpi float64)
+}
+
+// buildChart creates the chart described by the specification, using the
+// data of the given table.
+func buildChart(spec *Spec, t *dataset.Table) (chart, error) {
+	var (
+		c   chart
+		err error
+	)
+
+	switch spec.GetType() {
+	case chartTypeLine, chartTypeScatter:
+		c, err = buildSeriesChart(spec, t)
+	case chartTypeBar:
+		c, err = buildBarChart(spec, t)
+	case chartTypePie, chartTypeDonut:
+		c, err = buildPieChart(spec, t)
+	case chartTypeStacked:
+		c, err = buildStackedBarChart(spec, t)
+	default:
+		err = fmt.Errorf("unsupported chart type: %s", spec.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if spec.Width > 0 {
+		c.SetWidth(spec.Width)
+	}
+	if spec.Height > 0 {
+		c.SetHeight(spec.Height)
+	}
+	if spec.DPI > 0 {
+		c.SetDPI(spec.DPI)
+	}
+	return c, nil
+}
+
+func buildSeriesChart(spec *Spec, t *dataset.Table) (chart, error) {
+	xcol, ycols, err := resolveColumns(spec, t)
+	if err != nil {
+		return nil, err
+	}
+
+	timeValues := isTimeColumn(spec, t, xcol)
+
+	c := &unichart.Chart{
+		Title: spec.Title,
+		XAxis: unichart.XAxis{
+			Name:  spec.XAxis.Name,
+			Style: render.Style{Hidden: spec.XAxis.Hidden},
+			Range: axisRange(spec.XAxis),
+		},
+		YAxis: unichart.YAxis{
+			Name:           spec.YAxis.Name,
+			Style:          render.Style{Hidden: spec.YAxis.Hidden},
+			Range:          axisRange(spec.YAxis),
+			ValueFormatter: floatFormatter(spec.YAxis.Format),
+		},
+	}
+
+	if timeValues && spec.XAxis.Format != "" {
+		c.XAxis.ValueFormatter = dataset.TimeValueFormatterWithFormat(spec.XAxis.Format)
+	} else if !timeValues {
+		c.XAxis.ValueFormatter = floatFormatter(spec.XAxis.Format)
+	}
+
+	for index, ycol := range ycols {
+		style, err := seriesStyle(spec, index)
+		if err != nil {
+			return nil, err
+		}
+
+		var series dataset.Series
+		var length int
+		if timeValues {
+			ts, err := t.TimeSeriesAt(xcol, ycol)
+			if err != nil {
+				return nil, err
+			}
+			ts.Style = style
+			series, length = ts, ts.Len()
+		} else {
+			cs, err := t.ContinuousSeriesAt(xcol, ycol)
+			if err != nil {
+				return nil, err
+			}
+			cs.Style = style
+			series, length = cs, cs.Len()
+		}
+		if length == 0 {
+			return nil, fmt.Errorf("column %s contains no values", columnName(t, ycol))
+		}
+		c.Series = append(c.Series, series)
+	}
+
+	if spec.ShowLegend(len(c.Series)) {
+		c.Elements = []render.Renderable{unichart.Legend(c)}
+	}
+	return c, nil
+}
+
+func buildBarChart(spec *Spec, t *dataset.Table) (chart, error) {
+	values, err := labeledValues(spec, t)
+	if err != nil {
+		return nil, err
+	}
+
+	return &unichart.BarChart{
+		Title:        spec.Title,
+		IsHorizontal: spec.Horizontal,
+		XAxis:        render.Style{Hidden: spec.XAxis.Hidden},
+		YAxis: unichart.YAxis{
+			Name:           spec.YAxis.Name,
+			Style:          render.Style{Hidden: spec.YAxis.Hidden},
+			Range:          axisRange(spec.YAxis),
+			ValueFormatter: floatFormatter(spec.YAxis.Format),
+		},
+		Bars: values,
+	}, nil
+}
+
+func buildPieChart(spec *Spec, t *dataset.Table) (chart, error) {
+	values, err := labeledValues(spec, t)
+	if err != nil {
+		return nil, err
+	}
+
+	if spec.GetType() == chartTypeDonut {
+		return &unichart.DonutChart{Title: spec.Title, Values: values}, nil
+	}
+	return &unichart.PieChart{Title: spec.Title, Values: values}, nil
+}
+
+// buildStackedBarChart creates a stacked bar for each row of the table.
+// The X column provides the bar names and each Y column a bar section.
+func buildStackedBarChart(spec *Spec, t *dataset.Table) (chart, error) {
+	xcol, ycols, err := resolveColumns(spec, t)
+	if err != nil {
+		return nil, err
+	}
+
+	labels, groups, columns, err := t.StackedValuesAt(xcol, ycols...)
+	if err != nil {
+		return nil, err
+	}
+	if len(groups) == 0 {
+		return nil, errors.New("input contains no values")
+	}
+
+	bars := make([]unichart.StackedBar, len(groups))
+	for i, values := range groups {
+		bars[i] = unichart.StackedBar{Name: labels[i], Values: values}
+	}
+
+	// The sections are styled after their columns, as rows may skip
+	// missing values.
+	if len(spec.Colors) > 0 {
+		for i, bar := range bars {
+			for j, col := range columns[i] {
+				style, err := seriesStyle(spec, columnPosition(ycols, col))
+				if err != nil {
+					return nil, err
+				}
+				bar.Values[j].Style = style
+			}
+		}
+	}
+
+	return &unichart.StackedBarChart{
+		Title:        spec.Title,
+		IsHorizontal: spec.Horizontal,
+		XAxis:        render.Style{Hidden: spec.XAxis.Hidden},
+		YAxis:        render.Style{Hidden: spec.YAxis.Hidden},
+		Bars:         bars,
+	}, nil
+}
+
+// labeledValues returns the values of the first Y column, labeled using
+// the values of the X column.
+func labeledValues(spec *Spec, t *dataset.Table) ([]dataset.Value, error) {
+	xcol, ycols, err := resolveColumns(spec, t)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := t.ValuesAt(xcol, ycols[0])
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("column %s contains no values", columnName(t, ycols[0]))
+	}
+
+	for index := range values {
+		if values[index].Style, err = seriesStyle(spec, index); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// resolveColumns returns the indices of the X and Y columns. By default,
+// the first column is used for X values and all the others for Y values.
+func resolveColumns(spec *Spec, t *dataset.Table) (int, []int, error) {
+	xcol := 0
+	if spec.X != "" {
+		col, err := t.Column(spec.X)
+		if err != nil {
+			return 0, nil, err
+		}
+		xcol = col
+	}
+
+	var ycols []int
+	for _, yref := range spec.Y {
+		col, err := t.Column(yref)
+		if err != nil {
+			return 0, nil, err
+		}
+		ycols = append(ycols, col)
+	}
+	if len(spec.Y) == 0 {
+		for col := 0; col < t.Width(); col++ {
+			if col != xcol {
+				ycols = append(ycols, col)
+			}
+		}
+	}
+	if len(ycols) == 0 {
+		return 0, nil, errors.New("input must contain at least two columns")
+	}
+	return xcol, ycols, nil
+}
+
+// columnPosition returns the position of a column within the specified
+// columns.
+func columnPosition(cols []int, col int) int {
+	for index, c := range cols {
+		if c == col {
+			return index
+		}
+	}
+	return -1
+}
+
+// columnName returns the name of a column, or its index if it has none.
+func columnName(t *dataset.Table, col int) string {
+	if name := t.ColumnName(col); name != "" {
+		return name
+	}
+	return strconv.Itoa(col)
+}
+
+// isTimeColumn returns true if the values of the X column should be parsed
+// as times. That is the case if a time layout is specified or if the
+// column contains values which are not numeric.
+func isTimeColumn(spec *Spec, t *dataset.Table, xcol int) bool {
+	if spec.TimeLayout != "" {
+		return true
+	}
+
+	for row := 0; row < t.Len(); row++ {
+		if _, err := t.ParseFloat(t.Cell(row, xcol)); err != nil && err != dataset.ErrMissingValue {
+			return true
+		}
+	}
+	return false
+}
+
+func seriesStyle(spec *Spec, index int) (render.Style, error) {
+	gapMode, err := spec.LineGapMode()
+	if err != nil {
+		return render.Style{}, err
+	}
+
+	downsamplingMode, err := spec.DownsamplingMode()
+	if err != nil {
+		return render.Style{}, err
+	}
+
+	style := render.Style{
+		StrokeWidth:      spec.StrokeWidth,
+		DotWidth:         spec.DotWidth,
+		LineGapMode:      gapMode,
+		DownsamplingMode: downsamplingMode,
+	}
+	if spec.GetType() == chartTypeScatter {
+		style.StrokeWidth = -1
+		if style.DotWidth == 0 {
+			style.DotWidth = 3
+		}
+	}
+
+	var seriesColor color.Color = render.GetDefaultColor(index)
+	if len(spec.Colors) > 0 {
+		c, err := parseColor(spec.Colors[index%len(spec.Colors)])
+		if err != nil {
+			return style, err
+		}
+		seriesColor = c
+		style.StrokeColor = c
+		style.DotColor = c
+		if spec.GetType() != chartTypeLine {
+			style.FillColor = c
+		}
+	}
+
+	// Line fills are drawn translucent, so that overlapping series remain
+	// visible.
+	if spec.Fill && spec.GetType() == chartTypeLine {
+		rgba := color.RGBAModel.Convert(seriesColor).(color.RGBA)
+		style.FillColor = render.ColorWithAlpha(rgba, 64)
+	}
+	return style, nil
+}
+
+// axisRange returns the range of the axis, if specified. Half specified
+// ranges are rejected when the spec is validated.
+func axisRange(axis AxisSpec) sequence.Range {
+	if axis.Min == nil || axis.Max == nil {
+		return nil
+	}
+	return &sequence.ContinuousRange{Min: *axis.Min, Max: *axis.Max}
+}
+
+func floatFormatter(format string) dataset.ValueFormatter {
+	if format == "" {
+		return nil
+	}
+	return func(v interface{}) string {
+		return dataset.FloatValueFormatterWithFormat(v, format)
+	}
+}
@@ -0,0 +1,233 @@
+// Command unichart renders charts from CSV, TSV or JSON data.
+//
+// The data is read from a file or from the standard input and the chart is
+// written as an SVG or PNG image, using the renderers of the render/svg and
+// render/raster packages. Charts can be configured using command line flags
+// or a JSON specification file. Flags take precedence over the values of
+// the specification file.
+//
+// Usage:
+//
+//	unichart [flags] [input]
+//
+// Examples:
+//
+//	unichart -x date -y open,close -o prices.svg prices.csv
+//	cat sales.tsv | unichart -type bar -format tsv -o sales.png
+//	unichart -spec chart.json -title "Monthly sales" data.json
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/unidoc/unichart/render"
+	"github.com/unidoc/unichart/render/raster"
+	"github.com/unidoc/unichart/render/svg"
+)
+
+// Output formats supported by the command.
+const (
+	outputSVG = "svg"
+	outputPNG = "png"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "unichart: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run parses the command line arguments, renders the chart and writes it
+// to the configured output.
+func run(args []string, stdin io.Reader, stdout io.Writer) error {
+	spec, err := parseArgs(args)
+	if err != nil {
+		return err
+	}
+	if err := spec.Validate(); err != nil {
+		return err
+	}
+
+	in := stdin
+	if spec.Input != "" && spec.Input != "-" {
+		f, err := os.Open(spec.Input)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	opts, err := spec.TableOptions()
+	if err != nil {
+		return err
+	}
+
+	t, err := readTable(in, spec.GetFormat(), opts)
+	if err != nil {
+		return err
+	}
+
+	c, err := buildChart(spec, t)
+	if err != nil {
+		return err
+	}
+
+	var rp render.RendererProvider = svg.NewRenderer
+	if spec.GetOutputFormat() == outputPNG {
+		rp = raster.NewRenderer
+	}
+
+	var buf bytes.Buffer
+	if err := c.Render(rp, &buf); err != nil {
+		return err
+	}
+
+	if spec.Output == "" || spec.Output == "-" {
+		_, err = stdout.Write(buf.Bytes())
+		return err
+	}
+	return os.WriteFile(spec.Output, buf.Bytes(), 0644)
+}
+
+// parseArgs creates the chart specification from the command line
+// arguments, loading the specification file first, if one is provided.
+func parseArgs(args []string) (*Spec, error) {
+	var (
+		flags    = flag.NewFlagSet("unichart", flag.ContinueOnError)
+		flagSpec Spec
+		specPath string
+		y        string
+		colors   string
+		legend   bool
+	)
+
+	flags.StringVar(&specPath, "spec", "", "JSON chart specification file")
+	flags.StringVar(&flagSpec.Type, "type", "", "chart type: line, scatter, bar, pie, donut or stacked (default line)")
+	flags.StringVar(&flagSpec.Title, "title", "", "chart title")
+	flags.IntVar(&flagSpec.Width, "width", 0, "chart width")
+	flags.IntVar(&flagSpec.Height, "height", 0, "chart height")
+	flags.Float64Var(&flagSpec.DPI, "dpi", 0, "chart DPI")
+	flags.StringVar(&flagSpec.Format, "format", "", "input format: csv, tsv or json (default from the input extension)")
+	flags.BoolVar(&flagSpec.NoHeader, "no-header", false, "the delimited input has no header row")
+	flags.StringVar(&flagSpec.Output, "o", "", "output file (default stdout)")
+	flags.StringVar(&flagSpec.OutputFormat, "output-format", "", "output format: svg or png (default from the output extension)")
+	flags.StringVar(&flagSpec.X, "x", "", "X values (or labels) column name or index (default 0)")
+	flags.StringVar(&y, "y", "", "comma separated Y values column names or indices (default all other columns)")
+	flags.StringVar(&flagSpec.TimeLayout, "time-layout", "", "Go time layout of the X values")
+	flags.StringVar(&flagSpec.TimeZone, "tz", "", "time zone of the X values without offset (default UTC)")
+	flags.StringVar(&flagSpec.ThousandsSeparator, "thousands", "", "thousands separator of the input numbers")
+	flags.StringVar(&flagSpec.DecimalSeparator, "decimal", "", "decimal separator of the input numbers (default .)")
+	flags.StringVar(&flagSpec.Missing, "missing", "", "missing values policy: skip, zero, nan or error (default skip)")
+	flags.StringVar(&flagSpec.XAxis.Name, "xname", "", "X axis name")
+	flags.StringVar(&flagSpec.YAxis.Name, "yname", "", "Y axis name")
+	flags.StringVar(&flagSpec.XAxis.Format, "xformat", "", "X axis value format (printf verb or Go time layout)")
+	flags.StringVar(&flagSpec.YAxis.Format, "yformat", "", "Y axis value format (printf verb)")
+	flags.StringVar(&colors, "colors", "", "comma separated series colors (#rrggbb)")
+	flags.Float64Var(&flagSpec.StrokeWidth, "stroke-width", 0, "series stroke width")
+	flags.Float64Var(&flagSpec.DotWidth, "dot-width", 0, "series dot width")
+	flags.BoolVar(&flagSpec.Fill, "fill", false, "fill the area under line series")
+	flags.StringVar(&flagSpec.Gaps, "gaps", "", "line gaps at missing values: break, connect, zero or step (default break)")
+	flags.StringVar(&flagSpec.Downsample, "downsample", "", "line downsampling: none, lttb or minmax (default none)")
+	flags.BoolVar(&flagSpec.Horizontal, "horizontal", false, "draw horizontal bars")
+	flags.BoolVar(&legend, "legend", false, "draw a legend (default true for multiple series)")
+
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+	if flags.NArg() > 1 {
+		return nil, fmt.Errorf("too many arguments: %s", strings.Join(flags.Args(), " "))
+	}
+
+	spec := &Spec{}
+	if specPath != "" {
+		loaded, err := loadSpec(specPath)
+		if err != nil {
+			return nil, err
+		}
+		spec = loaded
+	}
+	if flags.NArg() == 1 {
+		spec.Input = flags.Arg(0)
+	}
+
+	// Override the specification with the explicitly set flags.
+	flags.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "type":
+			spec.Type = flagSpec.Type
+		case "title":
+			spec.Title = flagSpec.Title
+		case "width":
+			spec.Width = flagSpec.Width
+		case "height":
+			spec.Height = flagSpec.Height
+		case "dpi":
+			spec.DPI = flagSpec.DPI
+		case "format":
+			spec.Format = flagSpec.Format
+		case "no-header":
+			spec.NoHeader = flagSpec.NoHeader
+		case "o":
+			spec.Output = flagSpec.Output
+		case "output-format":
+			spec.OutputFormat = flagSpec.OutputFormat
+		case "x":
+			spec.X = flagSpec.X
+		case "y":
+			spec.Y = splitList(y)
+		case "time-layout":
+			spec.TimeLayout = flagSpec.TimeLayout
+		case "tz":
+			spec.TimeZone = flagSpec.TimeZone
+		case "thousands":
+			spec.ThousandsSeparator = flagSpec.ThousandsSeparator
+		case "decimal":
+			spec.DecimalSeparator = flagSpec.DecimalSeparator
+		case "missing":
+			spec.Missing = flagSpec.Missing
+		case "xname":
+			spec.XAxis.Name = flagSpec.XAxis.Name
+		case "yname":
+			spec.YAxis.Name = flagSpec.YAxis.Name
+		case "xformat":
+			spec.XAxis.Format = flagSpec.XAxis.Format
+		case "yformat":
+			spec.YAxis.Format = flagSpec.YAxis.Format
+		case "colors":
+			spec.Colors = splitList(colors)
+		case "stroke-width":
+			spec.StrokeWidth = flagSpec.StrokeWidth
+		case "dot-width":
+			spec.DotWidth = flagSpec.DotWidth
+		case "fill":
+			spec.Fill = flagSpec.Fill
+		case "gaps":
+			spec.Gaps = flagSpec.Gaps
+		case "downsample":
+			spec.Downsample = flagSpec.Downsample
+		case "horizontal":
+			spec.Horizontal = flagSpec.Horizontal
+		case "legend":
+			spec.Legend = &legend
+		}
+	})
+
+	return spec, nil
+}
+
+func splitList(s string) []string {
+	var values []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
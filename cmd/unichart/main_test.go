@@ -0,0 +1,160 @@
+package main
+
+import (
+	"bytes"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/unidoc/unichart"
+	"github.com/unidoc/unichart/dataset"
+)
+
+const testCSV = `date,open,close
+2024-01-01,10,11
+2024-01-02,11,12.5
+2024-01-03,12,
+2024-01-04,10,14
+`
+
+func TestRunLineSVG(t *testing.T) {
+	out := bytes.NewBuffer(nil)
+	err := run([]string{"-title", "Prices", "-y", "open,close"}, strings.NewReader(testCSV), out)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(out.String(), "<svg"))
+	require.Contains(t, out.String(), ">Prices</text>")
+	require.Contains(t, out.String(), ">close</text>")
+}
+
+func TestRunBarPNG(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "chart.png")
+	err := run([]string{"-type", "bar", "-x", "0", "-y", "close", "-width", "300", "-height", "200", "-o", output},
+		strings.NewReader(testCSV), nil)
+	require.NoError(t, err)
+
+	f, err := os.Open(output)
+	require.NoError(t, err)
+	defer f.Close()
+
+	img, err := png.Decode(f)
+	require.NoError(t, err)
+	require.Equal(t, 300, img.Bounds().Dx())
+	require.Equal(t, 200, img.Bounds().Dy())
+}
+
+func TestRunSpecFile(t *testing.T) {
+	dir := t.TempDir()
+	spec := filepath.Join(dir, "spec.json")
+	input := filepath.Join(dir, "data.json")
+	require.NoError(t, os.WriteFile(spec, []byte(`{"type": "pie", "x": "name", "y": ["value"], "title": "Spec"}`), 0644))
+	require.NoError(t, os.WriteFile(input, []byte(`[{"name": "a", "value": 1}, {"name": "b", "value": 3}]`), 0644))
+
+	out := bytes.NewBuffer(nil)
+	err := run([]string{"-spec", spec, "-title", "Flag", input}, nil, out)
+	require.NoError(t, err)
+	require.Contains(t, out.String(), ">Flag</text>")
+	require.Contains(t, out.String(), ">b</text>")
+}
+
+func TestRunErrors(t *testing.T) {
+	err := run([]string{"-type", "radar"}, strings.NewReader(testCSV), bytes.NewBuffer(nil))
+	require.Error(t, err)
+
+	err = run([]string{"-y", "missing"}, strings.NewReader(testCSV), bytes.NewBuffer(nil))
+	require.EqualError(t, err, "column not found: missing")
+
+	err = run([]string{"-colors", "blue"}, strings.NewReader(testCSV), bytes.NewBuffer(nil))
+	require.EqualError(t, err, "invalid color: blue")
+
+	spec := filepath.Join(t.TempDir(), "spec.json")
+	require.NoError(t, os.WriteFile(spec, []byte(`{"y": ["close"], "y_axis": {"min": 0}}`), 0644))
+	err = run([]string{"-spec", spec}, strings.NewReader(testCSV), bytes.NewBuffer(nil))
+	require.EqualError(t, err, "invalid y_axis range; both min and max must be specified")
+
+	require.NoError(t, os.WriteFile(spec, []byte(`{"y": ["close"], "x_axis": {"min": 5, "max": 1}}`), 0644))
+	err = run([]string{"-spec", spec}, strings.NewReader(testCSV), bytes.NewBuffer(nil))
+	require.EqualError(t, err, "invalid x_axis range; max must be greater than min")
+}
+
+func TestReadTable(t *testing.T) {
+	tbl, err := readTable(strings.NewReader("1\t2\n3\t4\n"), formatTSV, dataset.TableOptions{})
+	require.NoError(t, err)
+	require.Nil(t, tbl.Header)
+	require.Equal(t, 2, tbl.Len())
+
+	tbl, err = readTable(strings.NewReader(`[{"b": 1, "a": "x"}, {"c": true, "a": [1]}]`), formatJSON, dataset.TableOptions{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"b", "a", "c"}, tbl.Header)
+	require.Equal(t, []string{"1", "x", ""}, tbl.Rows[0])
+	require.Equal(t, []string{"", "[1]", "true"}, tbl.Rows[1])
+
+	// The default X column is the first key of the document.
+	tbl, err = readTable(strings.NewReader(`[{"x": 1, "value": 2}]`), formatJSON, dataset.TableOptions{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"x", "value"}, tbl.Header)
+
+	_, err = readTable(strings.NewReader(`{"x": 1}`), formatJSON, dataset.TableOptions{})
+	require.Error(t, err)
+}
+
+func TestRunTableOptions(t *testing.T) {
+	input := "day\tsales\n01.02.2024\t1.000,5\n02.02.2024\t\n03.02.2024\t2.000\n"
+	args := []string{"-format", "tsv", "-time-layout", "02.01.2006", "-tz", "Europe/Berlin",
+		"-thousands", ".", "-decimal", ","}
+
+	err := run(args, strings.NewReader(input), bytes.NewBuffer(nil))
+	require.NoError(t, err)
+
+	err = run(append(args, "-missing", "error"), strings.NewReader(input), bytes.NewBuffer(nil))
+	require.EqualError(t, err, "row 2, column sales: missing value")
+
+	err = run([]string{"-missing", "sometimes"}, strings.NewReader(testCSV), bytes.NewBuffer(nil))
+	require.EqualError(t, err, "unsupported missing value policy: sometimes")
+}
+
+func TestResolveColumns(t *testing.T) {
+	// Default columns are not confused with numeric column names.
+	tbl := &dataset.Table{
+		Header: []string{"x", "2", "1"},
+		Rows:   [][]string{{"1", "10", "20"}, {"2", "11", "21"}},
+	}
+	xcol, ycols, err := resolveColumns(&Spec{}, tbl)
+	require.NoError(t, err)
+	require.Equal(t, 0, xcol)
+	require.Equal(t, []int{1, 2}, ycols)
+
+	c, err := buildChart(&Spec{}, tbl)
+	require.NoError(t, err)
+	series := c.(*unichart.Chart).Series
+	require.Equal(t, "2", series[0].GetName())
+	require.Equal(t, []float64{10, 11}, series[0].(dataset.ContinuousSeries).YValues)
+
+	// Column references match names first.
+	xcol, ycols, err = resolveColumns(&Spec{X: "1", Y: []string{"x"}}, tbl)
+	require.NoError(t, err)
+	require.Equal(t, 2, xcol)
+	require.Equal(t, []int{0}, ycols)
+}
+
+func TestStackedBarColors(t *testing.T) {
+	tbl := &dataset.Table{
+		Header: []string{"name", "a", "b"},
+		Rows:   [][]string{{"foo", "1", "2"}, {"bar", "", "3"}},
+	}
+	spec := &Spec{Type: chartTypeStacked, Colors: []string{"#ff0000", "#0000ff"}}
+	c, err := buildChart(spec, tbl)
+	require.NoError(t, err)
+
+	// Sections keep the color of their column when previous cells are
+	// missing.
+	blue, err := parseColor("#0000ff")
+	require.NoError(t, err)
+	bars := c.(*unichart.StackedBarChart).Bars
+	require.Equal(t, blue, bars[0].Values[1].Style.FillColor)
+	require.Len(t, bars[1].Values, 1)
+	require.Equal(t, blue, bars[1].Values[0].Style.FillColor)
+}
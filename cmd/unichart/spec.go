@@ -0,0 +1,300 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/unidoc/unichart/dataset"
+	"github.com/unidoc/unichart/render"
+)
+
+// Chart types supported by the command.
+const (
+	chartTypeLine    = "line"
+	chartTypeScatter = "scatter"
+	chartTypeBar     = "bar"
+	chartTypePie     = "pie"
+	chartTypeDonut   = "donut"
+	chartTypeStacked = "stacked"
+)
+
+// Spec describes the chart to be rendered. It can be loaded from a JSON
+// file and overridden using command line flags.
+type Spec struct {
+	Type   string  `json:"type"`
+	Title  string  `json:"title"`
+	Width  int     `json:"width"`
+	Height int     `json:"height"`
+	DPI    float64 `json:"dpi"`
+
+	Input    string `json:"input"`
+	Format   string `json:"format"`
+	NoHeader bool   `json:"no_header"`
+
+	Output       string `json:"output"`
+	OutputFormat string `json:"output_format"`
+
+	X          string   `json:"x"`
+	Y          []string `json:"y"`
+	TimeLayout string   `json:"time_layout"`
+	TimeZone   string   `json:"time_zone"`
+
+	ThousandsSeparator string `json:"thousands_separator"`
+	DecimalSeparator   string `json:"decimal_separator"`
+	Missing            string `json:"missing"`
+
+	XAxis AxisSpec `json:"x_axis"`
+	YAxis AxisSpec `json:"y_axis"`
+
+	Colors      []string `json:"colors"`
+	StrokeWidth float64  `json:"stroke_width"`
+	DotWidth    float64  `json:"dot_width"`
+	Fill        bool     `json:"fill"`
+	Gaps        string   `json:"gaps"`
+	Downsample  string   `json:"downsample"`
+	Horizontal  bool     `json:"horizontal"`
+	Legend      *bool    `json:"legend"`
+}
+
+// AxisSpec describes the options of a chart axis.
+type AxisSpec struct {
+	Name   string   `json:"name"`
+	Min    *float64 `json:"min"`
+	Max    *float64 `json:"max"`
+	Format string   `json:"format"`
+	Hidden bool     `json:"hidden"`
+}
+
+// Validate validates the axis options. The range of the axis must be fully
+// specified, if specified at all.
+func (a AxisSpec) Validate(name string) error {
+	if (a.Min == nil) != (a.Max == nil) {
+		return fmt.Errorf("invalid %s range; both min and max must be specified", name)
+	}
+	if a.Min != nil && !(*a.Max > *a.Min) {
+		return fmt.Errorf("invalid %s range; max must be greater than min", name)
+	}
+	return nil
+}
+
+// loadSpec reads a chart specification from the JSON file at the given path.
+func loadSpec(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := &Spec{}
+	if err := json.Unmarshal(data, spec); err != nil {
+		return nil, fmt.Errorf("invalid spec file %s: %v", path, err)
+	}
+	return spec, nil
+}
+
+// GetType returns the chart type, defaulting to a line chart.
+func (s Spec) GetType() string {
+	if s.Type == "" {
+		return chartTypeLine
+	}
+	return strings.ToLower(s.Type)
+}
+
+// GetFormat returns the input format. If not set, it is inferred from the
+// input file extension, defaulting to CSV.
+func (s Spec) GetFormat() string {
+	if s.Format != "" {
+		return strings.ToLower(s.Format)
+	}
+
+	switch {
+	case strings.HasSuffix(strings.ToLower(s.Input), ".tsv"):
+		return formatTSV
+	case strings.HasSuffix(strings.ToLower(s.Input), ".json"):
+		return formatJSON
+	}
+	return formatCSV
+}
+
+// GetOutputFormat returns the output format. If not set, it is inferred
+// from the output file extension, defaulting to SVG.
+func (s Spec) GetOutputFormat() string {
+	if s.OutputFormat != "" {
+		return strings.ToLower(s.OutputFormat)
+	}
+	if strings.HasSuffix(strings.ToLower(s.Output), ".png") {
+		return outputPNG
+	}
+	return outputSVG
+}
+
+// ShowLegend returns true if a legend should be drawn for the specified
+// number of series.
+func (s Spec) ShowLegend(seriesCount int) bool {
+	if s.Legend != nil {
+		return *s.Legend
+	}
+	return seriesCount > 1
+}
+
+// TableOptions returns the options used to load the input table.
+func (s Spec) TableOptions() (dataset.TableOptions, error) {
+	opts := dataset.TableOptions{}
+	if s.NoHeader {
+		opts.Header = dataset.HeaderAbsent
+	}
+	if s.TimeLayout != "" {
+		opts.TimeLayouts = []string{s.TimeLayout}
+	}
+
+	if s.TimeZone != "" {
+		loc, err := time.LoadLocation(s.TimeZone)
+		if err != nil {
+			return opts, fmt.Errorf("invalid time zone: %s", s.TimeZone)
+		}
+		opts.Location = loc
+	}
+
+	var err error
+	if opts.ThousandsSeparator, err = parseSeparator(s.ThousandsSeparator); err != nil {
+		return opts, err
+	}
+	if opts.DecimalSeparator, err = parseSeparator(s.DecimalSeparator); err != nil {
+		return opts, err
+	}
+
+	switch strings.ToLower(s.Missing) {
+	case "", "skip":
+		opts.MissingValuePolicy = dataset.MissingValueSkip
+	case "zero":
+		opts.MissingValuePolicy = dataset.MissingValueZero
+	case "nan":
+		opts.MissingValuePolicy = dataset.MissingValueNaN
+	case "error":
+		opts.MissingValuePolicy = dataset.MissingValueError
+	default:
+		return opts, fmt.Errorf("unsupported missing value policy: %s", s.Missing)
+	}
+	return opts, nil
+}
+
+// LineGapMode returns how line series handle missing values.
+func (s Spec) LineGapMode() (render.LineGapMode, error) {
+	switch strings.ToLower(s.Gaps) {
+	case "":
+		return render.LineGapModeUnset, nil
+	case "break":
+		return render.LineGapModeBreak, nil
+	case "connect":
+		return render.LineGapModeConnect, nil
+	case "zero":
+		return render.LineGapModeZero, nil
+	case "step":
+		return render.LineGapModeStep, nil
+	}
+	return render.LineGapModeUnset, fmt.Errorf("unsupported gap mode: %s", s.Gaps)
+}
+
+// DownsamplingMode returns how line series with many values are reduced.
+func (s Spec) DownsamplingMode() (render.DownsamplingMode, error) {
+	switch strings.ToLower(s.Downsample) {
+	case "":
+		return render.DownsamplingModeUnset, nil
+	case "none":
+		return render.DownsamplingModeNone, nil
+	case "lttb":
+		return render.DownsamplingModeLTTB, nil
+	case "minmax":
+		return render.DownsamplingModeMinMax, nil
+	}
+	return render.DownsamplingModeUnset, fmt.Errorf("unsupported downsampling mode: %s", s.Downsample)
+}
+
+// Validate validates the specification.
+func (s Spec) Validate() error {
+	switch s.GetType() {
+	case chartTypeLine, chartTypeScatter, chartTypeBar, chartTypePie, chartTypeDonut, chartTypeStacked:
+	default:
+		return fmt.Errorf("unsupported chart type: %s", s.Type)
+	}
+
+	switch s.GetFormat() {
+	case formatCSV, formatTSV, formatJSON:
+	default:
+		return fmt.Errorf("unsupported input format: %s", s.Format)
+	}
+
+	switch s.GetOutputFormat() {
+	case outputSVG, outputPNG:
+	default:
+		return fmt.Errorf("unsupported output format: %s", s.OutputFormat)
+	}
+
+	if s.Width < 0 || s.Height < 0 {
+		return fmt.Errorf("invalid chart size %dx%d", s.Width, s.Height)
+	}
+	for _, c := range s.Colors {
+		if _, err := parseColor(c); err != nil {
+			return err
+		}
+	}
+
+	if _, err := s.LineGapMode(); err != nil {
+		return err
+	}
+	if _, err := s.DownsamplingMode(); err != nil {
+		return err
+	}
+	if err := s.XAxis.Validate("x_axis"); err != nil {
+		return err
+	}
+	if err := s.YAxis.Validate("y_axis"); err != nil {
+		return err
+	}
+
+	_, err := s.TableOptions()
+	return err
+}
+
+// parseSeparator parses a single character number separator.
+func parseSeparator(s string) (rune, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	runes := []rune(s)
+	if len(runes) != 1 {
+		return 0, fmt.Errorf("invalid separator: %q", s)
+	}
+	return runes[0], nil
+}
+
+// parseColor parses a color in the #rgb, #rrggbb or #rrggbbaa format.
+func parseColor(s string) (color.Color, error) {
+	hex := strings.TrimPrefix(strings.TrimSpace(s), "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+	if len(hex) != 8 {
+		return nil, fmt.Errorf("invalid color: %s", s)
+	}
+
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid color: %s", s)
+	}
+
+	return color.NRGBA{
+		R: uint8(v >> 24),
+		G: uint8(v >> 16),
+		B: uint8(v >> 8),
+		A: uint8(v),
+	}, nil
+}
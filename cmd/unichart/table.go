@@ -0,0 +1,155 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/unidoc/unichart/dataset"
+)
+
+// Input formats supported by the command.
+const (
+	formatCSV  = "csv"
+	formatTSV  = "tsv"
+	formatJSON = "json"
+)
+
+// readTable reads a table in the specified format from the given reader.
+func readTable(r io.Reader, format string, opts dataset.TableOptions) (*dataset.Table, error) {
+	var (
+		t   *dataset.Table
+		err error
+	)
+
+	switch format {
+	case formatCSV:
+		t, err = dataset.ReadCSV(r, opts)
+	case formatTSV:
+		t, err = dataset.ReadTSV(r, opts)
+	case formatJSON:
+		t, err = readJSON(r, opts)
+	default:
+		err = fmt.Errorf("unsupported input format: %s", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if t.Len() == 0 {
+		return nil, errors.New("input contains no records")
+	}
+	return t, nil
+}
+
+// readJSON reads an array of objects. Columns are ordered by the first
+// object defining them, in the order of the keys within the object.
+func readJSON(r io.Reader, opts dataset.TableOptions) (*dataset.Table, error) {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+
+	objects, err := decodeJSONObjects(decoder)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON input: %v", err)
+	}
+
+	t := &dataset.Table{Options: opts}
+	columns := map[string]int{}
+	for _, object := range objects {
+		for _, field := range object {
+			if _, ok := columns[field.key]; !ok {
+				columns[field.key] = len(t.Header)
+				t.Header = append(t.Header, field.key)
+			}
+		}
+	}
+
+	for _, object := range objects {
+		row := make([]string, len(t.Header))
+		for _, field := range object {
+			row[columns[field.key]] = jsonString(field.value)
+		}
+		t.Rows = append(t.Rows, row)
+	}
+	return t, nil
+}
+
+// jsonField is a key/value pair of a JSON object.
+type jsonField struct {
+	key   string
+	value interface{}
+}
+
+// decodeJSONObjects decodes an array of objects, preserving the order of
+// the keys of each object.
+func decodeJSONObjects(decoder *json.Decoder) ([][]jsonField, error) {
+	if err := expectJSONDelim(decoder, '['); err != nil {
+		return nil, err
+	}
+
+	var objects [][]jsonField
+	for decoder.More() {
+		if err := expectJSONDelim(decoder, '{'); err != nil {
+			return nil, err
+		}
+
+		var object []jsonField
+		for decoder.More() {
+			token, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			key, ok := token.(string)
+			if !ok {
+				return nil, fmt.Errorf("unexpected token %v", token)
+			}
+
+			var value interface{}
+			if err := decoder.Decode(&value); err != nil {
+				return nil, err
+			}
+			object = append(object, jsonField{key: key, value: value})
+		}
+
+		if err := expectJSONDelim(decoder, '}'); err != nil {
+			return nil, err
+		}
+		objects = append(objects, object)
+	}
+
+	if err := expectJSONDelim(decoder, ']'); err != nil {
+		return nil, err
+	}
+	return objects, nil
+}
+
+// expectJSONDelim reads the next token of the decoder, which must be the
+// specified delimiter.
+func expectJSONDelim(decoder *json.Decoder, delim json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return fmt.Errorf("expected %v, got %v", delim, token)
+	}
+	return nil
+}
+
+func jsonString(v interface{}) string {
+	switch typed := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return typed
+	case json.Number:
+		return typed.String()
+	case bool:
+		return strconv.FormatBool(typed)
+	}
+
+	data, _ := json.Marshal(v)
+	return string(data)
+}
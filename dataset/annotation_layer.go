@@ -0,0 +1,566 @@
+package dataset
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/unidoc/unichart/dataset/sequence"
+	"github.com/unidoc/unichart/mathutil"
+	"github.com/unidoc/unichart/render"
+)
+
+const (
+	// defaultAnnotationLabelPadding is the distance between annotation labels
+	// and the elements they describe.
+	defaultAnnotationLabelPadding = 4
+
+	// defaultAnnotationBandAlpha is the alpha of the default band fill color.
+	defaultAnnotationBandAlpha = 40
+
+	// defaultArrowHeadSize is the length of arrow heads.
+	defaultArrowHeadSize = 8
+
+	// defaultCalloutOffset is the default horizontal and vertical distance
+	// between callout anchors and labels.
+	defaultCalloutOffset = 24
+)
+
+var (
+	// defaultReferenceLineDashArray is the dash array of reference lines.
+	defaultReferenceLineDashArray = []float64{5, 3}
+)
+
+// Interface Assertions.
+var (
+	_ MeasurableSeries   = (*AnnotationLayer)(nil)
+	_ AxisBoundsProvider = (*AnnotationLayer)(nil)
+	_ Annotation         = (*HorizontalLine)(nil)
+	_ Annotation         = (*VerticalLine)(nil)
+	_ Annotation         = (*XBand)(nil)
+	_ Annotation         = (*YBand)(nil)
+	_ Annotation         = (*Arrow)(nil)
+	_ Annotation         = (*Callout)(nil)
+)
+
+// CoordinateSystem specifies how annotation positions are interpreted.
+type CoordinateSystem int
+
+const (
+	// CoordinateSystemData positions annotations using data values.
+	CoordinateSystemData CoordinateSystem = iota
+
+	// CoordinateSystemCanvas positions annotations relative to the canvas
+	// box, (0, 0) being its top left corner and (1, 1) its bottom right one.
+	CoordinateSystemCanvas
+)
+
+// AnnotationPoint is the position of an annotation.
+type AnnotationPoint struct {
+	X      float64
+	Y      float64
+	System CoordinateSystem
+}
+
+// Translate returns the position of the point on the canvas.
+func (p AnnotationPoint) Translate(canvasBox render.Box, xrange, yrange sequence.Range) (x, y int) {
+	if p.System == CoordinateSystemCanvas {
+		x = canvasBox.Left + int(math.Round(p.X*float64(canvasBox.Width())))
+		y = canvasBox.Top + int(math.Round(p.Y*float64(canvasBox.Height())))
+		return
+	}
+
+	x = canvasBox.Left + xrange.Translate(p.X)
+	y = canvasBox.Bottom - yrange.Translate(p.Y)
+	return
+}
+
+// Annotation is an element drawn over the chart canvas, like a reference
+// line, a band or a callout.
+type Annotation interface {
+	// Measure returns the bounds of the annotation.
+	Measure(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, defaults render.Style) render.Box
+	// Render draws the annotation.
+	Render(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, defaults render.Style)
+}
+
+// AnnotationLayer is a series of annotations drawn over the chart canvas.
+// Annotations extending outside the canvas are taken into account by the
+// chart layout, like the labels of an AnnotationSeries. The ranges derived
+// from the data are extended to include the values of the reference lines,
+// so that lines such as targets are drawn even if the data does not reach
+// them. Bands are clipped to the ranges, while arrows and callouts are drawn
+// at their positions, even outside of the canvas.
+type AnnotationLayer struct {
+	Name        string
+	Style       render.Style
+	YAxis       YAxisType
+	Annotations []Annotation
+}
+
+// GetName returns the name of the layer.
+func (al AnnotationLayer) GetName() string {
+	return al.Name
+}
+
+// GetStyle returns the layer style.
+func (al AnnotationLayer) GetStyle() render.Style {
+	return al.Style
+}
+
+// GetYAxis returns which YAxis the annotations are positioned on.
+func (al AnnotationLayer) GetYAxis() YAxisType {
+	return al.YAxis
+}
+
+func (al AnnotationLayer) annotationStyleDefaults(defaults render.Style) render.Style {
+	return render.Style{
+		FontColor:       render.DefaultTextColor,
+		Font:            defaults.Font,
+		FontSize:        defaultAnnotationFontSize,
+		StrokeColor:     defaults.StrokeColor,
+		StrokeWidth:     1,
+		StrokeDashArray: defaultReferenceLineDashArray,
+		FillColor:       defaultAnnotationFillColor,
+		Padding:         defaultAnnotationPadding,
+	}
+}
+
+// Measure returns a bounds box of the annotations.
+func (al AnnotationLayer) Measure(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, defaults render.Style) render.Box {
+	box := render.Box{
+		Top:  math.MaxInt32,
+		Left: math.MaxInt32,
+	}
+	if al.Style.Hidden {
+		return box
+	}
+
+	style := al.Style.InheritFrom(al.annotationStyleDefaults(defaults))
+	for _, a := range al.Annotations {
+		ab := a.Measure(r, canvasBox, xrange, yrange, style)
+		if ab.IsZero() {
+			continue
+		}
+		box.Top = mathutil.MinInt(box.Top, ab.Top)
+		box.Left = mathutil.MinInt(box.Left, ab.Left)
+		box.Right = mathutil.MaxInt(box.Right, ab.Right)
+		box.Bottom = mathutil.MaxInt(box.Bottom, ab.Bottom)
+	}
+	return box
+}
+
+// GetAxisBounds returns the values of the visible reference lines, which
+// the ranges of the axes include.
+func (al AnnotationLayer) GetAxisBounds() (xvalues, yvalues []float64) {
+	for _, a := range al.Annotations {
+		switch line := a.(type) {
+		case HorizontalLine:
+			if !line.Style.Hidden {
+				yvalues = append(yvalues, line.Value)
+			}
+		case *HorizontalLine:
+			if line != nil && !line.Style.Hidden {
+				yvalues = append(yvalues, line.Value)
+			}
+		case VerticalLine:
+			if !line.Style.Hidden {
+				xvalues = append(xvalues, line.Value)
+			}
+		case *VerticalLine:
+			if line != nil && !line.Style.Hidden {
+				xvalues = append(xvalues, line.Value)
+			}
+		}
+	}
+	return xvalues, yvalues
+}
+
+// Render draws the annotations.
+func (al AnnotationLayer) Render(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, defaults render.Style) {
+	if al.Style.Hidden {
+		return
+	}
+
+	style := al.Style.InheritFrom(al.annotationStyleDefaults(defaults))
+	for _, a := range al.Annotations {
+		a.Render(r, canvasBox, xrange, yrange, style)
+	}
+}
+
+// Validate validates the layer.
+func (al AnnotationLayer) Validate() error {
+	if len(al.Annotations) == 0 {
+		return fmt.Errorf("annotation layer requires annotations to be set and not empty")
+	}
+	return nil
+}
+
+// HorizontalLine is a reference line drawn across the canvas at a Y value.
+// Lines outside of the Y range, which can only be set explicitly when the
+// line is part of an AnnotationLayer, are not drawn.
+type HorizontalLine struct {
+	Value float64
+	Label string
+	Style render.Style
+}
+
+// Measure returns the bounds of the line.
+func (hl HorizontalLine) Measure(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, defaults render.Style) render.Box {
+	if hl.Style.Hidden || !inRange(yrange, hl.Value) {
+		return render.Box{}
+	}
+	y := canvasBox.Bottom - yrange.Translate(hl.Value)
+	return render.Box{Top: y, Left: canvasBox.Left, Right: canvasBox.Right, Bottom: y}
+}
+
+// Render draws the line.
+func (hl HorizontalLine) Render(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, defaults render.Style) {
+	if hl.Style.Hidden || !inRange(yrange, hl.Value) {
+		return
+	}
+
+	style := hl.Style.InheritFrom(defaults)
+	y := canvasBox.Bottom - yrange.Translate(hl.Value)
+	drawAnnotationLine(r, style, canvasBox.Left, y, canvasBox.Right, y)
+
+	if hl.Label != "" {
+		tb := render.Text.Measure(r, hl.Label, style)
+		x := canvasBox.Right - defaultAnnotationLabelPadding - tb.Width()
+		render.Text.Draw(r, hl.Label, x, y-defaultAnnotationLabelPadding, style)
+	}
+}
+
+// VerticalLine is a reference line drawn across the canvas at an X value.
+// Lines outside of the X range, which can only be set explicitly when the
+// line is part of an AnnotationLayer, are not drawn.
+type VerticalLine struct {
+	Value float64
+	Label string
+	Style render.Style
+}
+
+// Measure returns the bounds of the line.
+func (vl VerticalLine) Measure(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, defaults render.Style) render.Box {
+	if vl.Style.Hidden || !inRange(xrange, vl.Value) {
+		return render.Box{}
+	}
+	x := canvasBox.Left + xrange.Translate(vl.Value)
+	return render.Box{Top: canvasBox.Top, Left: x, Right: x, Bottom: canvasBox.Bottom}
+}
+
+// Render draws the line.
+func (vl VerticalLine) Render(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, defaults render.Style) {
+	if vl.Style.Hidden || !inRange(xrange, vl.Value) {
+		return
+	}
+
+	style := vl.Style.InheritFrom(defaults)
+	x := canvasBox.Left + xrange.Translate(vl.Value)
+	drawAnnotationLine(r, style, x, canvasBox.Top, x, canvasBox.Bottom)
+
+	if vl.Label != "" {
+		tb := render.Text.Measure(r, vl.Label, style)
+		render.Text.Draw(r, vl.Label, x+defaultAnnotationLabelPadding,
+			canvasBox.Top+defaultAnnotationLabelPadding+tb.Height(), style)
+	}
+}
+
+// XBand is a shaded band drawn across the canvas between two X values.
+type XBand struct {
+	From  float64
+	To    float64
+	Label string
+	Style render.Style
+}
+
+// Measure returns the bounds of the band.
+func (xb XBand) Measure(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, defaults render.Style) render.Box {
+	left, right, ok := bandBounds(xrange, xb.From, xb.To)
+	if xb.Style.Hidden || !ok {
+		return render.Box{}
+	}
+	return render.Box{
+		Top:    canvasBox.Top,
+		Left:   canvasBox.Left + left,
+		Right:  canvasBox.Left + right,
+		Bottom: canvasBox.Bottom,
+	}
+}
+
+// Render draws the band.
+func (xb XBand) Render(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, defaults render.Style) {
+	box := xb.Measure(r, canvasBox, xrange, yrange, defaults)
+	if box.IsZero() {
+		return
+	}
+
+	style := xb.Style.InheritFrom(bandStyleDefaults(defaults))
+	drawAnnotationBand(r, style, box, xb.Label)
+}
+
+// YBand is a shaded band drawn across the canvas between two Y values.
+type YBand struct {
+	From  float64
+	To    float64
+	Label string
+	Style render.Style
+}
+
+// Measure returns the bounds of the band.
+func (yb YBand) Measure(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, defaults render.Style) render.Box {
+	bottom, top, ok := bandBounds(yrange, yb.From, yb.To)
+	if yb.Style.Hidden || !ok {
+		return render.Box{}
+	}
+	return render.Box{
+		Top:    canvasBox.Bottom - top,
+		Left:   canvasBox.Left,
+		Right:  canvasBox.Right,
+		Bottom: canvasBox.Bottom - bottom,
+	}
+}
+
+// Render draws the band.
+func (yb YBand) Render(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, defaults render.Style) {
+	box := yb.Measure(r, canvasBox, xrange, yrange, defaults)
+	if box.IsZero() {
+		return
+	}
+
+	style := yb.Style.InheritFrom(bandStyleDefaults(defaults))
+	drawAnnotationBand(r, style, box, yb.Label)
+}
+
+// Arrow is an arrow pointing from a position to another, with an optional
+// label drawn at its start.
+type Arrow struct {
+	From  AnnotationPoint
+	To    AnnotationPoint
+	Label string
+	Style render.Style
+}
+
+// Measure returns the bounds of the arrow and its label.
+func (a Arrow) Measure(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, defaults render.Style) render.Box {
+	if a.Style.Hidden {
+		return render.Box{}
+	}
+
+	style := a.Style.InheritFrom(defaults)
+	fx, fy := a.From.Translate(canvasBox, xrange, yrange)
+	tx, ty := a.To.Translate(canvasBox, xrange, yrange)
+
+	box := render.Box{
+		Top:    mathutil.MinInt(fy, ty) - defaultArrowHeadSize,
+		Left:   mathutil.MinInt(fx, tx) - defaultArrowHeadSize,
+		Right:  mathutil.MaxInt(fx, tx) + defaultArrowHeadSize,
+		Bottom: mathutil.MaxInt(fy, ty) + defaultArrowHeadSize,
+	}
+	if a.Label != "" {
+		box = box.Grow(a.labelBox(r, style, fx, fy, tx))
+	}
+	return box
+}
+
+// Render draws the arrow.
+func (a Arrow) Render(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, defaults render.Style) {
+	if a.Style.Hidden {
+		return
+	}
+
+	style := a.Style.InheritFrom(defaults)
+	fx, fy := a.From.Translate(canvasBox, xrange, yrange)
+	tx, ty := a.To.Translate(canvasBox, xrange, yrange)
+
+	lineStyle := style.GetStrokeOptions()
+	lineStyle.StrokeDashArray = a.Style.StrokeDashArray
+	drawAnnotationLine(r, lineStyle, fx, fy, tx, ty)
+
+	// Draw the arrow head.
+	angle := math.Atan2(float64(ty-fy), float64(tx-fx))
+	lx, ly := mathutil.CirclePoint(tx, ty, defaultArrowHeadSize, angle+math.Pi-math.Pi/7+math.Pi/2)
+	rx, ry := mathutil.CirclePoint(tx, ty, defaultArrowHeadSize, angle+math.Pi+math.Pi/7+math.Pi/2)
+
+	r.SetFillColor(style.GetStrokeColor())
+	r.SetStrokeColor(style.GetStrokeColor())
+	r.SetStrokeWidth(style.GetStrokeWidth())
+	r.MoveTo(tx, ty)
+	r.LineTo(lx, ly)
+	r.LineTo(rx, ry)
+	r.LineTo(tx, ty)
+	r.Close()
+	r.FillStroke()
+	r.ResetStyle()
+
+	if a.Label != "" {
+		lb := a.labelBox(r, style, fx, fy, tx)
+		render.Text.Draw(r, a.Label, lb.Left, lb.Bottom, style)
+	}
+}
+
+// labelBox returns the bounds of the arrow label, which is placed at the
+// start of the arrow, on the opposite side of its head.
+func (a Arrow) labelBox(r render.Renderer, style render.Style, fx, fy, tx int) render.Box {
+	tb := render.Text.Measure(r, a.Label, style)
+
+	left := fx + defaultAnnotationLabelPadding
+	if tx > fx {
+		left = fx - defaultAnnotationLabelPadding - tb.Width()
+	}
+	top := fy - tb.Height()>>1
+
+	return render.Box{
+		Top:    top,
+		Left:   left,
+		Right:  left + tb.Width(),
+		Bottom: top + tb.Height(),
+	}
+}
+
+// Callout is a boxed text label connected to an anchor position by a leader
+// line. The label is offset from the anchor by the specified amount of
+// pixels, which defaults to the top right of the anchor.
+type Callout struct {
+	Anchor  AnnotationPoint
+	OffsetX int
+	OffsetY int
+	Label   string
+	Style   render.Style
+}
+
+// Measure returns the bounds of the callout.
+func (c Callout) Measure(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, defaults render.Style) render.Box {
+	if c.Style.Hidden {
+		return render.Box{}
+	}
+
+	style := c.Style.InheritFrom(defaults)
+	ax, ay := c.Anchor.Translate(canvasBox, xrange, yrange)
+	return render.Box{Top: ay, Left: ax, Right: ax, Bottom: ay}.Grow(c.labelBox(r, style, ax, ay))
+}
+
+// Render draws the callout.
+func (c Callout) Render(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, defaults render.Style) {
+	if c.Style.Hidden {
+		return
+	}
+
+	style := c.Style.InheritFrom(defaults)
+	ax, ay := c.Anchor.Translate(canvasBox, xrange, yrange)
+	box := c.labelBox(r, style, ax, ay)
+
+	// Connect the anchor to the closest point of the label box.
+	lx := mathutil.MaxInt(box.Left, mathutil.MinInt(ax, box.Right))
+	ly := mathutil.MaxInt(box.Top, mathutil.MinInt(ay, box.Bottom))
+	drawAnnotationLeader(r, style, ax, ay, lx, ly)
+
+	boxStyle := style.GetFillAndStrokeOptions()
+	boxStyle.StrokeDashArray = c.Style.StrokeDashArray
+	box.Draw(r, boxStyle)
+
+	pl := style.Padding.GetLeft(defaultAnnotationPadding.Left)
+	pb := style.Padding.GetBottom(defaultAnnotationPadding.Bottom)
+	render.Text.Draw(r, c.Label, box.Left+pl, box.Bottom-pb, style)
+}
+
+// labelBox returns the bounds of the callout label box.
+func (c Callout) labelBox(r render.Renderer, style render.Style, ax, ay int) render.Box {
+	dx, dy := c.OffsetX, c.OffsetY
+	if dx == 0 && dy == 0 {
+		dx, dy = defaultCalloutOffset, -defaultCalloutOffset
+	}
+
+	tb := render.Text.Measure(r, c.Label, style)
+	width := tb.Width() + style.Padding.GetLeft(defaultAnnotationPadding.Left) +
+		style.Padding.GetRight(defaultAnnotationPadding.Right)
+	height := tb.Height() + style.Padding.GetTop(defaultAnnotationPadding.Top) +
+		style.Padding.GetBottom(defaultAnnotationPadding.Bottom)
+
+	left := ax + dx
+	if dx < 0 {
+		left -= width
+	}
+	top := ay + dy
+	if dy < 0 {
+		top -= height
+	}
+
+	return render.Box{
+		Top:    top,
+		Left:   left,
+		Right:  left + width,
+		Bottom: top + height,
+	}
+}
+
+func bandStyleDefaults(defaults render.Style) render.Style {
+	fill := color.NRGBAModel.Convert(defaults.GetStrokeColor(render.DefaultLineColor)).(color.NRGBA)
+	fill.A = defaultAnnotationBandAlpha
+
+	return render.Style{
+		FillColor:   fill,
+		StrokeColor: render.ColorTransparent,
+		FontColor:   defaults.FontColor,
+		Font:        defaults.Font,
+		FontSize:    defaults.FontSize,
+	}
+}
+
+// inRange returns true if the value is within the bounds of the range.
+func inRange(r sequence.Range, value float64) bool {
+	min, max := r.GetMin(), r.GetMax()
+	if min > max {
+		min, max = max, min
+	}
+	return value >= min && value <= max
+}
+
+// bandBounds returns the translated bounds of a band, clipped to the range.
+func bandBounds(r sequence.Range, from, to float64) (low, high int, ok bool) {
+	min, max := r.GetMin(), r.GetMax()
+	if min > max {
+		min, max = max, min
+	}
+	from, to = math.Min(from, to), math.Max(from, to)
+	if to < min || from > max {
+		return 0, 0, false
+	}
+
+	low = r.Translate(math.Max(from, min))
+	high = r.Translate(math.Min(to, max))
+	if low > high {
+		low, high = high, low
+	}
+	return low, high, true
+}
+
+func drawAnnotationLine(r render.Renderer, style render.Style, x0, y0, x1, y1 int) {
+	style.GetStrokeOptions().WriteDrawingOptionsToRenderer(r)
+	defer r.ResetStyle()
+
+	r.MoveTo(x0, y0)
+	r.LineTo(x1, y1)
+	r.Stroke()
+}
+
+func drawAnnotationLeader(r render.Renderer, style render.Style, x0, y0, x1, y1 int) {
+	leaderStyle := style.GetStrokeOptions()
+	leaderStyle.StrokeDashArray = nil
+	drawAnnotationLine(r, leaderStyle, x0, y0, x1, y1)
+
+	r.SetFillColor(style.GetStrokeColor())
+	r.Circle(2, x0, y0)
+	r.Fill()
+	r.ResetStyle()
+}
+
+func drawAnnotationBand(r render.Renderer, style render.Style, box render.Box, label string) {
+	box.Draw(r, style.GetFillAndStrokeOptions())
+
+	if label != "" {
+		tb := render.Text.Measure(r, label, style)
+		render.Text.Draw(r, label, box.Left+defaultAnnotationLabelPadding,
+			box.Top+defaultAnnotationLabelPadding+tb.Height(), style)
+	}
+}
@@ -0,0 +1,120 @@
+package dataset
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/unidoc/unichart/dataset/sequence"
+	"github.com/unidoc/unichart/render"
+	"github.com/unidoc/unichart/render/raster"
+)
+
+func TestAnnotationPointTranslate(t *testing.T) {
+	canvasBox := render.Box{Top: 10, Left: 20, Right: 120, Bottom: 110}
+	xrange := &sequence.ContinuousRange{Min: 0, Max: 10, Domain: 100}
+	yrange := &sequence.ContinuousRange{Min: 0, Max: 10, Domain: 100}
+
+	x, y := AnnotationPoint{X: 5, Y: 2}.Translate(canvasBox, xrange, yrange)
+	require.Equal(t, 70, x)
+	require.Equal(t, 90, y)
+
+	x, y = AnnotationPoint{X: 0.25, Y: 0.5, System: CoordinateSystemCanvas}.Translate(canvasBox, xrange, yrange)
+	require.Equal(t, 45, x)
+	require.Equal(t, 60, y)
+}
+
+func TestAnnotationBandsClipToRange(t *testing.T) {
+	canvasBox := render.Box{Right: 100, Bottom: 100}
+	xrange := &sequence.ContinuousRange{Min: 0, Max: 10, Domain: 100}
+	yrange := &sequence.ContinuousRange{Min: 0, Max: 10, Domain: 100}
+
+	box := XBand{From: 8, To: 20}.Measure(nil, canvasBox, xrange, yrange, render.Style{})
+	require.Equal(t, render.Box{Top: 0, Left: 80, Right: 100, Bottom: 100}, box)
+
+	box = YBand{From: 6, To: 2}.Measure(nil, canvasBox, xrange, yrange, render.Style{})
+	require.Equal(t, render.Box{Top: 40, Left: 0, Right: 100, Bottom: 80}, box)
+
+	require.True(t, XBand{From: 11, To: 12}.Measure(nil, canvasBox, xrange, yrange, render.Style{}).IsZero())
+	require.True(t, HorizontalLine{Value: -1}.Measure(nil, canvasBox, xrange, yrange, render.Style{}).IsZero())
+	require.True(t, VerticalLine{Value: 11}.Measure(nil, canvasBox, xrange, yrange, render.Style{}).IsZero())
+}
+
+func TestAnnotationLayerMeasure(t *testing.T) {
+	r, err := raster.NewRenderer(200, 200)
+	require.NoError(t, err)
+
+	canvasBox := render.Box{Top: 50, Left: 50, Right: 150, Bottom: 150}
+	xrange := &sequence.ContinuousRange{Min: 0, Max: 10, Domain: 100}
+	yrange := &sequence.ContinuousRange{Min: 0, Max: 10, Domain: 100}
+
+	layer := AnnotationLayer{
+		Annotations: []Annotation{
+			HorizontalLine{Value: 5, Label: "target"},
+			Callout{Anchor: AnnotationPoint{X: 10, Y: 10}, Label: "peak"},
+		},
+	}
+	require.NoError(t, layer.Validate())
+
+	box := layer.Measure(r, canvasBox, xrange, yrange, render.Style{})
+	require.Equal(t, canvasBox.Left, box.Left)
+	require.Less(t, box.Top, canvasBox.Top)
+	require.Greater(t, box.Right, canvasBox.Right)
+	require.Equal(t, 100, box.Bottom)
+
+	layer.Style.Hidden = true
+	box = layer.Measure(r, canvasBox, xrange, yrange, render.Style{})
+	require.Equal(t, canvasBox, canvasBox.Grow(box))
+
+	require.Error(t, AnnotationLayer{}.Validate())
+}
+
+func TestAnnotationLayerRender(t *testing.T) {
+	r, err := raster.NewRenderer(100, 100)
+	require.NoError(t, err)
+
+	canvasBox := render.Box{Right: 100, Bottom: 100}
+	xrange := &sequence.ContinuousRange{Min: 0, Max: 10, Domain: 100}
+	yrange := &sequence.ContinuousRange{Min: 0, Max: 10, Domain: 100}
+
+	layer := AnnotationLayer{
+		Annotations: []Annotation{
+			XBand{From: 2, To: 4, Style: render.Style{FillColor: color.RGBA{R: 255, A: 255}}},
+			VerticalLine{Value: 8, Label: "now"},
+			Arrow{From: AnnotationPoint{X: 5, Y: 5}, To: AnnotationPoint{X: 7, Y: 7}, Label: "rise"},
+		},
+	}
+	layer.Render(r, canvasBox, xrange, yrange, render.Style{StrokeColor: render.ColorBlue})
+
+	img := r.(*raster.Renderer).Image()
+	require.Equal(t, color.RGBA{R: 255, A: 255}, color.RGBAModel.Convert(img.At(30, 50)))
+	require.Equal(t, color.RGBA{}, img.At(10, 50))
+}
+
+func TestAnnotationLayerAxisBounds(t *testing.T) {
+	layer := AnnotationLayer{
+		Annotations: []Annotation{
+			HorizontalLine{Value: 99, Label: "target"},
+			HorizontalLine{Value: 50, Style: render.Style{Hidden: true}},
+			VerticalLine{Value: -3},
+			YBand{From: 200, To: 300},
+		},
+	}
+
+	xvalues, yvalues := layer.GetAxisBounds()
+	require.Equal(t, []float64{-3}, xvalues)
+	require.Equal(t, []float64{99}, yvalues)
+
+	// Lines referenced by pointers extend the axes too.
+	layer.Annotations = []Annotation{
+		&HorizontalLine{Value: 99},
+		&HorizontalLine{Value: 50, Style: render.Style{Hidden: true}},
+		&VerticalLine{Value: -3},
+		(*HorizontalLine)(nil),
+		(*VerticalLine)(nil),
+	}
+	xvalues, yvalues = layer.GetAxisBounds()
+	require.Equal(t, []float64{-3}, xvalues)
+	require.Equal(t, []float64{99}, yvalues)
+}
@@ -15,6 +15,14 @@ const (
 
 	// defaultAnnotationFontSize is the font size of annotations.
 	defaultAnnotationFontSize = 10.0
+
+	// defaultAnnotationSpacing is the vertical space between annotations
+	// nudged in order to avoid overlaps.
+	defaultAnnotationSpacing = 2
+
+	// defaultAnnotationLeaderWidth is the horizontal length of the leader
+	// lines connecting nudged annotations to their anchor points.
+	defaultAnnotationLeaderWidth = 8
 )
 
 var (
@@ -32,7 +40,8 @@ var (
 
 // Interface Assertions.
 var (
-	_ Series = (*AnnotationSeries)(nil)
+	_ Series           = (*AnnotationSeries)(nil)
+	_ MeasurableSeries = (*AnnotationSeries)(nil)
 )
 
 // FirstValueAnnotation returns an annotation series of just the first value of a value provider as an annotation.
@@ -180,12 +189,8 @@ func (as AnnotationSeries) Measure(r render.Renderer, canvasBox render.Box, xran
 		Bottom: 0,
 	}
 	if !as.Style.Hidden {
-		seriesStyle := as.Style.InheritFrom(as.annotationStyleDefaults(defaults))
-		for _, a := range as.Annotations {
-			style := a.Style.InheritFrom(seriesStyle)
-			lx := canvasBox.Left + xrange.Translate(a.XValue)
-			ly := canvasBox.Bottom - yrange.Translate(a.YValue)
-			ab := measureAnnotation(r, canvasBox, style, lx, ly, a.Label)
+		boxes := PlaceAnnotationBoxes(as.MeasureAnnotations(r, canvasBox, xrange, yrange, defaults), canvasBox)
+		for _, ab := range boxes {
 			box.Top = mathutil.MinInt(box.Top, ab.Top)
 			box.Left = mathutil.MinInt(box.Left, ab.Left)
 			box.Right = mathutil.MaxInt(box.Right, ab.Right)
@@ -195,16 +200,57 @@ func (as AnnotationSeries) Measure(r render.Renderer, canvasBox render.Box, xran
 	return box
 }
 
-// Render draws the series.
+// MeasureAnnotations returns the bounds of each annotation of the series,
+// drawn at its anchor point.
+func (as AnnotationSeries) MeasureAnnotations(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, defaults render.Style) []render.Box {
+	if as.Style.Hidden {
+		return nil
+	}
+
+	seriesStyle := as.Style.InheritFrom(as.annotationStyleDefaults(defaults))
+	boxes := make([]render.Box, len(as.Annotations))
+	for i, a := range as.Annotations {
+		style := a.Style.InheritFrom(seriesStyle)
+		lx := canvasBox.Left + xrange.Translate(a.XValue)
+		ly := canvasBox.Bottom - yrange.Translate(a.YValue)
+		boxes[i] = measureAnnotation(r, canvasBox, style, lx, ly, a.Label)
+	}
+	return boxes
+}
+
+// Render draws the series. Overlapping annotations are nudged vertically.
 func (as AnnotationSeries) Render(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, defaults render.Style) {
-	if !as.Style.Hidden {
-		seriesStyle := as.Style.InheritFrom(as.annotationStyleDefaults(defaults))
-		for _, a := range as.Annotations {
-			style := a.Style.InheritFrom(seriesStyle)
-			lx := canvasBox.Left + xrange.Translate(a.XValue)
-			ly := canvasBox.Bottom - yrange.Translate(a.YValue)
+	boxes := PlaceAnnotationBoxes(as.MeasureAnnotations(r, canvasBox, xrange, yrange, defaults), canvasBox)
+	as.RenderPlaced(r, canvasBox, xrange, yrange, defaults, boxes)
+}
+
+// RenderPlaced draws the annotations of the series within the specified
+// boxes, usually computed by PlaceAnnotationBoxes. Annotations drawn away
+// from their anchor points are connected to them by leader lines.
+func (as AnnotationSeries) RenderPlaced(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, defaults render.Style, boxes []render.Box) {
+	if as.Style.Hidden {
+		return
+	}
+
+	seriesStyle := as.Style.InheritFrom(as.annotationStyleDefaults(defaults))
+	for i, a := range as.Annotations {
+		style := a.Style.InheritFrom(seriesStyle)
+		lx := canvasBox.Left + xrange.Translate(a.XValue)
+		ly := canvasBox.Bottom - yrange.Translate(a.YValue)
+
+		if i >= len(boxes) {
 			drawAnnotation(r, canvasBox, style, lx, ly, a.Label)
+			continue
+		}
+
+		// Translate the anchor point along with the annotation box.
+		ab := measureAnnotation(r, canvasBox, style, lx, ly, a.Label)
+		tx := lx + boxes[i].Left - ab.Left
+		ty := ly + boxes[i].Top - ab.Top
+		if tx != lx || ty != ly {
+			drawAnnotationLeader(r, style, lx, ly, tx, ty)
 		}
+		drawAnnotation(r, canvasBox, style, tx, ty, a.Label)
 	}
 }
 
@@ -216,12 +262,62 @@ func (as AnnotationSeries) Validate() error {
 	return nil
 }
 
+// PlaceAnnotationBoxes resolves the overlaps between the specified
+// annotation boxes by nudging them vertically within the canvas box.
+// Nudged annotations are moved to the right, making room for the leader
+// lines connecting them to their anchor points. The room of the leader
+// lines is reserved before the overlaps are resolved, so the moved boxes
+// do not overlap each other.
+func PlaceAnnotationBoxes(boxes []render.Box, canvasBox render.Box) []render.Box {
+	leaderWidths := make([]int, len(boxes))
+	reserved := make([]render.Box, len(boxes))
+	copy(reserved, boxes)
+
+	// Grow the nudged boxes by the width of their leader lines until the
+	// nudged boxes no longer change. Boxes are only ever grown, so the
+	// iterations end after at most one round per box.
+	var placed []render.Box
+	for {
+		placed = render.PlaceLabels(reserved, canvasBox, defaultAnnotationSpacing)
+
+		grown := false
+		for i := range placed {
+			if placed[i].Top == boxes[i].Top || leaderWidths[i] > 0 {
+				continue
+			}
+
+			leaderWidth := defaultAnnotationLeaderWidth
+			if !canvasBox.IsZero() {
+				leaderWidth = mathutil.MinInt(leaderWidth, canvasBox.Right-boxes[i].Right)
+			}
+			if leaderWidth <= 0 {
+				continue
+			}
+
+			leaderWidths[i] = leaderWidth
+			reserved[i].Right += leaderWidth
+			grown = true
+		}
+		if !grown {
+			break
+		}
+	}
+
+	for i := range placed {
+		placed[i].Right = boxes[i].Right
+		if placed[i].Top != boxes[i].Top {
+			placed[i] = placed[i].Shift(leaderWidths[i], 0)
+		}
+	}
+	return placed
+}
+
 // measureAnnotation measures how big an annotation would be.
 func measureAnnotation(r render.Renderer, canvasBox render.Box, style render.Style, lx, ly int, label string) render.Box {
 	style.WriteToRenderer(r)
 	defer r.ResetStyle()
 
-	textBox := r.MeasureText(label)
+	textBox := render.Text.Measure(r, label, style)
 	textWidth := textBox.Width()
 	textHeight := textBox.Height()
 	halfTextHeight := textHeight >> 1
@@ -250,7 +346,7 @@ func drawAnnotation(r render.Renderer, canvasBox render.Box, style render.Style,
 	style.GetTextOptions().WriteToRenderer(r)
 	defer r.ResetStyle()
 
-	textBox := r.MeasureText(label)
+	textBox := render.Text.Measure(r, label, style)
 	textWidth := textBox.Width()
 	halfTextHeight := textBox.Height() >> 1
 
@@ -285,6 +381,5 @@ func drawAnnotation(r render.Renderer, canvasBox render.Box, style render.Style,
 	r.Close()
 	r.FillStroke()
 
-	style.GetTextOptions().WriteToRenderer(r)
-	r.Text(label, textX, textY)
+	render.Text.Draw(r, label, textX, textY, style)
 }
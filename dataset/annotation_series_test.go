@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/unidoc/unichart/render"
 )
 
 func TestFirstValueAnnotation(t *testing.T) {
@@ -32,3 +34,34 @@ func TestLastValueAnnotationSeries(t *testing.T) {
 	require.Equal(t, 5.0, lvaa.XValue)
 	require.Equal(t, 1.0, lvaa.YValue)
 }
+
+func TestPlaceAnnotationBoxesAdjacentColumns(t *testing.T) {
+	canvasBox := render.Box{Right: 400, Bottom: 400}
+	boxes := []render.Box{
+		{Top: 100, Left: 0, Right: 50, Bottom: 120},
+		{Top: 105, Left: 0, Right: 50, Bottom: 125},
+		{Top: 125, Left: 54, Right: 104, Bottom: 145},
+	}
+
+	placed := PlaceAnnotationBoxes(boxes, canvasBox)
+	require.Len(t, placed, len(boxes))
+	for i := range placed {
+		require.Equal(t, boxes[i].Width(), placed[i].Width())
+		require.Equal(t, boxes[i].Height(), placed[i].Height())
+		for j := i + 1; j < len(placed); j++ {
+			overlaps := placed[i].Left <= placed[j].Right && placed[j].Left <= placed[i].Right &&
+				placed[i].Top <= placed[j].Bottom && placed[j].Top <= placed[i].Bottom
+			require.False(t, overlaps, "boxes %d and %d overlap: %v %v", i, j, placed[i], placed[j])
+		}
+	}
+
+	// Nudged boxes are moved right by the width of the leader lines, within
+	// the canvas box.
+	edge := []render.Box{
+		{Top: 100, Left: 350, Right: 396, Bottom: 120},
+		{Top: 105, Left: 350, Right: 396, Bottom: 125},
+	}
+	placed = PlaceAnnotationBoxes(edge, canvasBox)
+	require.NotEqual(t, edge[1].Top, placed[1].Top)
+	require.Equal(t, 400, placed[1].Right)
+}
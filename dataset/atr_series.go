@@ -0,0 +1,114 @@
+package dataset
+
+import (
+	"fmt"
+
+	"github.com/unidoc/unichart/dataset/sequence"
+	"github.com/unidoc/unichart/render"
+)
+
+const (
+	// defaultATRPeriod is the default number of values used to average the
+	// true ranges of the ATR.
+	defaultATRPeriod = 14
+)
+
+// Interface Assertions.
+var (
+	_ Series              = (*ATRSeries)(nil)
+	_ FirstValuesProvider = (*ATRSeries)(nil)
+	_ LastValuesProvider  = (*ATRSeries)(nil)
+)
+
+// ATRSeries computes the average true range of the close values
+// (InnerSeries), which measures their volatility. The true ranges are
+// computed from the High and Low values, which default to the close values,
+// and smoothed using Wilder's method.
+type ATRSeries struct {
+	Name  string
+	Style render.Style
+	YAxis YAxisType
+
+	Period      int
+	High        ValuesProvider
+	Low         ValuesProvider
+	InnerSeries ValuesProvider
+
+	cache []float64
+}
+
+// GetName returns the name of the time series.
+func (atr ATRSeries) GetName() string {
+	return atr.Name
+}
+
+// GetStyle returns the line style.
+func (atr ATRSeries) GetStyle() render.Style {
+	return atr.Style
+}
+
+// GetYAxis returns which YAxis the series draws on.
+func (atr ATRSeries) GetYAxis() YAxisType {
+	return atr.YAxis
+}
+
+// GetPeriod returns the window size.
+func (atr ATRSeries) GetPeriod() int {
+	if atr.Period == 0 {
+		return defaultATRPeriod
+	}
+	return atr.Period
+}
+
+// Len returns the number of elements in the series.
+func (atr ATRSeries) Len() int {
+	return atr.InnerSeries.Len()
+}
+
+// GetValues gets a value at a given index.
+func (atr *ATRSeries) GetValues(index int) (x, y float64) {
+	if atr.InnerSeries == nil {
+		return
+	}
+	if atr.cache == nil {
+		atr.ensureCachedValues()
+	}
+	if index < 0 || index >= len(atr.cache) {
+		return
+	}
+	x, _ = atr.InnerSeries.GetValues(index)
+	y = atr.cache[index]
+	return
+}
+
+// GetFirstValues computes the first ATR value.
+func (atr *ATRSeries) GetFirstValues() (x, y float64) {
+	return atr.GetValues(0)
+}
+
+// GetLastValues computes the last ATR value.
+func (atr *ATRSeries) GetLastValues() (x, y float64) {
+	if atr.InnerSeries == nil {
+		return
+	}
+	return atr.GetValues(atr.InnerSeries.Len() - 1)
+}
+
+func (atr *ATRSeries) ensureCachedValues() {
+	highs, lows, closes := highLowCloseValues(atr.High, atr.Low, atr.InnerSeries)
+	atr.cache = averageTrueRange(highs, lows, closes, atr.GetPeriod())
+}
+
+// Render renders the series.
+func (atr *ATRSeries) Render(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, defaults render.Style) {
+	style := atr.Style.InheritFrom(defaults)
+	drawLineSeries(r, canvasBox, xrange, yrange, style, atr)
+}
+
+// Validate validates the series.
+func (atr *ATRSeries) Validate() error {
+	if atr.InnerSeries == nil {
+		return fmt.Errorf("atr series requires InnerSeries to be set")
+	}
+	return nil
+}
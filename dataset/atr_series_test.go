@@ -0,0 +1,38 @@
+package dataset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestATRSeries(t *testing.T) {
+	xvalues := []float64{1, 2, 3}
+	atr := &ATRSeries{
+		High:        ContinuousSeries{XValues: xvalues, YValues: []float64{2, 3, 4}},
+		Low:         ContinuousSeries{XValues: xvalues, YValues: []float64{1, 1, 2}},
+		InnerSeries: ContinuousSeries{XValues: xvalues, YValues: []float64{1.5, 2.5, 3}},
+		Period:      2,
+	}
+	require.Nil(t, atr.Validate())
+
+	for index, value := range []float64{1, 1.5, 1.75} {
+		x, y := atr.GetValues(index)
+		require.Equal(t, xvalues[index], x)
+		require.InDelta(t, value, y, 1e-9)
+	}
+	require.NotNil(t, (&ATRSeries{}).Validate())
+}
+
+func TestATRSeriesEmpty(t *testing.T) {
+	atr := &ATRSeries{InnerSeries: ContinuousSeries{}}
+
+	x, y := atr.GetValues(0)
+	require.Zero(t, x)
+	require.Zero(t, y)
+	require.NotNil(t, atr.cache)
+
+	x, y = atr.GetLastValues()
+	require.Zero(t, x)
+	require.Zero(t, y)
+}
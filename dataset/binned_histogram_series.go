@@ -0,0 +1,195 @@
+package dataset
+
+import (
+	"image/color"
+
+	"github.com/unidoc/unichart/dataset/sequence"
+	"github.com/unidoc/unichart/mathutil"
+	"github.com/unidoc/unichart/render"
+)
+
+const (
+	// defaultBinnedHistogramFillAlpha is the alpha of the default fill color
+	// of binned histograms, which keeps overlaid histograms visible.
+	defaultBinnedHistogramFillAlpha = 128
+)
+
+// HistogramNormalization is the normalization of the bin heights of a
+// histogram.
+type HistogramNormalization int
+
+// HistogramNormalization values.
+const (
+	// HistogramCount uses the number of samples in each bin.
+	HistogramCount HistogramNormalization = iota
+	// HistogramPercentage uses the fraction of the binned samples in each
+	// bin, formatted as a percentage.
+	HistogramPercentage
+	// HistogramDensity uses the probability density of each bin, so that
+	// the area of the histogram is 1.
+	HistogramDensity
+)
+
+// Interface Assertions.
+var (
+	_ Series                    = (*BinnedHistogramSeries)(nil)
+	_ FullBoundedValuesProvider = (*BinnedHistogramSeries)(nil)
+	_ ValueFormatterProvider    = (*BinnedHistogramSeries)(nil)
+)
+
+// BinnedHistogramSeries bins raw samples and draws them as a histogram,
+// with a bar spanning each bin. Cumulative histograms accumulate the bins
+// from left to right; cumulative densities are the cumulative distribution
+// of the samples. The bars are filled with a translucent version of the
+// series color by default, so multiple histograms can be overlaid. Use a
+// HistogramBuilder to overlay histograms sharing the same bins.
+type BinnedHistogramSeries struct {
+	Name  string
+	Style render.Style
+	YAxis YAxisType
+
+	Samples       []float64
+	Binning       HistogramBinning
+	Normalization HistogramNormalization
+	Cumulative    bool
+
+	edges   []float64
+	heights []float64
+	err     error
+}
+
+// GetName returns the name of the time series.
+func (bhs BinnedHistogramSeries) GetName() string {
+	return bhs.Name
+}
+
+// GetStyle returns the line style.
+func (bhs BinnedHistogramSeries) GetStyle() render.Style {
+	return bhs.Style
+}
+
+// GetYAxis returns which YAxis the series draws on.
+func (bhs BinnedHistogramSeries) GetYAxis() YAxisType {
+	return bhs.YAxis
+}
+
+// GetValueFormatters returns value formatter defaults for the series.
+func (bhs BinnedHistogramSeries) GetValueFormatters() (x, y ValueFormatter) {
+	x, y = FloatValueFormatter, FloatValueFormatter
+	if bhs.Normalization == HistogramPercentage {
+		y = PercentValueFormatter
+	}
+	return
+}
+
+// Bins returns the bin edges and the bin heights of the histogram. There is
+// one more edge than heights.
+func (bhs *BinnedHistogramSeries) Bins() (edges, heights []float64, err error) {
+	bhs.ensureCachedValues()
+	return bhs.edges, bhs.heights, bhs.err
+}
+
+// Len returns the number of bin edges.
+func (bhs *BinnedHistogramSeries) Len() int {
+	bhs.ensureCachedValues()
+	return len(bhs.edges)
+}
+
+// GetBoundedValues returns a bin edge, along with the height of the bin
+// starting at it and zero. The last edge has the height of the last bin.
+func (bhs *BinnedHistogramSeries) GetBoundedValues(index int) (x, y1, y2 float64) {
+	bhs.ensureCachedValues()
+	if index < 0 || index >= len(bhs.edges) {
+		return
+	}
+	return bhs.edges[index], bhs.heights[mathutil.MinInt(index, len(bhs.heights)-1)], 0
+}
+
+// GetBoundedLastValues returns the last bin edge and the height of the last
+// bin.
+func (bhs *BinnedHistogramSeries) GetBoundedLastValues() (x, y1, y2 float64) {
+	return bhs.GetBoundedValues(bhs.Len() - 1)
+}
+
+// Render renders the series.
+func (bhs *BinnedHistogramSeries) Render(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, defaults render.Style) {
+	edges, heights, err := bhs.Bins()
+	if err != nil {
+		return
+	}
+
+	style := bhs.getRenderStyle(defaults)
+	y0 := canvasBox.Bottom - yrange.Translate(getBaseline(yrange))
+	for i, height := range heights {
+		if height == 0 {
+			continue
+		}
+
+		render.Box{
+			Top:    canvasBox.Bottom - yrange.Translate(height),
+			Left:   canvasBox.Left + xrange.Translate(edges[i]),
+			Right:  canvasBox.Left + xrange.Translate(edges[i+1]),
+			Bottom: y0,
+		}.Draw(r, style)
+	}
+}
+
+// getRenderStyle returns the style of the bins, which are filled with a
+// translucent stroke color by default.
+func (bhs *BinnedHistogramSeries) getRenderStyle(defaults render.Style) render.Style {
+	style := bhs.Style.InheritFrom(defaults.InheritFrom(render.Style{
+		StrokeWidth: 1.0,
+	}))
+
+	fillColor := color.NRGBAModel.Convert(style.GetStrokeColor(render.DefaultLineColor)).(color.NRGBA)
+	fillColor.A = defaultBinnedHistogramFillAlpha
+	style.FillColor = bhs.Style.GetFillColor(fillColor)
+	return style
+}
+
+// Validate validates the series.
+func (bhs *BinnedHistogramSeries) Validate() error {
+	_, _, err := bhs.Bins()
+	return err
+}
+
+func (bhs *BinnedHistogramSeries) ensureCachedValues() {
+	if bhs.edges != nil || bhs.err != nil {
+		return
+	}
+
+	samples := finiteValues(bhs.Samples)
+	edges, err := bhs.Binning.GetEdges(samples)
+	if err != nil {
+		bhs.err = err
+		return
+	}
+
+	heights := binCounts(samples, edges)
+
+	// The heights are normalized by the number of binned samples, leaving
+	// out the samples outside of explicit edges.
+	var total float64
+	for _, height := range heights {
+		total += height
+	}
+
+	if bhs.Cumulative {
+		for i := 1; i < len(heights); i++ {
+			heights[i] += heights[i-1]
+		}
+	}
+
+	for i := range heights {
+		switch {
+		case total == 0:
+		case bhs.Normalization == HistogramPercentage,
+			bhs.Normalization == HistogramDensity && bhs.Cumulative:
+			heights[i] /= total
+		case bhs.Normalization == HistogramDensity:
+			heights[i] /= total * (edges[i+1] - edges[i])
+		}
+	}
+
+	bhs.edges, bhs.heights = edges, heights
+}
@@ -0,0 +1,101 @@
+package dataset
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/unidoc/unichart/render"
+)
+
+func TestBinnedHistogramSeries(t *testing.T) {
+	bhs := &BinnedHistogramSeries{
+		Samples: []float64{0, 0.5, 1, 1.5, 1.8, 3.5},
+		Binning: HistogramBinning{Edges: []float64{0, 1, 2, 4}},
+	}
+	require.NoError(t, bhs.Validate())
+
+	edges, heights, err := bhs.Bins()
+	require.NoError(t, err)
+	require.Equal(t, []float64{0, 1, 2, 4}, edges)
+	require.Equal(t, []float64{2, 3, 1}, heights)
+
+	require.Equal(t, 4, bhs.Len())
+	x, y1, y2 := bhs.GetBoundedLastValues()
+	require.Equal(t, 4.0, x)
+	require.Equal(t, 1.0, y1)
+	require.Equal(t, 0.0, y2)
+
+	normalized := func(normalization HistogramNormalization, cumulative bool) []float64 {
+		series := &BinnedHistogramSeries{
+			Samples:       bhs.Samples,
+			Binning:       bhs.Binning,
+			Normalization: normalization,
+			Cumulative:    cumulative,
+		}
+		_, heights, err := series.Bins()
+		require.NoError(t, err)
+		return heights
+	}
+	require.InDeltaSlice(t, []float64{2.0 / 6, 3.0 / 6, 1.0 / 6}, normalized(HistogramPercentage, false), 1e-9)
+	require.InDeltaSlice(t, []float64{2.0 / 6, 3.0 / 6, 1.0 / 12}, normalized(HistogramDensity, false), 1e-9)
+	require.Equal(t, []float64{2, 5, 6}, normalized(HistogramCount, true))
+	require.InDeltaSlice(t, []float64{2.0 / 6, 5.0 / 6, 1}, normalized(HistogramDensity, true), 1e-9)
+
+	// Samples outside of explicit edges are left out of the normalization.
+	outside := &BinnedHistogramSeries{
+		Samples:       append([]float64{-5, 10}, bhs.Samples...),
+		Binning:       bhs.Binning,
+		Normalization: HistogramPercentage,
+	}
+	_, heights, err = outside.Bins()
+	require.NoError(t, err)
+	require.InDeltaSlice(t, []float64{2.0 / 6, 3.0 / 6, 1.0 / 6}, heights, 1e-9)
+
+	_, yf := (&BinnedHistogramSeries{Normalization: HistogramPercentage}).GetValueFormatters()
+	require.Equal(t, "50.00%", yf(0.5))
+
+	require.Equal(t, ErrHistogramNoSamples, (&BinnedHistogramSeries{}).Validate())
+}
+
+func TestBinnedHistogramSeriesFillColor(t *testing.T) {
+	defaults := render.Style{StrokeColor: render.ColorBlue}
+
+	// The default fill is the translucent stroke color of the series.
+	bhs := &BinnedHistogramSeries{Style: render.Style{StrokeColor: color.RGBA{R: 200, G: 100, B: 50, A: 255}}}
+	style := bhs.getRenderStyle(defaults)
+	require.Equal(t, color.NRGBA{R: 200, G: 100, B: 50, A: defaultBinnedHistogramFillAlpha}, style.FillColor)
+
+	style = (&BinnedHistogramSeries{}).getRenderStyle(defaults)
+	fillColor := color.NRGBAModel.Convert(render.ColorBlue).(color.NRGBA)
+	fillColor.A = defaultBinnedHistogramFillAlpha
+	require.Equal(t, fillColor, style.FillColor)
+
+	bhs = &BinnedHistogramSeries{Style: render.Style{FillColor: render.ColorRed}}
+	require.Equal(t, render.ColorRed, bhs.getRenderStyle(defaults).FillColor)
+}
+
+func TestHistogramBuilder(t *testing.T) {
+	hb := HistogramBuilder{Binning: HistogramBinning{Method: BinningCount, Count: 4}}
+	series, err := hb.Build(
+		HistogramSamples{Name: "a", Samples: []float64{0, 1, 2}},
+		HistogramSamples{Name: "b", Samples: []float64{6, 7, 8}},
+	)
+	require.NoError(t, err)
+	require.Len(t, series, 2)
+
+	// The distributions share the bin edges of all the samples.
+	for _, s := range series {
+		edges, _, err := s.(*BinnedHistogramSeries).Bins()
+		require.NoError(t, err)
+		require.Equal(t, []float64{0, 2, 4, 6, 8}, edges)
+	}
+	require.Equal(t, "b", series[1].GetName())
+
+	_, heights, _ := series[1].(*BinnedHistogramSeries).Bins()
+	require.Equal(t, []float64{0, 0, 0, 3}, heights)
+
+	_, err = hb.Build()
+	require.Equal(t, ErrHistogramNoSamples, err)
+}
@@ -0,0 +1,160 @@
+package dataset
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/unidoc/unichart/dataset/sequence"
+	"github.com/unidoc/unichart/mathutil"
+	"github.com/unidoc/unichart/render"
+)
+
+const (
+	// defaultConfidenceBandStrokeAlpha is the alpha of the default
+	// confidence band stroke color.
+	defaultConfidenceBandStrokeAlpha = 96
+
+	// defaultConfidenceBandFillAlpha is the alpha of the default confidence
+	// band fill color.
+	defaultConfidenceBandFillAlpha = 40
+)
+
+// Interface Assertions.
+var (
+	_ Series                = (*ConfidenceBandSeries)(nil)
+	_ BoundedValuesProvider = (*ConfidenceBandSeries)(nil)
+)
+
+// ConfidenceBandSeries draws a shaded band between lower and upper values,
+// such as the confidence interval of another series. The lower and upper
+// values are matched by index, and the band is drawn at the X values of the
+// upper values. The band is interrupted at missing (NaN) values.
+type ConfidenceBandSeries struct {
+	Name  string
+	Style render.Style
+	YAxis YAxisType
+
+	Lower ValuesProvider
+	Upper ValuesProvider
+}
+
+// GetName returns the name of the time series.
+func (cbs ConfidenceBandSeries) GetName() string {
+	return cbs.Name
+}
+
+// GetStyle returns the line style.
+func (cbs ConfidenceBandSeries) GetStyle() render.Style {
+	return cbs.Style
+}
+
+// GetYAxis returns which YAxis the series draws on.
+func (cbs ConfidenceBandSeries) GetYAxis() YAxisType {
+	return cbs.YAxis
+}
+
+// Len returns the number of elements in the series.
+func (cbs ConfidenceBandSeries) Len() int {
+	if cbs.Lower == nil || cbs.Upper == nil {
+		return 0
+	}
+	return mathutil.MinInt(cbs.Lower.Len(), cbs.Upper.Len())
+}
+
+// GetBoundedValues gets the upper and lower values of the band at the
+// given index.
+func (cbs ConfidenceBandSeries) GetBoundedValues(index int) (x, y1, y2 float64) {
+	x, y1 = cbs.Upper.GetValues(index)
+	_, y2 = cbs.Lower.GetValues(index)
+	return
+}
+
+// Render renders the series.
+func (cbs ConfidenceBandSeries) Render(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, defaults render.Style) {
+	style := confidenceBandStyle(cbs.Style, defaults)
+	drawConfidenceBands(r, canvasBox, xrange, yrange, style, cbs)
+}
+
+// Validate validates the series.
+func (cbs ConfidenceBandSeries) Validate() error {
+	if cbs.Lower == nil || cbs.Upper == nil {
+		return fmt.Errorf("confidence band series requires Lower and Upper to be set")
+	}
+	if cbs.Lower.Len() != cbs.Upper.Len() {
+		return fmt.Errorf("confidence band series; must have same length lower and upper values")
+	}
+	return nil
+}
+
+// confidenceBandStyle returns the style of a band, which defaults to
+// translucent versions of the series color.
+func confidenceBandStyle(style, defaults render.Style) render.Style {
+	seriesColor := color.NRGBAModel.Convert(defaults.GetStrokeColor(render.DefaultLineColor)).(color.NRGBA)
+	strokeColor, fillColor := seriesColor, seriesColor
+	strokeColor.A = defaultConfidenceBandStrokeAlpha
+	fillColor.A = defaultConfidenceBandFillAlpha
+
+	bandStyle := style.InheritFrom(defaults.InheritFrom(render.Style{
+		StrokeWidth: 1.0,
+	}))
+	bandStyle.StrokeColor = style.GetStrokeColor(strokeColor)
+	bandStyle.FillColor = style.GetFillColor(fillColor)
+	return bandStyle
+}
+
+// drawConfidenceBands draws the bands between the bounded values, which
+// are interrupted at missing (NaN) values.
+func drawConfidenceBands(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, style render.Style, bvp BoundedValuesProvider) {
+	var run []int
+	for i := 0; i <= bvp.Len(); i++ {
+		if i < bvp.Len() {
+			vx, vy1, vy2 := bvp.GetBoundedValues(i)
+			if mathutil.IsFinite(vx) && mathutil.IsFinite(vy1) && mathutil.IsFinite(vy2) {
+				run = append(run, i)
+				continue
+			}
+		}
+
+		drawConfidenceBand(r, canvasBox, xrange, yrange, style, bvp, run)
+		run = run[:0]
+	}
+}
+
+// drawConfidenceBand fills the band between the bounded values at the
+// specified indices, and strokes its upper and lower edges.
+func drawConfidenceBand(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, style render.Style, bvp BoundedValuesProvider, indices []int) {
+	if len(indices) == 0 {
+		return
+	}
+
+	upper := make([]linePoint, len(indices))
+	lower := make([]linePoint, len(indices))
+	for i, index := range indices {
+		vx, vy1, vy2 := bvp.GetBoundedValues(index)
+		x := canvasBox.Left + xrange.Translate(vx)
+		upper[i] = linePoint{x, canvasBox.Bottom - yrange.Translate(vy1)}
+		lower[i] = linePoint{x, canvasBox.Bottom - yrange.Translate(vy2)}
+	}
+
+	style.GetFillOptions().WriteDrawingOptionsToRenderer(r)
+	r.MoveTo(upper[0].x, upper[0].y)
+	for _, p := range upper[1:] {
+		r.LineTo(p.x, p.y)
+	}
+	for i := len(lower) - 1; i >= 0; i-- {
+		r.LineTo(lower[i].x, lower[i].y)
+	}
+	r.Close()
+	r.Fill()
+	r.ResetStyle()
+
+	style.GetStrokeOptions().WriteDrawingOptionsToRenderer(r)
+	for _, edge := range [][]linePoint{upper, lower} {
+		r.MoveTo(edge[0].x, edge[0].y)
+		for _, p := range edge[1:] {
+			r.LineTo(p.x, p.y)
+		}
+		r.Stroke()
+	}
+	r.ResetStyle()
+}
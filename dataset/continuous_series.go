@@ -12,6 +12,8 @@ var (
 	_ Series              = (*ContinuousSeries)(nil)
 	_ FirstValuesProvider = (*ContinuousSeries)(nil)
 	_ LastValuesProvider  = (*ContinuousSeries)(nil)
+	_ XAxisProvider       = (*ContinuousSeries)(nil)
+	_ ErrorBoundsProvider = (*ContinuousSeries)(nil)
 )
 
 // ContinuousSeries represents a line on a chart.
@@ -19,12 +21,17 @@ type ContinuousSeries struct {
 	Name  string
 	Style render.Style
 	YAxis YAxisType
+	XAxis XAxisType
 
 	XValueFormatter ValueFormatter
 	YValueFormatter ValueFormatter
 
 	XValues []float64
 	YValues []float64
+
+	// ErrorBars holds the optional errors of the values, drawn as error
+	// bars around the points of the series.
+	ErrorBars ErrorBars
 }
 
 // GetName returns the name of the time series.
@@ -47,6 +54,13 @@ func (cs ContinuousSeries) GetValues(index int) (float64, float64) {
 	return cs.XValues[index], cs.YValues[index]
 }
 
+// GetErrorBounds gets the error bounds of the x,y values at a given index.
+func (cs ContinuousSeries) GetErrorBounds(index int) (xlow, xhigh, ylow, yhigh float64) {
+	xlow, xhigh = cs.ErrorBars.GetXBounds(index, cs.XValues[index])
+	ylow, yhigh = cs.ErrorBars.GetYBounds(index, cs.YValues[index])
+	return
+}
+
 // GetFirstValues gets the first x,y values.
 func (cs ContinuousSeries) GetFirstValues() (float64, float64) {
 	return cs.XValues[0], cs.YValues[0]
@@ -77,10 +91,16 @@ func (cs ContinuousSeries) GetYAxis() YAxisType {
 	return cs.YAxis
 }
 
+// GetXAxis returns which XAxis the series draws on.
+func (cs ContinuousSeries) GetXAxis() XAxisType {
+	return cs.XAxis
+}
+
 // Render renders the series.
 func (cs ContinuousSeries) Render(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, defaults render.Style) {
 	style := cs.Style.InheritFrom(defaults)
 	drawLineSeries(r, canvasBox, xrange, yrange, style, cs)
+	drawErrorBars(r, canvasBox, xrange, yrange, style, cs, cs.ErrorBars)
 }
 
 // Validate validates the series.
@@ -96,5 +116,5 @@ func (cs ContinuousSeries) Validate() error {
 	if len(cs.XValues) != len(cs.YValues) {
 		return fmt.Errorf("continuous series; must have same length xvalues as yvalues")
 	}
-	return nil
+	return cs.ErrorBars.Validate(len(cs.XValues))
 }
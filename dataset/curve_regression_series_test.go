@@ -0,0 +1,141 @@
+package dataset
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/unidoc/unichart/mathutil"
+)
+
+func TestExponentialRegressionSeries(t *testing.T) {
+	var xv, yv []float64
+	for i := 0; i < 20; i++ {
+		xv = append(xv, float64(i))
+		yv = append(yv, 3*math.Exp(0.2*float64(i)))
+	}
+
+	ers := &ExponentialRegressionSeries{InnerSeries: ContinuousSeries{XValues: xv, YValues: yv}}
+	require.Nil(t, ers.Validate())
+
+	a, b, err := ers.Coefficients()
+	require.Nil(t, err)
+	require.InDelta(t, 3, a, 1e-6)
+	require.InDelta(t, 0.2, b, 1e-6)
+
+	x, y := ers.GetLastValues()
+	require.Equal(t, 19.0, x)
+	require.InDelta(t, yv[19], y, 1e-6)
+
+	invalid := &ExponentialRegressionSeries{InnerSeries: ContinuousSeries{XValues: []float64{1, 2}, YValues: []float64{1, -1}}}
+	require.NotNil(t, invalid.Validate())
+	_, y = invalid.GetValues(0)
+	require.True(t, math.IsNaN(y))
+}
+
+func TestLogarithmicRegressionSeries(t *testing.T) {
+	var xv, yv []float64
+	for i := 1; i <= 20; i++ {
+		xv = append(xv, float64(i))
+		yv = append(yv, 2+1.5*math.Log(float64(i)))
+	}
+
+	lgrs := &LogarithmicRegressionSeries{InnerSeries: ContinuousSeries{XValues: xv, YValues: yv}}
+	require.Nil(t, lgrs.Validate())
+
+	a, b, err := lgrs.Coefficients()
+	require.Nil(t, err)
+	require.InDelta(t, 2, a, 1e-6)
+	require.InDelta(t, 1.5, b, 1e-6)
+
+	for i := range xv {
+		_, y := lgrs.GetValues(i)
+		require.InDelta(t, yv[i], y, 1e-6)
+	}
+}
+
+func TestPowerRegressionSeries(t *testing.T) {
+	var xv, yv []float64
+	for i := 1; i <= 20; i++ {
+		xv = append(xv, float64(i))
+		yv = append(yv, 0.5*math.Pow(float64(i), 1.7))
+	}
+
+	pwrs := &PowerRegressionSeries{InnerSeries: ContinuousSeries{XValues: xv, YValues: yv}, Offset: 5, Limit: 10}
+	require.Nil(t, pwrs.Validate())
+	require.Equal(t, 10, pwrs.Len())
+
+	a, b, err := pwrs.Coefficients()
+	require.Nil(t, err)
+	require.InDelta(t, 0.5, a, 1e-6)
+	require.InDelta(t, 1.7, b, 1e-6)
+
+	x, _ := pwrs.GetFirstValues()
+	require.Equal(t, 6.0, x)
+}
+
+func TestLoessSeries(t *testing.T) {
+	var xv, yv []float64
+	for i := 0; i < 50; i++ {
+		xv = append(xv, float64(i))
+		yv = append(yv, 2*float64(i)+1+math.Sin(float64(i)))
+	}
+	yv[25] = 200
+
+	ls := &LoessSeries{InnerSeries: ContinuousSeries{XValues: xv, YValues: yv}}
+	require.Nil(t, ls.Validate())
+	require.Equal(t, 0.3, ls.GetBandwidth())
+	require.Equal(t, 2, ls.GetIterations())
+	require.Equal(t, len(xv), ls.Len())
+
+	// The robustness iterations suppress the outlier.
+	x, y := ls.GetValues(25)
+	require.Equal(t, 25.0, x)
+	require.InDelta(t, 51, y, 1)
+
+	x, y = ls.GetLastValues()
+	require.Equal(t, 49.0, x)
+	require.InDelta(t, 99, y, 2)
+
+	require.NotNil(t, (&LoessSeries{}).Validate())
+
+	// Errors of the fit are reported by Validate, and leave the series empty.
+	ls = &LoessSeries{InnerSeries: ContinuousSeries{XValues: xv, YValues: yv}}
+	ls.err = mathutil.ErrPolyRegArraysSameLength
+	require.Equal(t, mathutil.ErrPolyRegArraysSameLength, ls.Validate())
+	require.Equal(t, 0, ls.Len())
+}
+
+func TestCurveRegressionSeriesTimeValues(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ts := TimeSeries{}
+	for i := 0; i < 30; i++ {
+		ts.XValues = append(ts.XValues, start.AddDate(0, 0, i))
+		ts.YValues = append(ts.YValues, 100*math.Exp(0.1*float64(i)))
+	}
+
+	testCases := []struct {
+		series interface {
+			Series
+			ValuesProvider
+		}
+		epsilon float64
+	}{
+		{&ExponentialRegressionSeries{InnerSeries: ts}, 1e-6},
+		{&PowerRegressionSeries{InnerSeries: ts}, 1e-3},
+		// The local linear fits flatten the curvature of the values.
+		{&LoessSeries{InnerSeries: ts}, 0.05},
+	}
+	for _, tc := range testCases {
+		require.Nil(t, tc.series.Validate())
+		require.Equal(t, ts.Len(), tc.series.Len())
+		for i := 0; i < tc.series.Len(); i++ {
+			x, y := tc.series.GetValues(i)
+			require.Equal(t, float64(ts.XValues[i].UnixNano()), x)
+			require.True(t, mathutil.IsFinite(y))
+			require.InEpsilon(t, ts.YValues[i], y, tc.epsilon)
+		}
+	}
+}
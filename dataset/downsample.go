@@ -0,0 +1,248 @@
+package dataset
+
+import (
+	"math"
+	"sort"
+
+	"github.com/unidoc/unichart/dataset/sequence"
+	"github.com/unidoc/unichart/mathutil"
+	"github.com/unidoc/unichart/render"
+)
+
+// downsampledValues is a ValuesProvider for downsampled line series values.
+// It holds the indices of the kept values in the original series.
+type downsampledValues struct {
+	vs      ValuesProvider
+	indices []int
+}
+
+// Len returns the number of values.
+func (dv downsampledValues) Len() int {
+	return len(dv.indices)
+}
+
+// GetValues gets the x,y values at a given index.
+func (dv downsampledValues) GetValues(index int) (float64, float64) {
+	return dv.vs.GetValues(dv.indices[index])
+}
+
+// sourceIndex returns the index in the original series of the value at the
+// given index of the specified values, which may have been downsampled.
+func sourceIndex(vs ValuesProvider, index int) int {
+	if dv, ok := vs.(downsampledValues); ok {
+		return dv.indices[index]
+	}
+	return index
+}
+
+// DownsampleLTTB reduces the specified values to the given number of points
+// using the Largest-Triangle-Three-Buckets algorithm. The X values must be
+// sorted in ascending order. The first and last values are always kept.
+func DownsampleLTTB(xvalues, yvalues []float64, threshold int) (x, y []float64) {
+	return pickValues(xvalues, yvalues, lttbIndices(xvalues, yvalues, threshold))
+}
+
+// lttbIndices returns the indices of the values kept by DownsampleLTTB.
+func lttbIndices(xvalues, yvalues []float64, threshold int) []int {
+	n := mathutil.MinInt(len(xvalues), len(yvalues))
+	if threshold >= n || threshold < 3 {
+		indices := make([]int, n)
+		for i := range indices {
+			indices[i] = i
+		}
+		return indices
+	}
+
+	indices := make([]int, 0, threshold)
+	indices = append(indices, 0)
+
+	every := float64(n-2) / float64(threshold-2)
+	a := 0
+	for i := 0; i < threshold-2; i++ {
+		// Compute the average point of the next bucket.
+		avgStart := int(math.Floor(float64(i+1)*every)) + 1
+		avgEnd := mathutil.MinInt(int(math.Floor(float64(i+2)*every))+1, n)
+		if avgStart >= avgEnd {
+			avgStart, avgEnd = n-1, n
+		}
+
+		var avgX, avgY float64
+		for j := avgStart; j < avgEnd; j++ {
+			avgX += xvalues[j]
+			avgY += yvalues[j]
+		}
+		avgX /= float64(avgEnd - avgStart)
+		avgY /= float64(avgEnd - avgStart)
+
+		// Select the point of the current bucket forming the largest
+		// triangle with the previously selected point and the average.
+		rangeStart := int(math.Floor(float64(i)*every)) + 1
+		rangeEnd := int(math.Floor(float64(i+1)*every)) + 1
+
+		ax, ay := xvalues[a], yvalues[a]
+		maxArea, next := -1.0, rangeStart
+		for j := rangeStart; j < rangeEnd; j++ {
+			area := math.Abs((ax-avgX)*(yvalues[j]-ay) - (ax-xvalues[j])*(avgY-ay))
+			if area > maxArea {
+				maxArea, next = area, j
+			}
+		}
+
+		indices = append(indices, next)
+		a = next
+	}
+
+	return append(indices, n-1)
+}
+
+// DownsampleMinMax reduces the specified values by keeping the first, last,
+// minimum and maximum values of each group of consecutive values falling
+// into the same pixel column of the given range.
+func DownsampleMinMax(xvalues, yvalues []float64, xrange sequence.Range) (x, y []float64) {
+	return pickValues(xvalues, yvalues, minMaxIndices(xvalues, yvalues, xrange))
+}
+
+// minMaxIndices returns the indices of the values kept by DownsampleMinMax.
+func minMaxIndices(xvalues, yvalues []float64, xrange sequence.Range) []int {
+	n := mathutil.MinInt(len(xvalues), len(yvalues))
+
+	var kept, group []int
+	flush := func() {
+		if len(group) == 0 {
+			return
+		}
+
+		first, last := group[0], group[len(group)-1]
+		min, max := first, first
+		for _, index := range group {
+			if yvalues[index] < yvalues[min] {
+				min = index
+			}
+			if yvalues[index] > yvalues[max] {
+				max = index
+			}
+		}
+
+		indices := []int{first, min, max, last}
+		sort.Ints(indices)
+		for i, index := range indices {
+			if i > 0 && index == indices[i-1] {
+				continue
+			}
+			kept = append(kept, index)
+		}
+		group = group[:0]
+	}
+
+	column := 0
+	for i := 0; i < n; i++ {
+		c := xrange.Translate(xvalues[i])
+		if len(group) > 0 && c != column {
+			flush()
+		}
+		column = c
+		group = append(group, i)
+	}
+	flush()
+
+	return kept
+}
+
+// pickValues returns the values at the specified indices.
+func pickValues(xvalues, yvalues []float64, indices []int) (x, y []float64) {
+	x = make([]float64, len(indices))
+	y = make([]float64, len(indices))
+	for i, index := range indices {
+		x[i], y[i] = xvalues[index], yvalues[index]
+	}
+	return x, y
+}
+
+// downsample reduces the values of a line series before drawing, according
+// to the specified downsampling mode. Only the values within the X range,
+// along with their closest neighbors outside it, are kept. Missing values
+// are preserved, so that gaps in the line are still drawn.
+func downsample(vs ValuesProvider, mode render.DownsamplingMode, canvasBox render.Box, xrange sequence.Range) ValuesProvider {
+	if mode != render.DownsamplingModeLTTB && mode != render.DownsamplingModeMinMax {
+		return vs
+	}
+
+	threshold := 2 * canvasBox.Width()
+	if threshold <= 0 || vs.Len() <= threshold {
+		return vs
+	}
+
+	xvalues, yvalues, offset := visibleValues(vs, xrange)
+	dv := downsampledValues{vs: vs}
+	if len(xvalues) <= threshold {
+		for i := range xvalues {
+			dv.indices = append(dv.indices, offset+i)
+		}
+		return dv
+	}
+
+	appendRun := func(start, end int) {
+		if start >= end {
+			return
+		}
+
+		var indices []int
+		if mode == render.DownsamplingModeMinMax {
+			indices = minMaxIndices(xvalues[start:end], yvalues[start:end], xrange)
+		} else {
+			runThreshold := int(math.Ceil(float64(threshold) * float64(end-start) / float64(len(xvalues))))
+			indices = lttbIndices(xvalues[start:end], yvalues[start:end], mathutil.MaxInt(runThreshold, 3))
+		}
+		for _, index := range indices {
+			dv.indices = append(dv.indices, offset+start+index)
+		}
+	}
+
+	start := 0
+	for i := range xvalues {
+		if mathutil.IsFinite(xvalues[i]) && mathutil.IsFinite(yvalues[i]) {
+			continue
+		}
+
+		appendRun(start, i)
+		dv.indices = append(dv.indices, offset+i)
+		start = i + 1
+	}
+	appendRun(start, len(xvalues))
+
+	return dv
+}
+
+// visibleValues returns the values within the X range, along with their
+// closest neighbors outside of it, and the index of the first one. All the
+// values are returned if the X values are not sorted.
+func visibleValues(vs ValuesProvider, xrange sequence.Range) (xvalues, yvalues []float64, offset int) {
+	n := vs.Len()
+	xvalues = make([]float64, n)
+	yvalues = make([]float64, n)
+
+	sorted := true
+	prev := math.Inf(-1)
+	for i := 0; i < n; i++ {
+		xvalues[i], yvalues[i] = vs.GetValues(i)
+		if mathutil.IsFinite(xvalues[i]) {
+			if xvalues[i] < prev {
+				sorted = false
+			}
+			prev = xvalues[i]
+		}
+	}
+	if !sorted || xrange == nil || xrange.IsZero() {
+		return xvalues, yvalues, 0
+	}
+
+	min, max := xrange.GetMin(), xrange.GetMax()
+	start, end := 0, n
+	for start < n-1 && !(xvalues[start+1] >= min) {
+		start++
+	}
+	for end > start+1 && !(xvalues[end-2] <= max) {
+		end--
+	}
+	return xvalues[start:end], yvalues[start:end], start
+}
@@ -0,0 +1,116 @@
+package dataset
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/unidoc/unichart/dataset/sequence"
+	"github.com/unidoc/unichart/render"
+	"github.com/unidoc/unichart/render/raster"
+)
+
+func TestDownsampleLTTB(t *testing.T) {
+	var xvalues, yvalues []float64
+	for i := 0; i < 100; i++ {
+		xvalues = append(xvalues, float64(i))
+		yvalues = append(yvalues, 0)
+	}
+	yvalues[42] = 10
+
+	x, y := DownsampleLTTB(xvalues, yvalues, 10)
+	require.Len(t, x, 10)
+	require.Len(t, y, 10)
+	require.Equal(t, 0.0, x[0])
+	require.Equal(t, 99.0, x[9])
+	require.Contains(t, x, 42.0)
+	require.Contains(t, y, 10.0)
+
+	x, _ = DownsampleLTTB(xvalues[:5], yvalues[:5], 10)
+	require.Len(t, x, 5)
+}
+
+func TestDownsampleMinMax(t *testing.T) {
+	xvalues := []float64{0, 1, 2, 3, 4, 5, 6, 7}
+	yvalues := []float64{1, 5, -2, 3, 4, 4, 9, 0}
+	xrange := &sequence.ContinuousRange{Min: 0, Max: 7, Domain: 2}
+
+	x, y := DownsampleMinMax(xvalues, yvalues, xrange)
+	require.Equal(t, []float64{0, 1, 2, 3, 4, 6, 7}, x)
+	require.Equal(t, []float64{1, 5, -2, 3, 4, 9, 0}, y)
+}
+
+func TestDownsampleSeries(t *testing.T) {
+	cs := ContinuousSeries{}
+	for i := 0; i < 1000; i++ {
+		cs.XValues = append(cs.XValues, float64(i))
+		cs.YValues = append(cs.YValues, math.Sin(float64(i)))
+	}
+	cs.YValues[500] = math.NaN()
+
+	canvasBox := render.Box{Right: 50, Bottom: 50}
+	xrange := &sequence.ContinuousRange{Min: 0, Max: 999, Domain: 50}
+
+	vs := downsample(cs, render.DownsamplingModeNone, canvasBox, xrange)
+	require.Equal(t, 1000, vs.Len())
+
+	for _, mode := range []render.DownsamplingMode{render.DownsamplingModeLTTB, render.DownsamplingModeMinMax} {
+		vs = downsample(cs, mode, canvasBox, xrange)
+		require.True(t, vs.Len() < 250)
+
+		var gaps int
+		for i := 0; i < vs.Len(); i++ {
+			if _, y := vs.GetValues(i); math.IsNaN(y) {
+				gaps++
+			}
+		}
+		require.Equal(t, 1, gaps)
+	}
+
+	// Only the visible values and their neighbors are kept.
+	xrange = &sequence.ContinuousRange{Min: 100.5, Max: 110.5, Domain: 50}
+	vs = downsample(cs, render.DownsamplingModeLTTB, canvasBox, xrange)
+	require.Equal(t, 12, vs.Len())
+	x, _ := vs.GetValues(0)
+	require.Equal(t, 100.0, x)
+	require.Equal(t, 100, sourceIndex(vs, 0))
+	require.Equal(t, 111, sourceIndex(vs, vs.Len()-1))
+}
+
+func TestDownsampleDots(t *testing.T) {
+	cs := ContinuousSeries{}
+	for i := 0; i < 1000; i++ {
+		cs.XValues = append(cs.XValues, float64(i))
+		cs.YValues = append(cs.YValues, math.Sin(float64(i)))
+	}
+
+	// Dots are only drawn at the kept values, and the dot providers are
+	// called with the indices of the series values.
+	seen := map[int]bool{}
+	style := render.Style{
+		StrokeWidth:      1,
+		StrokeColor:      render.ColorBlue,
+		DotWidth:         1,
+		DownsamplingMode: render.DownsamplingModeLTTB,
+		DotWidthProvider: func(_, _ sequence.Range, index int, x, y float64) float64 {
+			vx, vy := cs.GetValues(index)
+			require.Equal(t, vx, x)
+			require.Equal(t, vy, y)
+			seen[index] = true
+			return 1
+		},
+	}
+
+	r, err := raster.NewRenderer(50, 50)
+	require.NoError(t, err)
+
+	canvasBox := render.Box{Right: 50, Bottom: 50}
+	xrange := &sequence.ContinuousRange{Min: 0, Max: 999, Domain: 50}
+	yrange := &sequence.ContinuousRange{Min: -1, Max: 1, Domain: 50}
+	drawLineSeries(r, canvasBox, xrange, yrange, style, cs)
+	require.Len(t, seen, downsample(cs, style.DownsamplingMode, canvasBox, xrange).Len())
+	require.True(t, len(seen) < 250)
+	require.True(t, seen[0])
+	require.True(t, seen[999])
+}
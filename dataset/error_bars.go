@@ -0,0 +1,89 @@
+package dataset
+
+import (
+	"fmt"
+
+	"github.com/unidoc/unichart/dataset/sequence"
+	"github.com/unidoc/unichart/mathutil"
+	"github.com/unidoc/unichart/render"
+)
+
+// ErrorBars holds the errors of the values of a series, drawn as error
+// bars. The errors are relative to the values: the upper bound of an X
+// value is x+XErrors[i] and its lower bound is x-XLowerErrors[i]. Lower
+// errors which are not set, including the ones past the end of a shorter
+// slice and missing (NaN) ones, default to the upper ones, resulting in
+// symmetric error bars. Missing (NaN) upper errors leave the upper bounds at
+// the values, so that only the lower errors are drawn.
+type ErrorBars struct {
+	XErrors      []float64
+	XLowerErrors []float64
+	YErrors      []float64
+	YLowerErrors []float64
+}
+
+// IsZero returns true if no errors are set.
+func (eb ErrorBars) IsZero() bool {
+	return len(eb.XErrors) == 0 && len(eb.XLowerErrors) == 0 &&
+		len(eb.YErrors) == 0 && len(eb.YLowerErrors) == 0
+}
+
+// GetXBounds returns the error bounds of the X value at the specified index.
+func (eb ErrorBars) GetXBounds(index int, x float64) (low, high float64) {
+	return errorBounds(eb.XErrors, eb.XLowerErrors, index, x)
+}
+
+// GetYBounds returns the error bounds of the Y value at the specified index.
+func (eb ErrorBars) GetYBounds(index int, y float64) (low, high float64) {
+	return errorBounds(eb.YErrors, eb.YLowerErrors, index, y)
+}
+
+// Validate validates the errors against the number of values of a series.
+func (eb ErrorBars) Validate(length int) error {
+	for _, errs := range [][]float64{eb.XErrors, eb.XLowerErrors, eb.YErrors, eb.YLowerErrors} {
+		if len(errs) > length {
+			return fmt.Errorf("error bars; must not have more errors than values")
+		}
+		for _, e := range errs {
+			if e < 0 {
+				return fmt.Errorf("error bars; errors must not be negative")
+			}
+		}
+	}
+	return nil
+}
+
+func errorBounds(upper, lower []float64, index int, value float64) (low, high float64) {
+	low, high = value, value
+	if index < len(upper) && mathutil.IsFinite(upper[index]) {
+		low, high = value-upper[index], value+upper[index]
+	}
+	if index < len(lower) && mathutil.IsFinite(lower[index]) {
+		low = value - lower[index]
+	}
+	return low, high
+}
+
+// drawErrorBars draws the error bars of the values of a series.
+func drawErrorBars(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, style render.Style, vs ValuesProvider, eb ErrorBars) {
+	if eb.IsZero() {
+		return
+	}
+
+	for i := 0; i < vs.Len(); i++ {
+		vx, vy := vs.GetValues(i)
+		if !mathutil.IsFinite(vx) || !mathutil.IsFinite(vy) {
+			continue
+		}
+
+		x := canvasBox.Left + xrange.Translate(vx)
+		y := canvasBox.Bottom - yrange.Translate(vy)
+
+		if ylow, yhigh := eb.GetYBounds(i, vy); ylow != yhigh {
+			render.DrawErrorBar(r, x, canvasBox.Bottom-yrange.Translate(ylow), x, canvasBox.Bottom-yrange.Translate(yhigh), style)
+		}
+		if xlow, xhigh := eb.GetXBounds(i, vx); xlow != xhigh {
+			render.DrawErrorBar(r, canvasBox.Left+xrange.Translate(xlow), y, canvasBox.Left+xrange.Translate(xhigh), y, style)
+		}
+	}
+}
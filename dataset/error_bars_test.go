@@ -0,0 +1,99 @@
+package dataset
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/unidoc/unichart/dataset/sequence"
+	"github.com/unidoc/unichart/render"
+	"github.com/unidoc/unichart/render/raster"
+)
+
+func TestErrorBarsBounds(t *testing.T) {
+	eb := ErrorBars{
+		XErrors:      []float64{1},
+		YErrors:      []float64{2, math.NaN(), 1},
+		YLowerErrors: []float64{0.5},
+	}
+
+	low, high := eb.GetXBounds(0, 10)
+	require.Equal(t, 9.0, low)
+	require.Equal(t, 11.0, high)
+
+	low, high = eb.GetXBounds(1, 10)
+	require.Equal(t, 10.0, low)
+	require.Equal(t, 10.0, high)
+
+	low, high = eb.GetYBounds(0, 10)
+	require.Equal(t, 9.5, low)
+	require.Equal(t, 12.0, high)
+
+	low, high = eb.GetYBounds(1, 10)
+	require.Equal(t, 10.0, low)
+	require.Equal(t, 10.0, high)
+
+	// Lower errors past the end of the slice default to the upper ones.
+	low, high = eb.GetYBounds(2, 10)
+	require.Equal(t, 9.0, low)
+	require.Equal(t, 11.0, high)
+
+	eb.YLowerErrors = []float64{math.NaN(), 3}
+	low, high = eb.GetYBounds(0, 10)
+	require.Equal(t, 8.0, low)
+	require.Equal(t, 12.0, high)
+
+	// Lower errors are drawn even if the upper ones are missing.
+	low, high = eb.GetYBounds(1, 10)
+	require.Equal(t, 7.0, low)
+	require.Equal(t, 10.0, high)
+
+	require.NoError(t, eb.Validate(3))
+	require.Error(t, eb.Validate(2))
+	require.Error(t, ErrorBars{YErrors: []float64{-1}}.Validate(1))
+}
+
+func TestContinuousSeriesErrorBounds(t *testing.T) {
+	cs := ContinuousSeries{
+		XValues:   []float64{1, 2},
+		YValues:   []float64{3, 4},
+		ErrorBars: ErrorBars{YErrors: []float64{0.5, 1}},
+	}
+	require.NoError(t, cs.Validate())
+
+	xlow, xhigh, ylow, yhigh := cs.GetErrorBounds(1)
+	require.Equal(t, 2.0, xlow)
+	require.Equal(t, 2.0, xhigh)
+	require.Equal(t, 3.0, ylow)
+	require.Equal(t, 5.0, yhigh)
+}
+
+func TestConfidenceBandSeries(t *testing.T) {
+	cbs := ConfidenceBandSeries{
+		Lower: ContinuousSeries{XValues: []float64{0, 1, 2, 3}, YValues: []float64{1, 1, math.NaN(), 1}},
+		Upper: ContinuousSeries{XValues: []float64{0, 1, 2, 3}, YValues: []float64{3, 3, 3, 3}},
+	}
+	require.NoError(t, cbs.Validate())
+	require.Equal(t, 4, cbs.Len())
+
+	x, y1, y2 := cbs.GetBoundedValues(1)
+	require.Equal(t, 1.0, x)
+	require.Equal(t, 3.0, y1)
+	require.Equal(t, 1.0, y2)
+
+	r, err := raster.NewRenderer(40, 40)
+	require.NoError(t, err)
+
+	canvasBox := render.Box{Right: 40, Bottom: 40}
+	xrange := &sequence.ContinuousRange{Min: 0, Max: 4, Domain: 40}
+	yrange := &sequence.ContinuousRange{Min: 0, Max: 4, Domain: 40}
+	cbs.Render(r, canvasBox, xrange, yrange, render.Style{StrokeColor: render.ColorBlue})
+
+	// The band is interrupted at the missing value.
+	img := r.(*raster.Renderer).Image()
+	require.NotZero(t, img.RGBAAt(5, 20).A)
+	require.Zero(t, img.RGBAAt(25, 20).A)
+
+	require.Error(t, ConfidenceBandSeries{Upper: cbs.Upper}.Validate())
+}
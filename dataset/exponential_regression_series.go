@@ -0,0 +1,148 @@
+package dataset
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/unidoc/unichart/dataset/sequence"
+	"github.com/unidoc/unichart/mathutil"
+	"github.com/unidoc/unichart/render"
+)
+
+// Interface Assertions.
+var (
+	_ Series              = (*ExponentialRegressionSeries)(nil)
+	_ FirstValuesProvider = (*ExponentialRegressionSeries)(nil)
+	_ LastValuesProvider  = (*ExponentialRegressionSeries)(nil)
+)
+
+// ExponentialRegressionSeries plots the exponential regression `y = a*e^(b*x)`
+// for the values of an inner series. The Y values must be positive. The
+// regression is fitted on X values centered on their mean, so that large X
+// values, such as times, do not underflow the fit.
+type ExponentialRegressionSeries struct {
+	Name  string
+	Style render.Style
+	YAxis YAxisType
+
+	Limit       int
+	Offset      int
+	InnerSeries ValuesProvider
+
+	a      float64
+	b      float64
+	avgx   float64
+	fitted bool
+}
+
+// Coefficients returns the `a` and `b` coefficients of the regression.
+func (ers *ExponentialRegressionSeries) Coefficients() (a, b float64, err error) {
+	if err := ers.ensureFitted(); err != nil {
+		return 0, 0, err
+	}
+	return ers.a * math.Exp(-ers.b*ers.avgx), ers.b, nil
+}
+
+// GetName returns the name of the time series.
+func (ers ExponentialRegressionSeries) GetName() string {
+	return ers.Name
+}
+
+// GetStyle returns the line style.
+func (ers ExponentialRegressionSeries) GetStyle() render.Style {
+	return ers.Style
+}
+
+// GetYAxis returns which YAxis the series draws on.
+func (ers ExponentialRegressionSeries) GetYAxis() YAxisType {
+	return ers.YAxis
+}
+
+// Len returns the number of elements in the series.
+func (ers ExponentialRegressionSeries) Len() int {
+	return mathutil.MinInt(ers.GetLimit(), ers.InnerSeries.Len()-ers.GetOffset())
+}
+
+// GetLimit returns the window size.
+func (ers ExponentialRegressionSeries) GetLimit() int {
+	if ers.Limit == 0 {
+		return ers.InnerSeries.Len()
+	}
+	return ers.Limit
+}
+
+// GetEndIndex returns the effective limit end.
+func (ers ExponentialRegressionSeries) GetEndIndex() int {
+	windowEnd := ers.GetOffset() + ers.GetLimit()
+	innerSeriesLastIndex := ers.InnerSeries.Len() - 1
+	return mathutil.MinInt(windowEnd, innerSeriesLastIndex)
+}
+
+// GetOffset returns the data offset.
+func (ers ExponentialRegressionSeries) GetOffset() int {
+	if ers.Offset == 0 {
+		return 0
+	}
+	return ers.Offset
+}
+
+// GetValues gets a value at a given index.
+func (ers *ExponentialRegressionSeries) GetValues(index int) (x, y float64) {
+	if ers.InnerSeries == nil || ers.InnerSeries.Len() == 0 {
+		return
+	}
+	if err := ers.ensureFitted(); err != nil {
+		return math.NaN(), math.NaN()
+	}
+	effectiveIndex := mathutil.MinInt(index+ers.GetOffset(), ers.InnerSeries.Len()-1)
+	x, _ = ers.InnerSeries.GetValues(effectiveIndex)
+	return x, ers.apply(x)
+}
+
+// GetFirstValues computes the first regression value.
+func (ers *ExponentialRegressionSeries) GetFirstValues() (x, y float64) {
+	return ers.GetValues(0)
+}
+
+// GetLastValues computes the last regression value.
+func (ers *ExponentialRegressionSeries) GetLastValues() (x, y float64) {
+	return ers.GetValues(ers.Len() - 1)
+}
+
+// Render renders the series.
+func (ers *ExponentialRegressionSeries) Render(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, defaults render.Style) {
+	style := ers.Style.InheritFrom(defaults)
+	drawLineSeries(r, canvasBox, xrange, yrange, style, ers)
+}
+
+// Validate validates the series.
+func (ers *ExponentialRegressionSeries) Validate() error {
+	if ers.InnerSeries == nil {
+		return fmt.Errorf("exponential regression series requires InnerSeries to be set")
+	}
+	return ers.ensureFitted()
+}
+
+func (ers *ExponentialRegressionSeries) apply(x float64) float64 {
+	return ers.a * math.Exp(ers.b*(x-ers.avgx))
+}
+
+func (ers *ExponentialRegressionSeries) ensureFitted() error {
+	if ers.fitted {
+		return nil
+	}
+
+	xvalues, yvalues := regressionWindow(ers.InnerSeries, ers.GetOffset(), ers.Len())
+	avgx := mathutil.Mean(xvalues...)
+	centered := make([]float64, len(xvalues))
+	for i, x := range xvalues {
+		centered[i] = x - avgx
+	}
+
+	a, b, err := mathutil.ExpRegression(centered, yvalues)
+	if err != nil {
+		return err
+	}
+	ers.a, ers.b, ers.avgx, ers.fitted = a, b, avgx, true
+	return nil
+}
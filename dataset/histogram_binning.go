@@ -0,0 +1,177 @@
+package dataset
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/unidoc/unichart/dataset/sequence"
+	"github.com/unidoc/unichart/mathutil"
+)
+
+const (
+	// maxHistogramBins is the maximum number of bins chosen by a binning
+	// method.
+	maxHistogramBins = 1000
+)
+
+var (
+	// ErrHistogramNoSamples is returned when binning a histogram without
+	// finite samples.
+	ErrHistogramNoSamples = errors.New("histogram; no finite samples to bin")
+
+	// ErrHistogramInvalidEdges is returned when the explicit bin edges of a
+	// histogram are not strictly increasing.
+	ErrHistogramInvalidEdges = errors.New("histogram; bin edges must be at least two strictly increasing values")
+)
+
+// BinningMethod is the method used to choose the bins of a histogram.
+type BinningMethod int
+
+// BinningMethod values.
+const (
+	// BinningSturges chooses ceil(log2(n))+1 bins of equal width.
+	BinningSturges BinningMethod = iota
+	// BinningCount uses a fixed number of bins of equal width.
+	BinningCount
+	// BinningWidth uses bins of a fixed width, aligned to multiples of the
+	// width.
+	BinningWidth
+	// BinningFreedmanDiaconis chooses bins of width 2*IQR/cbrt(n), which is
+	// robust to outliers.
+	BinningFreedmanDiaconis
+)
+
+// HistogramBinning defines how the samples of a histogram are binned.
+// Explicit Edges take precedence over the binning method. Samples outside of
+// explicit edges are ignored. Each bin includes its lower edge, and the last
+// bin also includes its upper edge. Methods whose parameters are missing or
+// would produce too many bins fall back to BinningSturges.
+type HistogramBinning struct {
+	Method BinningMethod
+	Count  int
+	Width  float64
+	Edges  []float64
+}
+
+// GetEdges returns the bin edges of the specified samples. Non-finite
+// samples are ignored.
+func (hb HistogramBinning) GetEdges(samples []float64) ([]float64, error) {
+	if len(hb.Edges) > 0 {
+		if len(hb.Edges) < 2 {
+			return nil, ErrHistogramInvalidEdges
+		}
+		for i := 1; i < len(hb.Edges); i++ {
+			if !(hb.Edges[i] > hb.Edges[i-1]) {
+				return nil, ErrHistogramInvalidEdges
+			}
+		}
+		return append([]float64{}, hb.Edges...), nil
+	}
+
+	values := finiteValues(samples)
+	if len(values) == 0 {
+		return nil, ErrHistogramNoSamples
+	}
+
+	min, max := sequence.NewWrapper(sequence.ArraySequence(values)).MinMax()
+	if min == max {
+		min, max = min-0.5, max+0.5
+	}
+
+	switch hb.Method {
+	case BinningCount:
+		if hb.Count > 0 {
+			return equalWidthEdges(min, max, mathutil.MinInt(hb.Count, maxHistogramBins)), nil
+		}
+	case BinningWidth:
+		if hb.Width > 0 && (max-min)/hb.Width < maxHistogramBins {
+			return fixedWidthEdges(min, max, hb.Width), nil
+		}
+	case BinningFreedmanDiaconis:
+		sorted := append([]float64{}, values...)
+		sort.Float64s(sorted)
+		iqr := quantile(sorted, 0.75) - quantile(sorted, 0.25)
+		width := 2 * iqr / math.Cbrt(float64(len(values)))
+		if width > 0 && (max-min)/width < maxHistogramBins {
+			return fixedWidthEdges(min, max, width), nil
+		}
+	}
+
+	count := int(math.Ceil(math.Log2(float64(len(values))))) + 1
+	return equalWidthEdges(min, max, count), nil
+}
+
+// finiteValues returns the finite values of the specified values.
+func finiteValues(values []float64) []float64 {
+	finite := make([]float64, 0, len(values))
+	for _, value := range values {
+		if !math.IsNaN(value) && !math.IsInf(value, 0) {
+			finite = append(finite, value)
+		}
+	}
+	return finite
+}
+
+// quantile returns the quantile p of the specified sorted values, linearly
+// interpolated between the closest ranks.
+func quantile(sorted []float64, p float64) float64 {
+	position := p * float64(len(sorted)-1)
+	lower := int(math.Floor(position))
+	if lower >= len(sorted)-1 {
+		return sorted[len(sorted)-1]
+	}
+	fraction := position - float64(lower)
+	return sorted[lower] + fraction*(sorted[lower+1]-sorted[lower])
+}
+
+// equalWidthEdges returns the edges of count bins of equal width between
+// min and max.
+func equalWidthEdges(min, max float64, count int) []float64 {
+	edges := make([]float64, count+1)
+	width := (max - min) / float64(count)
+	for i := range edges {
+		edges[i] = min + float64(i)*width
+	}
+	edges[count] = max
+	return edges
+}
+
+// fixedWidthEdges returns the edges of bins of the specified width, aligned
+// to multiples of the width, covering min and max. As the last bin includes
+// its upper edge, a max falling on an edge is covered by the bin below it.
+func fixedWidthEdges(min, max, width float64) []float64 {
+	start := math.Floor(min/width) * width
+	count := mathutil.MaxInt(int(math.Ceil((max-start)/width)), 1)
+
+	edges := make([]float64, count+1)
+	for i := range edges {
+		edges[i] = start + float64(i)*width
+	}
+	return edges
+}
+
+// binCounts returns the number of samples in each bin between the
+// specified edges.
+func binCounts(samples, edges []float64) []float64 {
+	counts := make([]float64, len(edges)-1)
+	last := len(edges) - 1
+	for _, sample := range samples {
+		if !(sample >= edges[0] && sample <= edges[last]) {
+			continue
+		}
+
+		// Find the bin whose lower edge is the last edge below the sample.
+		low, high := 0, last
+		for high-low > 1 {
+			mid := (low + high) / 2
+			if sample < edges[mid] {
+				high = mid
+			} else {
+				low = mid
+			}
+		}
+		counts[low]++
+	}
+	return counts
+}
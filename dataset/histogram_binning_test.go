@@ -0,0 +1,56 @@
+package dataset
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistogramBinningGetEdges(t *testing.T) {
+	samples := []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, math.NaN()}
+
+	// Sturges: ceil(log2(9))+1 = 5 bins.
+	edges, err := HistogramBinning{}.GetEdges(samples)
+	require.NoError(t, err)
+	require.Equal(t, []float64{0, 1.6, 3.2, 4.800000000000001, 6.4, 8}, edges)
+
+	edges, err = HistogramBinning{Method: BinningCount, Count: 4}.GetEdges(samples)
+	require.NoError(t, err)
+	require.Equal(t, []float64{0, 2, 4, 6, 8}, edges)
+
+	// Fixed width bins are aligned to multiples of the width.
+	edges, err = HistogramBinning{Method: BinningWidth, Width: 3}.GetEdges([]float64{1, 7})
+	require.NoError(t, err)
+	require.Equal(t, []float64{0, 3, 6, 9}, edges)
+
+	// Maximums falling on an edge are covered by the last bin.
+	edges, err = HistogramBinning{Method: BinningWidth, Width: 5}.GetEdges([]float64{0, 10})
+	require.NoError(t, err)
+	require.Equal(t, []float64{0, 5, 10}, edges)
+
+	// Freedman-Diaconis: 2*IQR/cbrt(n) = 2*4/cbrt(9).
+	edges, err = HistogramBinning{Method: BinningFreedmanDiaconis}.GetEdges(samples)
+	require.NoError(t, err)
+	require.InDelta(t, 8/math.Cbrt(9), edges[1]-edges[0], 1e-9)
+
+	edges, err = HistogramBinning{Edges: []float64{-1, 10}}.GetEdges(samples)
+	require.NoError(t, err)
+	require.Equal(t, []float64{-1, 10}, edges)
+
+	_, err = HistogramBinning{Edges: []float64{1, 1}}.GetEdges(samples)
+	require.Equal(t, ErrHistogramInvalidEdges, err)
+	_, err = HistogramBinning{}.GetEdges([]float64{math.NaN()})
+	require.Equal(t, ErrHistogramNoSamples, err)
+
+	// Identical samples get a bin around them.
+	edges, err = HistogramBinning{Method: BinningCount, Count: 1}.GetEdges([]float64{2, 2})
+	require.NoError(t, err)
+	require.Equal(t, []float64{1.5, 2.5}, edges)
+}
+
+func TestBinCounts(t *testing.T) {
+	edges := []float64{0, 1, 2, 3}
+	counts := binCounts([]float64{-1, 0, 0.5, 1, 2.5, 3, 4}, edges)
+	require.Equal(t, []float64{2, 1, 2}, counts)
+}
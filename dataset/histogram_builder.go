@@ -0,0 +1,57 @@
+package dataset
+
+import (
+	"github.com/unidoc/unichart/render"
+)
+
+// HistogramSamples are the raw samples of a distribution drawn by a
+// HistogramBuilder.
+type HistogramSamples struct {
+	Name    string
+	Style   render.Style
+	Samples []float64
+}
+
+// HistogramBuilder builds binned histogram series from the raw samples of
+// one or more distributions. The bins are chosen once for all the samples,
+// so the histograms of multiple distributions share the same bin edges and
+// can be overlaid.
+type HistogramBuilder struct {
+	Binning       HistogramBinning
+	Normalization HistogramNormalization
+	Cumulative    bool
+	YAxis         YAxisType
+}
+
+// Edges returns the bin edges shared by the histograms of the specified
+// distributions.
+func (hb HistogramBuilder) Edges(distributions ...HistogramSamples) ([]float64, error) {
+	var samples []float64
+	for _, distribution := range distributions {
+		samples = append(samples, distribution.Samples...)
+	}
+	return hb.Binning.GetEdges(samples)
+}
+
+// Build returns a binned histogram series for each of the specified
+// distributions, in order.
+func (hb HistogramBuilder) Build(distributions ...HistogramSamples) ([]Series, error) {
+	edges, err := hb.Edges(distributions...)
+	if err != nil {
+		return nil, err
+	}
+
+	series := make([]Series, len(distributions))
+	for i, distribution := range distributions {
+		series[i] = &BinnedHistogramSeries{
+			Name:          distribution.Name,
+			Style:         distribution.Style,
+			YAxis:         hb.YAxis,
+			Samples:       distribution.Samples,
+			Binning:       HistogramBinning{Edges: edges},
+			Normalization: hb.Normalization,
+			Cumulative:    hb.Cumulative,
+		}
+	}
+	return series, nil
+}
@@ -91,7 +91,7 @@ func drawHistogramSeries(r render.Renderer, canvasBox render.Box, xrange, yrange
 	// Draw a box for each datapoint.
 	for index := 0; index < seriesLength; index++ {
 		vx, vy := vs.GetValues(index)
-		y0 := yrange.Translate(0)
+		y0 := yrange.Translate(getBaseline(yrange))
 		x := cl + xrange.Translate(vx)
 		y := yrange.Translate(vy)
 
@@ -0,0 +1,121 @@
+package dataset
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/unidoc/unichart/dataset/sequence"
+	"github.com/unidoc/unichart/mathutil"
+	"github.com/unidoc/unichart/render"
+)
+
+const (
+	// defaultHMAPeriod is the default number of values to average.
+	defaultHMAPeriod = 16
+)
+
+// Interface Assertions.
+var (
+	_ Series              = (*HMASeries)(nil)
+	_ FirstValuesProvider = (*HMASeries)(nil)
+	_ LastValuesProvider  = (*HMASeries)(nil)
+)
+
+// HMASeries computes the Hull moving average of an inner series, which
+// reduces the lag of weighted moving averages. It is computed as
+// WMA(2*WMA(n/2) - WMA(n)) over sqrt(n) values.
+type HMASeries struct {
+	Name  string
+	Style render.Style
+	YAxis YAxisType
+
+	Period      int
+	InnerSeries ValuesProvider
+
+	cache []float64
+}
+
+// GetName returns the name of the time series.
+func (hma HMASeries) GetName() string {
+	return hma.Name
+}
+
+// GetStyle returns the line style.
+func (hma HMASeries) GetStyle() render.Style {
+	return hma.Style
+}
+
+// GetYAxis returns which YAxis the series draws on.
+func (hma HMASeries) GetYAxis() YAxisType {
+	return hma.YAxis
+}
+
+// GetPeriod returns the window size.
+func (hma HMASeries) GetPeriod() int {
+	if hma.Period == 0 {
+		return defaultHMAPeriod
+	}
+	return hma.Period
+}
+
+// Len returns the number of elements in the series.
+func (hma HMASeries) Len() int {
+	return hma.InnerSeries.Len()
+}
+
+// GetValues gets a value at a given index.
+func (hma *HMASeries) GetValues(index int) (x, y float64) {
+	if hma.InnerSeries == nil {
+		return
+	}
+	if hma.cache == nil {
+		hma.ensureCachedValues()
+	}
+	if index < 0 || index >= len(hma.cache) {
+		return
+	}
+	x, _ = hma.InnerSeries.GetValues(index)
+	y = hma.cache[index]
+	return
+}
+
+// GetFirstValues computes the first moving average value.
+func (hma *HMASeries) GetFirstValues() (x, y float64) {
+	return hma.GetValues(0)
+}
+
+// GetLastValues computes the last moving average value.
+func (hma *HMASeries) GetLastValues() (x, y float64) {
+	if hma.InnerSeries == nil {
+		return
+	}
+	return hma.GetValues(hma.InnerSeries.Len() - 1)
+}
+
+func (hma *HMASeries) ensureCachedValues() {
+	values := indicatorValues(hma.InnerSeries)
+	period := hma.GetPeriod()
+
+	half := weightedMovingAverage(values, mathutil.MaxInt(1, period/2))
+	full := weightedMovingAverage(values, period)
+	for i := range values {
+		values[i] = 2*half[i] - full[i]
+	}
+
+	sqrtPeriod := int(math.Round(math.Sqrt(float64(period))))
+	hma.cache = weightedMovingAverage(values, mathutil.MaxInt(1, sqrtPeriod))
+}
+
+// Render renders the series.
+func (hma *HMASeries) Render(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, defaults render.Style) {
+	style := hma.Style.InheritFrom(defaults)
+	drawLineSeries(r, canvasBox, xrange, yrange, style, hma)
+}
+
+// Validate validates the series.
+func (hma *HMASeries) Validate() error {
+	if hma.InnerSeries == nil {
+		return fmt.Errorf("hma series requires InnerSeries to be set")
+	}
+	return nil
+}
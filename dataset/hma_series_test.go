@@ -0,0 +1,37 @@
+package dataset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unidoc/unichart/dataset/sequence"
+)
+
+func TestHMASeries(t *testing.T) {
+	values := sequence.LinearRange(0, 49)
+	hma := &HMASeries{
+		InnerSeries: ContinuousSeries{XValues: values, YValues: values},
+	}
+	require.Nil(t, hma.Validate())
+	require.Equal(t, defaultHMAPeriod, hma.GetPeriod())
+
+	// The Hull moving average of linear values lags by a fraction of a
+	// value, far less than the weighted moving average.
+	for index := 20; index < len(values); index++ {
+		_, y := hma.GetValues(index)
+		require.InDelta(t, values[index]-2.0/3, y, 1e-9)
+	}
+}
+
+func TestHMASeriesEmpty(t *testing.T) {
+	hma := &HMASeries{InnerSeries: ContinuousSeries{}}
+
+	x, y := hma.GetValues(0)
+	require.Zero(t, x)
+	require.Zero(t, y)
+	require.NotNil(t, hma.cache)
+
+	x, y = hma.GetLastValues()
+	require.Zero(t, x)
+	require.Zero(t, y)
+}
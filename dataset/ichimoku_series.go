@@ -0,0 +1,308 @@
+package dataset
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/unidoc/unichart/dataset/sequence"
+	"github.com/unidoc/unichart/render"
+)
+
+const (
+	// defaultIchimokuConversionPeriod is the default period of the
+	// conversion line (tenkan-sen).
+	defaultIchimokuConversionPeriod = 9
+
+	// defaultIchimokuBasePeriod is the default period of the base line
+	// (kijun-sen).
+	defaultIchimokuBasePeriod = 26
+
+	// defaultIchimokuLeadingSpanBPeriod is the default period of the leading
+	// span B (senkou span B).
+	defaultIchimokuLeadingSpanBPeriod = 52
+
+	// defaultIchimokuDisplacement is the default number of values the
+	// leading spans are shifted forward and the lagging span is shifted
+	// backward by.
+	defaultIchimokuDisplacement = 26
+)
+
+// Interface Assertions.
+var (
+	_ Series                = (*IchimokuSeries)(nil)
+	_ FirstValuesProvider   = (*IchimokuSeries)(nil)
+	_ LastValuesProvider    = (*IchimokuSeries)(nil)
+	_ Series                = (*IchimokuCloudSeries)(nil)
+	_ BoundedValuesProvider = (*IchimokuCloudSeries)(nil)
+)
+
+// IchimokuLine is a line of the Ichimoku Kinko Hyo indicator.
+type IchimokuLine int
+
+// IchimokuLine values.
+const (
+	// IchimokuConversionLine is the midpoint of the conversion period
+	// (tenkan-sen).
+	IchimokuConversionLine IchimokuLine = iota
+	// IchimokuBaseLine is the midpoint of the base period (kijun-sen).
+	IchimokuBaseLine
+	// IchimokuLeadingSpanA is the average of the conversion and base lines,
+	// shifted forward (senkou span A).
+	IchimokuLeadingSpanA
+	// IchimokuLeadingSpanB is the midpoint of the leading span B period,
+	// shifted forward (senkou span B).
+	IchimokuLeadingSpanB
+	// IchimokuLaggingSpan is the close value, shifted backward (chikou
+	// span).
+	IchimokuLaggingSpan
+)
+
+// IchimokuPeriods holds the periods of the Ichimoku Kinko Hyo indicator.
+// Zero periods default to the traditional 9, 26, 52 and 26 values.
+type IchimokuPeriods struct {
+	ConversionPeriod   int
+	BasePeriod         int
+	LeadingSpanBPeriod int
+	Displacement       int
+}
+
+// GetConversionPeriod returns the period of the conversion line.
+func (ip IchimokuPeriods) GetConversionPeriod() int {
+	if ip.ConversionPeriod == 0 {
+		return defaultIchimokuConversionPeriod
+	}
+	return ip.ConversionPeriod
+}
+
+// GetBasePeriod returns the period of the base line.
+func (ip IchimokuPeriods) GetBasePeriod() int {
+	if ip.BasePeriod == 0 {
+		return defaultIchimokuBasePeriod
+	}
+	return ip.BasePeriod
+}
+
+// GetLeadingSpanBPeriod returns the period of the leading span B.
+func (ip IchimokuPeriods) GetLeadingSpanBPeriod() int {
+	if ip.LeadingSpanBPeriod == 0 {
+		return defaultIchimokuLeadingSpanBPeriod
+	}
+	return ip.LeadingSpanBPeriod
+}
+
+// GetDisplacement returns the number of values the leading and lagging
+// spans are shifted by.
+func (ip IchimokuPeriods) GetDisplacement() int {
+	if ip.Displacement == 0 {
+		return defaultIchimokuDisplacement
+	}
+	return ip.Displacement
+}
+
+// lines returns the values of the Ichimoku lines at each index of the
+// specified values. The spans are shifted within the indices of the values,
+// so shifted values falling outside of them are dropped, and the indices
+// without values are undefined (NaN).
+func (ip IchimokuPeriods) lines(highs, lows, closes []float64) map[IchimokuLine][]float64 {
+	midpoint := func(period int) []float64 {
+		values := make([]float64, len(closes))
+		for i := range closes {
+			lowest, highest := windowExtremes(highs, lows, i, period)
+			values[i] = (lowest + highest) / 2
+		}
+		return values
+	}
+
+	conversion := midpoint(ip.GetConversionPeriod())
+	base := midpoint(ip.GetBasePeriod())
+	spanB := midpoint(ip.GetLeadingSpanBPeriod())
+
+	displacement := ip.GetDisplacement()
+	lines := map[IchimokuLine][]float64{
+		IchimokuConversionLine: conversion,
+		IchimokuBaseLine:       base,
+		IchimokuLeadingSpanA:   make([]float64, len(closes)),
+		IchimokuLeadingSpanB:   make([]float64, len(closes)),
+		IchimokuLaggingSpan:    make([]float64, len(closes)),
+	}
+	for i := range closes {
+		lines[IchimokuLeadingSpanA][i] = math.NaN()
+		lines[IchimokuLeadingSpanB][i] = math.NaN()
+		if lagged := i - displacement; lagged >= 0 {
+			lines[IchimokuLeadingSpanA][i] = (conversion[lagged] + base[lagged]) / 2
+			lines[IchimokuLeadingSpanB][i] = spanB[lagged]
+		}
+
+		lines[IchimokuLaggingSpan][i] = math.NaN()
+		if led := i + displacement; led < len(closes) {
+			lines[IchimokuLaggingSpan][i] = closes[led]
+		}
+	}
+	return lines
+}
+
+// IchimokuSeries computes a line of the Ichimoku Kinko Hyo indicator for the
+// close values (InnerSeries). The High and Low values default to the close
+// values. The cloud between the leading spans is drawn by
+// IchimokuCloudSeries.
+type IchimokuSeries struct {
+	Name  string
+	Style render.Style
+	YAxis YAxisType
+
+	Line        IchimokuLine
+	Periods     IchimokuPeriods
+	High        ValuesProvider
+	Low         ValuesProvider
+	InnerSeries ValuesProvider
+
+	cache []float64
+}
+
+// GetName returns the name of the time series.
+func (is IchimokuSeries) GetName() string {
+	return is.Name
+}
+
+// GetStyle returns the line style.
+func (is IchimokuSeries) GetStyle() render.Style {
+	return is.Style
+}
+
+// GetYAxis returns which YAxis the series draws on.
+func (is IchimokuSeries) GetYAxis() YAxisType {
+	return is.YAxis
+}
+
+// Len returns the number of elements in the series.
+func (is IchimokuSeries) Len() int {
+	return is.InnerSeries.Len()
+}
+
+// GetValues gets a value at a given index.
+func (is *IchimokuSeries) GetValues(index int) (x, y float64) {
+	if is.InnerSeries == nil {
+		return
+	}
+	if is.cache == nil {
+		is.ensureCachedValues()
+	}
+	if index < 0 || index >= len(is.cache) {
+		return
+	}
+	x, _ = is.InnerSeries.GetValues(index)
+	y = is.cache[index]
+	return
+}
+
+// GetFirstValues computes the first value of the line.
+func (is *IchimokuSeries) GetFirstValues() (x, y float64) {
+	return is.GetValues(0)
+}
+
+// GetLastValues computes the last value of the line.
+func (is *IchimokuSeries) GetLastValues() (x, y float64) {
+	if is.InnerSeries == nil {
+		return
+	}
+	return is.GetValues(is.InnerSeries.Len() - 1)
+}
+
+func (is *IchimokuSeries) ensureCachedValues() {
+	highs, lows, closes := highLowCloseValues(is.High, is.Low, is.InnerSeries)
+	is.cache = is.Periods.lines(highs, lows, closes)[is.Line]
+}
+
+// Render renders the series.
+func (is *IchimokuSeries) Render(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, defaults render.Style) {
+	style := is.Style.InheritFrom(defaults)
+	drawLineSeries(r, canvasBox, xrange, yrange, style, is)
+}
+
+// Validate validates the series.
+func (is *IchimokuSeries) Validate() error {
+	if is.InnerSeries == nil {
+		return fmt.Errorf("ichimoku series requires InnerSeries to be set")
+	}
+	if is.Line < IchimokuConversionLine || is.Line > IchimokuLaggingSpan {
+		return fmt.Errorf("ichimoku series; invalid line")
+	}
+	return nil
+}
+
+// IchimokuCloudSeries draws the cloud (kumo) of the Ichimoku Kinko Hyo
+// indicator for the close values (InnerSeries), which is the area between
+// the leading spans A and B. The High and Low values default to the close
+// values. The cloud is not drawn for the first values, before the leading
+// spans are shifted in.
+type IchimokuCloudSeries struct {
+	Name  string
+	Style render.Style
+	YAxis YAxisType
+
+	Periods     IchimokuPeriods
+	High        ValuesProvider
+	Low         ValuesProvider
+	InnerSeries ValuesProvider
+
+	spanA []float64
+	spanB []float64
+}
+
+// GetName returns the name of the time series.
+func (ics IchimokuCloudSeries) GetName() string {
+	return ics.Name
+}
+
+// GetStyle returns the line style.
+func (ics IchimokuCloudSeries) GetStyle() render.Style {
+	return ics.Style
+}
+
+// GetYAxis returns which YAxis the series draws on.
+func (ics IchimokuCloudSeries) GetYAxis() YAxisType {
+	return ics.YAxis
+}
+
+// Len returns the number of elements in the series.
+func (ics IchimokuCloudSeries) Len() int {
+	return ics.InnerSeries.Len()
+}
+
+// GetBoundedValues gets the leading spans A and B at the given index.
+func (ics *IchimokuCloudSeries) GetBoundedValues(index int) (x, y1, y2 float64) {
+	if ics.InnerSeries == nil {
+		return
+	}
+	if ics.spanA == nil {
+		ics.ensureCachedValues()
+	}
+	if index < 0 || index >= len(ics.spanA) {
+		return
+	}
+	x, _ = ics.InnerSeries.GetValues(index)
+	y1 = ics.spanA[index]
+	y2 = ics.spanB[index]
+	return
+}
+
+func (ics *IchimokuCloudSeries) ensureCachedValues() {
+	highs, lows, closes := highLowCloseValues(ics.High, ics.Low, ics.InnerSeries)
+	lines := ics.Periods.lines(highs, lows, closes)
+	ics.spanA = lines[IchimokuLeadingSpanA]
+	ics.spanB = lines[IchimokuLeadingSpanB]
+}
+
+// Render renders the series.
+func (ics *IchimokuCloudSeries) Render(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, defaults render.Style) {
+	style := confidenceBandStyle(ics.Style, defaults)
+	drawConfidenceBands(r, canvasBox, xrange, yrange, style, ics)
+}
+
+// Validate validates the series.
+func (ics *IchimokuCloudSeries) Validate() error {
+	if ics.InnerSeries == nil {
+		return fmt.Errorf("ichimoku cloud series requires InnerSeries to be set")
+	}
+	return nil
+}
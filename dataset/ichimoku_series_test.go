@@ -0,0 +1,75 @@
+package dataset
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIchimokuSeries(t *testing.T) {
+	closes := ContinuousSeries{
+		XValues: []float64{1, 2, 3, 4, 5},
+		YValues: []float64{1, 3, 2, 5, 4},
+	}
+	periods := IchimokuPeriods{ConversionPeriod: 2, BasePeriod: 3, LeadingSpanBPeriod: 4, Displacement: 2}
+
+	values := func(line IchimokuLine) []float64 {
+		is := &IchimokuSeries{Line: line, Periods: periods, InnerSeries: closes}
+		require.Nil(t, is.Validate())
+
+		var yvalues []float64
+		for index := 0; index < is.Len(); index++ {
+			_, y := is.GetValues(index)
+			yvalues = append(yvalues, y)
+		}
+		return yvalues
+	}
+	nan := math.NaN()
+
+	require.Equal(t, []float64{1, 2, 2.5, 3.5, 4.5}, values(IchimokuConversionLine))
+	require.Equal(t, []float64{1, 2, 2, 3.5, 3.5}, values(IchimokuBaseLine))
+	requireNaNEqual(t, []float64{nan, nan, 1, 2, 2.25}, values(IchimokuLeadingSpanA))
+	requireNaNEqual(t, []float64{nan, nan, 1, 2, 2}, values(IchimokuLeadingSpanB))
+	requireNaNEqual(t, []float64{2, 5, 4, nan, nan}, values(IchimokuLaggingSpan))
+
+	ics := &IchimokuCloudSeries{Periods: periods, InnerSeries: closes}
+	require.Nil(t, ics.Validate())
+	x, y1, y2 := ics.GetBoundedValues(4)
+	require.Equal(t, 5.0, x)
+	require.Equal(t, 2.25, y1)
+	require.Equal(t, 2.0, y2)
+
+	require.NotNil(t, (&IchimokuSeries{Line: IchimokuLaggingSpan + 1, InnerSeries: closes}).Validate())
+}
+
+func requireNaNEqual(t *testing.T, expected, actual []float64) {
+	require.Len(t, actual, len(expected))
+	for i := range expected {
+		if math.IsNaN(expected[i]) {
+			require.True(t, math.IsNaN(actual[i]), "index %d", i)
+			continue
+		}
+		require.Equal(t, expected[i], actual[i], "index %d", i)
+	}
+}
+
+func TestIchimokuSeriesEmpty(t *testing.T) {
+	is := &IchimokuSeries{Line: IchimokuLeadingSpanA, InnerSeries: ContinuousSeries{}}
+
+	x, y := is.GetValues(0)
+	require.Zero(t, x)
+	require.Zero(t, y)
+	require.NotNil(t, is.cache)
+
+	x, y = is.GetLastValues()
+	require.Zero(t, x)
+	require.Zero(t, y)
+
+	ics := &IchimokuCloudSeries{InnerSeries: ContinuousSeries{}}
+	x, y1, y2 := ics.GetBoundedValues(0)
+	require.Zero(t, x)
+	require.Zero(t, y1)
+	require.Zero(t, y2)
+	require.NotNil(t, ics.spanA)
+}
@@ -0,0 +1,109 @@
+package dataset
+
+import (
+	"math"
+)
+
+// indicatorValues returns the Y values of the specified series.
+func indicatorValues(vs ValuesProvider) []float64 {
+	values := make([]float64, vs.Len())
+	for i := range values {
+		_, values[i] = vs.GetValues(i)
+	}
+	return values
+}
+
+// highLowCloseValues returns the high, low and close values used by price
+// indicators. Missing high and low values default to the close values.
+func highLowCloseValues(high, low, close ValuesProvider) (highs, lows, closes []float64) {
+	closes = indicatorValues(close)
+	highs = append([]float64{}, closes...)
+	lows = append([]float64{}, closes...)
+	if high != nil {
+		for i := 0; i < high.Len() && i < len(highs); i++ {
+			_, highs[i] = high.GetValues(i)
+		}
+	}
+	if low != nil {
+		for i := 0; i < low.Len() && i < len(lows); i++ {
+			_, lows[i] = low.GetValues(i)
+		}
+	}
+	return highs, lows, closes
+}
+
+// windowExtremes returns the lowest low and the highest high of the
+// specified period ending at index. The window is truncated at the start of
+// the values.
+func windowExtremes(highs, lows []float64, index, period int) (lowest, highest float64) {
+	lowest, highest = math.Inf(1), math.Inf(-1)
+	for i := index; i >= 0 && i > index-period; i-- {
+		lowest = math.Min(lowest, lows[i])
+		highest = math.Max(highest, highs[i])
+	}
+	return lowest, highest
+}
+
+// weightedMovingAverage returns the linearly weighted moving average of the
+// specified values. The most recent value has a weight equal to the period
+// and the weights decrease linearly. The window is truncated at the start of
+// the values.
+func weightedMovingAverage(values []float64, period int) []float64 {
+	averages := make([]float64, len(values))
+	for index := range values {
+		var accum, weights float64
+		for i := index; i >= 0 && i > index-period; i-- {
+			weight := float64(period - (index - i))
+			accum += weight * values[i]
+			weights += weight
+		}
+		averages[index] = accum / weights
+	}
+	return averages
+}
+
+// exponentialMovingAverage returns the exponential moving average of the
+// specified values, seeded with the first value.
+func exponentialMovingAverage(values []float64, period int) []float64 {
+	averages := make([]float64, len(values))
+	sigma := 2.0 / (float64(period) + 1)
+	for i, value := range values {
+		if i == 0 {
+			averages[i] = value
+			continue
+		}
+		averages[i] = (value-averages[i-1])*sigma + averages[i-1]
+	}
+	return averages
+}
+
+// wilderAverage returns the smoothed moving average of the specified values
+// used by Wilder's indicators. The first period values are averaged
+// cumulatively.
+func wilderAverage(values []float64, period int) []float64 {
+	averages := make([]float64, len(values))
+	var accum float64
+	for i, value := range values {
+		if i < period {
+			accum += value
+			averages[i] = accum / float64(i+1)
+			continue
+		}
+		averages[i] = (averages[i-1]*float64(period-1) + value) / float64(period)
+	}
+	return averages
+}
+
+// averageTrueRange returns the average true range of the specified values,
+// smoothed over the specified period.
+func averageTrueRange(highs, lows, closes []float64, period int) []float64 {
+	trueRanges := make([]float64, len(closes))
+	for i := range closes {
+		trueRanges[i] = highs[i] - lows[i]
+		if i > 0 {
+			trueRanges[i] = math.Max(trueRanges[i], math.Abs(highs[i]-closes[i-1]))
+			trueRanges[i] = math.Max(trueRanges[i], math.Abs(lows[i]-closes[i-1]))
+		}
+	}
+	return wilderAverage(trueRanges, period)
+}
@@ -0,0 +1,115 @@
+package dataset
+
+import (
+	"math"
+
+	"github.com/unidoc/unichart/dataset/sequence"
+)
+
+// KDEKernel is the kernel of a kernel density estimate.
+type KDEKernel int
+
+// KDEKernel values.
+const (
+	// KernelGaussian is the standard normal kernel.
+	KernelGaussian KDEKernel = iota
+	// KernelEpanechnikov is the parabolic kernel, which has a bounded
+	// support of one bandwidth.
+	KernelEpanechnikov
+)
+
+// Evaluate returns the value of the kernel at the specified distance, in
+// bandwidths.
+func (k KDEKernel) Evaluate(u float64) float64 {
+	switch k {
+	case KernelEpanechnikov:
+		if math.Abs(u) >= 1 {
+			return 0
+		}
+		return 0.75 * (1 - u*u)
+	default:
+		return math.Exp(-0.5*u*u) / math.Sqrt(2*math.Pi)
+	}
+}
+
+// Support returns the distance, in bandwidths, beyond which the kernel is
+// negligible.
+func (k KDEKernel) Support() float64 {
+	if k == KernelEpanechnikov {
+		return 1
+	}
+	return 3
+}
+
+// BandwidthMethod is the rule of thumb used to choose the bandwidth of a
+// kernel density estimate.
+type BandwidthMethod int
+
+// BandwidthMethod values.
+const (
+	// BandwidthSilverman uses 0.9*min(stddev, IQR/1.34)*n^(-1/5), which is
+	// robust to skewed and heavy tailed samples.
+	BandwidthSilverman BandwidthMethod = iota
+	// BandwidthScott uses 1.06*stddev*n^(-1/5), which is optimal for normal
+	// samples.
+	BandwidthScott
+)
+
+// Bandwidth returns the bandwidth chosen for the specified samples. Samples
+// without spread get a bandwidth of 1.
+func (bm BandwidthMethod) Bandwidth(samples []float64) float64 {
+	values := finiteValues(samples)
+	if len(values) == 0 {
+		return 1
+	}
+
+	wrapper := sequence.NewWrapper(sequence.ArraySequence(values))
+	spread := wrapper.StdDev()
+	factor := 1.06
+	if bm == BandwidthSilverman {
+		factor = 0.9
+		if iqr := wrapper.Percentile(0.75) - wrapper.Percentile(0.25); iqr > 0 {
+			spread = math.Min(spread, iqr/1.34)
+		}
+	}
+
+	bandwidth := factor * spread * math.Pow(float64(len(values)), -0.2)
+	if bandwidth <= 0 {
+		return 1
+	}
+	return bandwidth
+}
+
+// KernelDensity returns the kernel density estimate of the specified
+// samples at x. Non-finite samples are ignored.
+func KernelDensity(samples []float64, kernel KDEKernel, bandwidth, x float64) float64 {
+	var density float64
+	var count int
+	for _, sample := range samples {
+		if math.IsNaN(sample) || math.IsInf(sample, 0) {
+			continue
+		}
+		density += kernel.Evaluate((x - sample) / bandwidth)
+		count++
+	}
+	if count == 0 || bandwidth <= 0 {
+		return 0
+	}
+	return density / (float64(count) * bandwidth)
+}
+
+// KernelDensityCurve evaluates the kernel density estimate of the
+// specified samples at evenly spaced points between min and max.
+func KernelDensityCurve(samples []float64, kernel KDEKernel, bandwidth, min, max float64, points int) (xvalues, yvalues []float64) {
+	xvalues = make([]float64, points)
+	yvalues = make([]float64, points)
+	step := (max - min) / float64(points-1)
+	for i := range xvalues {
+		xvalues[i] = min + float64(i)*step
+		if i == points-1 {
+			xvalues[i] = max
+		}
+		yvalues[i] = KernelDensity(samples, kernel, bandwidth, xvalues[i])
+	}
+	return xvalues, yvalues
+}
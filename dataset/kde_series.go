@@ -0,0 +1,129 @@
+package dataset
+
+import (
+	"fmt"
+
+	"github.com/unidoc/unichart/dataset/sequence"
+	"github.com/unidoc/unichart/render"
+)
+
+const (
+	// defaultKDEPoints is the default number of points at which kernel
+	// density estimates are evaluated.
+	defaultKDEPoints = 200
+)
+
+// Interface Assertions.
+var (
+	_ Series              = (*KDESeries)(nil)
+	_ FirstValuesProvider = (*KDESeries)(nil)
+	_ LastValuesProvider  = (*KDESeries)(nil)
+)
+
+// KDESeries draws the kernel density estimate of raw samples, which is a
+// smooth estimate of their distribution. The density is evaluated at evenly
+// spaced points covering the samples and the support of the kernel around
+// them. The bandwidth defaults to the one chosen by the BandwidthMethod.
+// The series is drawn as a line, or as a filled area if the style has a
+// fill color.
+type KDESeries struct {
+	Name  string
+	Style render.Style
+	YAxis YAxisType
+
+	Samples         []float64
+	Kernel          KDEKernel
+	Bandwidth       float64
+	BandwidthMethod BandwidthMethod
+	Points          int
+
+	xvalues []float64
+	yvalues []float64
+}
+
+// GetName returns the name of the time series.
+func (kde KDESeries) GetName() string {
+	return kde.Name
+}
+
+// GetStyle returns the line style.
+func (kde KDESeries) GetStyle() render.Style {
+	return kde.Style
+}
+
+// GetYAxis returns which YAxis the series draws on.
+func (kde KDESeries) GetYAxis() YAxisType {
+	return kde.YAxis
+}
+
+// GetBandwidth returns the bandwidth of the kernel.
+func (kde KDESeries) GetBandwidth() float64 {
+	if kde.Bandwidth > 0 {
+		return kde.Bandwidth
+	}
+	return kde.BandwidthMethod.Bandwidth(kde.Samples)
+}
+
+// GetPoints returns the number of points at which the density is
+// evaluated.
+func (kde KDESeries) GetPoints() int {
+	if kde.Points < 2 {
+		return defaultKDEPoints
+	}
+	return kde.Points
+}
+
+// Len returns the number of elements in the series.
+func (kde *KDESeries) Len() int {
+	kde.ensureCachedValues()
+	return len(kde.xvalues)
+}
+
+// GetValues gets a value at a given index.
+func (kde *KDESeries) GetValues(index int) (x, y float64) {
+	kde.ensureCachedValues()
+	if index < 0 || index >= len(kde.xvalues) {
+		return
+	}
+	return kde.xvalues[index], kde.yvalues[index]
+}
+
+// GetFirstValues computes the first density value.
+func (kde *KDESeries) GetFirstValues() (x, y float64) {
+	return kde.GetValues(0)
+}
+
+// GetLastValues computes the last density value.
+func (kde *KDESeries) GetLastValues() (x, y float64) {
+	return kde.GetValues(kde.Len() - 1)
+}
+
+// Render renders the series.
+func (kde *KDESeries) Render(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, defaults render.Style) {
+	style := kde.Style.InheritFrom(defaults)
+	drawLineSeries(r, canvasBox, xrange, yrange, style, kde)
+}
+
+// Validate validates the series.
+func (kde *KDESeries) Validate() error {
+	if len(finiteValues(kde.Samples)) == 0 {
+		return fmt.Errorf("kde series requires finite Samples to be set")
+	}
+	return nil
+}
+
+func (kde *KDESeries) ensureCachedValues() {
+	if kde.xvalues != nil {
+		return
+	}
+
+	samples := finiteValues(kde.Samples)
+	if len(samples) == 0 {
+		return
+	}
+
+	bandwidth := kde.GetBandwidth()
+	min, max := sequence.NewWrapper(sequence.ArraySequence(samples)).MinMax()
+	extent := kde.Kernel.Support() * bandwidth
+	kde.xvalues, kde.yvalues = KernelDensityCurve(samples, kde.Kernel, bandwidth, min-extent, max+extent, kde.GetPoints())
+}
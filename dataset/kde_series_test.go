@@ -0,0 +1,59 @@
+package dataset
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKDEKernels(t *testing.T) {
+	for _, kernel := range []KDEKernel{KernelGaussian, KernelEpanechnikov} {
+		// The kernels integrate to 1 over their support.
+		var area float64
+		step := 0.001
+		for u := -kernel.Support(); u < kernel.Support(); u += step {
+			area += kernel.Evaluate(u) * step
+		}
+		require.InDelta(t, 1, area, 0.01)
+	}
+	require.Zero(t, KernelEpanechnikov.Evaluate(1.5))
+}
+
+func TestBandwidthMethod(t *testing.T) {
+	samples := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, math.NaN()}
+
+	// stddev = sqrt(8.25), IQR/1.34 > stddev.
+	stddev := math.Sqrt(8.25)
+	require.InDelta(t, 1.06*stddev*math.Pow(10, -0.2), BandwidthScott.Bandwidth(samples), 1e-9)
+	require.InDelta(t, 0.9*stddev*math.Pow(10, -0.2), BandwidthSilverman.Bandwidth(samples), 1e-9)
+
+	require.Equal(t, 1.0, BandwidthSilverman.Bandwidth([]float64{3, 3}))
+	require.Equal(t, 1.0, BandwidthScott.Bandwidth(nil))
+}
+
+func TestKDESeries(t *testing.T) {
+	kde := &KDESeries{
+		Samples:   []float64{0, 1, 1, 2, math.NaN()},
+		Kernel:    KernelEpanechnikov,
+		Bandwidth: 1,
+		Points:    41,
+	}
+	require.NoError(t, kde.Validate())
+	require.Equal(t, 41, kde.Len())
+
+	// The curve covers the samples and the support of the kernel.
+	x, y := kde.GetFirstValues()
+	require.Equal(t, -1.0, x)
+	require.Zero(t, y)
+	x, y = kde.GetLastValues()
+	require.Equal(t, 3.0, x)
+	require.Zero(t, y)
+
+	// Density at 1: (0 + 0.75 + 0.75 + 0) / 4.
+	x, y = kde.GetValues(20)
+	require.Equal(t, 1.0, x)
+	require.InDelta(t, 0.375, y, 1e-9)
+
+	require.Error(t, (&KDESeries{Samples: []float64{math.NaN()}}).Validate())
+}
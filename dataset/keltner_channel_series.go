@@ -0,0 +1,158 @@
+package dataset
+
+import (
+	"fmt"
+
+	"github.com/unidoc/unichart/dataset/sequence"
+	"github.com/unidoc/unichart/render"
+)
+
+const (
+	// defaultKeltnerPeriod is the default EMA period of the middle line of
+	// Keltner channels.
+	defaultKeltnerPeriod = 20
+
+	// defaultKeltnerATRPeriod is the default ATR period of the width of
+	// Keltner channels.
+	defaultKeltnerATRPeriod = 10
+
+	// defaultKeltnerMultiplier is the default number of ATRs above and below
+	// the middle line of Keltner channels.
+	defaultKeltnerMultiplier = 2.0
+)
+
+// Interface Assertions.
+var (
+	_ Series                    = (*KeltnerChannelSeries)(nil)
+	_ FullBoundedValuesProvider = (*KeltnerChannelSeries)(nil)
+)
+
+// KeltnerChannelSeries draws Keltner channels for the close values
+// (InnerSeries). The channels are defined by two lines, one at
+// EMA+k*ATR, one at EMA-k*ATR. The High and Low values used by the ATR
+// default to the close values.
+type KeltnerChannelSeries struct {
+	Name  string
+	Style render.Style
+	YAxis YAxisType
+
+	Period      int
+	ATRPeriod   int
+	K           float64
+	High        ValuesProvider
+	Low         ValuesProvider
+	InnerSeries ValuesProvider
+
+	upper []float64
+	lower []float64
+}
+
+// GetName returns the name of the time series.
+func (kcs KeltnerChannelSeries) GetName() string {
+	return kcs.Name
+}
+
+// GetStyle returns the line style.
+func (kcs KeltnerChannelSeries) GetStyle() render.Style {
+	return kcs.Style
+}
+
+// GetYAxis returns which YAxis the series draws on.
+func (kcs KeltnerChannelSeries) GetYAxis() YAxisType {
+	return kcs.YAxis
+}
+
+// GetPeriod returns the EMA window size.
+func (kcs KeltnerChannelSeries) GetPeriod() int {
+	if kcs.Period == 0 {
+		return defaultKeltnerPeriod
+	}
+	return kcs.Period
+}
+
+// GetATRPeriod returns the ATR window size.
+func (kcs KeltnerChannelSeries) GetATRPeriod() int {
+	if kcs.ATRPeriod == 0 {
+		return defaultKeltnerATRPeriod
+	}
+	return kcs.ATRPeriod
+}
+
+// GetK returns the K value, or the number of ATRs above and below to band
+// the exponential moving average with.
+func (kcs KeltnerChannelSeries) GetK(defaults ...float64) float64 {
+	if kcs.K == 0 {
+		if len(defaults) > 0 {
+			return defaults[0]
+		}
+		return defaultKeltnerMultiplier
+	}
+	return kcs.K
+}
+
+// Len returns the number of elements in the series.
+func (kcs KeltnerChannelSeries) Len() int {
+	return kcs.InnerSeries.Len()
+}
+
+// GetBoundedValues gets the bounded value for the series.
+func (kcs *KeltnerChannelSeries) GetBoundedValues(index int) (x, y1, y2 float64) {
+	if kcs.InnerSeries == nil {
+		return
+	}
+	if kcs.upper == nil {
+		kcs.ensureCachedValues()
+	}
+	if index < 0 || index >= len(kcs.upper) {
+		return
+	}
+	x, _ = kcs.InnerSeries.GetValues(index)
+	y1 = kcs.upper[index]
+	y2 = kcs.lower[index]
+	return
+}
+
+// GetBoundedLastValues returns the last bounded value for the series.
+func (kcs *KeltnerChannelSeries) GetBoundedLastValues() (x, y1, y2 float64) {
+	if kcs.InnerSeries == nil {
+		return
+	}
+	return kcs.GetBoundedValues(kcs.InnerSeries.Len() - 1)
+}
+
+func (kcs *KeltnerChannelSeries) ensureCachedValues() {
+	highs, lows, closes := highLowCloseValues(kcs.High, kcs.Low, kcs.InnerSeries)
+	middle := exponentialMovingAverage(closes, kcs.GetPeriod())
+	atr := averageTrueRange(highs, lows, closes, kcs.GetATRPeriod())
+
+	k := kcs.GetK()
+	kcs.upper = make([]float64, len(closes))
+	kcs.lower = make([]float64, len(closes))
+	for i := range closes {
+		kcs.upper[i] = middle[i] + k*atr[i]
+		kcs.lower[i] = middle[i] - k*atr[i]
+	}
+}
+
+// Render renders the series.
+func (kcs *KeltnerChannelSeries) Render(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, defaults render.Style) {
+	if kcs.Len() == 0 {
+		return
+	}
+
+	s := kcs.Style.InheritFrom(defaults.InheritFrom(render.Style{
+		StrokeWidth: 1.0,
+		StrokeColor: render.ColorWithAlpha(render.DefaultLineColor, 64),
+		FillColor:   render.ColorWithAlpha(render.DefaultLineColor, 32),
+	}))
+
+	drawBoundedSeries(r, canvasBox, xrange, yrange, s, kcs)
+}
+
+// Validate validates the series.
+func (kcs KeltnerChannelSeries) Validate() error {
+	if kcs.InnerSeries == nil {
+		return fmt.Errorf("keltner channel series requires InnerSeries to be set")
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package dataset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeltnerChannelSeries(t *testing.T) {
+	xvalues := []float64{1, 2, 3, 4}
+	kcs := &KeltnerChannelSeries{
+		High:        ContinuousSeries{XValues: xvalues, YValues: []float64{11, 11, 11, 11}},
+		Low:         ContinuousSeries{XValues: xvalues, YValues: []float64{9, 9, 9, 9}},
+		InnerSeries: ContinuousSeries{XValues: xvalues, YValues: []float64{10, 10, 10, 10}},
+	}
+	require.Nil(t, kcs.Validate())
+	require.Equal(t, 2.0, kcs.GetK())
+
+	for index := range xvalues {
+		x, y1, y2 := kcs.GetBoundedValues(index)
+		require.Equal(t, xvalues[index], x)
+		require.InDelta(t, 14, y1, 1e-9)
+		require.InDelta(t, 6, y2, 1e-9)
+	}
+
+	x, y1, y2 := kcs.GetBoundedLastValues()
+	require.Equal(t, 4.0, x)
+	require.InDelta(t, 14, y1, 1e-9)
+	require.InDelta(t, 6, y2, 1e-9)
+}
+
+func TestKeltnerChannelSeriesEmpty(t *testing.T) {
+	kcs := &KeltnerChannelSeries{InnerSeries: ContinuousSeries{}}
+
+	x, y1, y2 := kcs.GetBoundedValues(0)
+	require.Zero(t, x)
+	require.Zero(t, y1)
+	require.Zero(t, y2)
+	require.NotNil(t, kcs.upper)
+
+	x, y1, y2 = kcs.GetBoundedLastValues()
+	require.Zero(t, x)
+	require.Zero(t, y1)
+	require.Zero(t, y2)
+}
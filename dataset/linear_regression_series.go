@@ -14,6 +14,8 @@ var (
 	_ FirstValuesProvider       = (*LinearRegressionSeries)(nil)
 	_ LastValuesProvider        = (*LinearRegressionSeries)(nil)
 	_ LinearCoefficientProvider = (*LinearRegressionSeries)(nil)
+	_ RegressionStatsProvider   = (*LinearRegressionSeries)(nil)
+	_ ErrorBoundsProvider       = (*LinearRegressionSeries)(nil)
 )
 
 // LinearRegressionSeries is a series that plots the n-nearest neighbors
@@ -27,10 +29,20 @@ type LinearRegressionSeries struct {
 	Offset      int
 	InnerSeries ValuesProvider
 
+	// ConfidenceLevel is the level (e.g. 0.95) of the confidence band of
+	// the mean response drawn around the fit. Zero disables the band.
+	ConfidenceLevel float64
+	// PredictionLevel is the level (e.g. 0.95) of the prediction band of
+	// new observations drawn around the fit. Zero disables the band.
+	PredictionLevel float64
+	// BandStyle is the style of the confidence and prediction bands.
+	BandStyle render.Style
+
 	m       float64
 	b       float64
 	avgx    float64
 	stddevx float64
+	stats   *mathutil.RegressionStats
 }
 
 // Coefficients returns the linear coefficients for the series.
@@ -131,9 +143,50 @@ func (lrs *LinearRegressionSeries) GetLastValues() (x, y float64) {
 	return
 }
 
+// Stats returns the fit statistics of the regression, such as the R^2
+// value, the residual standard error and the coefficient standard errors.
+func (lrs *LinearRegressionSeries) Stats() (*mathutil.RegressionStats, error) {
+	if lrs.stats == nil {
+		var xvalues, yvalues []float64
+		for index := lrs.GetOffset(); index < lrs.GetEndIndex(); index++ {
+			x, y := lrs.InnerSeries.GetValues(index)
+			xvalues = append(xvalues, x)
+			yvalues = append(yvalues, y)
+		}
+
+		stats, err := mathutil.PolyRegressionStats(xvalues, yvalues, 1)
+		if err != nil {
+			return nil, err
+		}
+		lrs.stats = stats
+	}
+	return lrs.stats, nil
+}
+
+// GetErrorBounds returns the bounds of the confidence and prediction bands
+// at a given index.
+func (lrs *LinearRegressionSeries) GetErrorBounds(index int) (xlow, xhigh, ylow, yhigh float64) {
+	x, y := lrs.GetValues(index)
+	xlow, xhigh, ylow, yhigh = x, x, y, y
+	if lrs.ConfidenceLevel <= 0 && lrs.PredictionLevel <= 0 {
+		return
+	}
+
+	if stats, err := lrs.Stats(); err == nil {
+		width := regressionBandWidth(stats, x, lrs.ConfidenceLevel, lrs.PredictionLevel)
+		ylow, yhigh = y-width, y+width
+	}
+	return
+}
+
 // Render renders the series.
 func (lrs *LinearRegressionSeries) Render(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, defaults render.Style) {
 	style := lrs.Style.InheritFrom(defaults)
+	if lrs.ConfidenceLevel > 0 || lrs.PredictionLevel > 0 {
+		if stats, err := lrs.Stats(); err == nil {
+			drawRegressionBands(r, canvasBox, xrange, yrange, lrs.BandStyle, style, stats, lrs, lrs.ConfidenceLevel, lrs.PredictionLevel)
+		}
+	}
 	drawLineSeries(r, canvasBox, xrange, yrange, style, lrs)
 }
 
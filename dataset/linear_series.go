@@ -2,6 +2,7 @@ package dataset
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/unidoc/unichart/dataset/sequence"
 	"github.com/unidoc/unichart/mathutil"
@@ -122,6 +123,11 @@ func (ls *LinearSeries) normalize(xvalue float64) float64 {
 	return xvalue
 }
 
+// linePoint is a line series point in canvas coordinates.
+type linePoint struct {
+	x, y int
+}
+
 // drawLineSeries draws a line series with a renderer.
 func drawLineSeries(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, style render.Style, vs ValuesProvider) {
 	if vs.Len() == 0 {
@@ -130,40 +136,34 @@ func drawLineSeries(r render.Renderer, canvasBox render.Box, xrange, yrange sequ
 
 	cb := canvasBox.Bottom
 	cl := canvasBox.Left
+	yv0 := yrange.Translate(getBaseline(yrange))
 
-	v0x, v0y := vs.GetValues(0)
-	x0 := cl + xrange.Translate(v0x)
-	y0 := cb - yrange.Translate(v0y)
-
-	yv0 := yrange.Translate(0)
-
-	var vx, vy float64
-	var x, y int
+	path := downsample(vs, style.GetDownsamplingMode(), canvasBox, xrange)
+	segments := lineSegments(canvasBox, xrange, yrange, style.GetLineGapMode(), path)
 
 	if style.ShouldDrawStroke() && style.ShouldDrawFill() {
 		style.GetFillOptions().WriteDrawingOptionsToRenderer(r)
-		r.MoveTo(x0, y0)
-		for i := 1; i < vs.Len(); i++ {
-			vx, vy = vs.GetValues(i)
-			x = cl + xrange.Translate(vx)
-			y = cb - yrange.Translate(vy)
-			r.LineTo(x, y)
+		for _, segment := range segments {
+			first, last := segment[0], segment[len(segment)-1]
+
+			r.MoveTo(first.x, first.y)
+			for _, p := range segment[1:] {
+				r.LineTo(p.x, p.y)
+			}
+			r.LineTo(last.x, cb-yv0)
+			r.LineTo(first.x, cb-yv0)
+			r.LineTo(first.x, first.y)
 		}
-		r.LineTo(x, mathutil.MinInt(cb, cb-yv0))
-		r.LineTo(x0, mathutil.MinInt(cb, cb-yv0))
-		r.LineTo(x0, y0)
 		r.Fill()
 	}
 
 	if style.ShouldDrawStroke() {
 		style.GetStrokeOptions().WriteDrawingOptionsToRenderer(r)
-
-		r.MoveTo(x0, y0)
-		for i := 1; i < vs.Len(); i++ {
-			vx, vy = vs.GetValues(i)
-			x = cl + xrange.Translate(vx)
-			y = cb - yrange.Translate(vy)
-			r.LineTo(x, y)
+		for _, segment := range segments {
+			r.MoveTo(segment[0].x, segment[0].y)
+			for _, p := range segment[1:] {
+				r.LineTo(p.x, p.y)
+			}
 		}
 		r.Stroke()
 	}
@@ -172,18 +172,24 @@ func drawLineSeries(r render.Renderer, canvasBox render.Box, xrange, yrange sequ
 		defaultDotWidth := style.GetDotWidth()
 
 		style.GetDotOptions().WriteDrawingOptionsToRenderer(r)
-		for i := 0; i < vs.Len(); i++ {
-			vx, vy = vs.GetValues(i)
-			x = cl + xrange.Translate(vx)
-			y = cb - yrange.Translate(vy)
+		// Dots are drawn at the kept values only, while the dot providers
+		// are passed the indices of the values in the series.
+		for i := 0; i < path.Len(); i++ {
+			vx, vy := path.GetValues(i)
+			if !mathutil.IsFinite(vx) || !mathutil.IsFinite(vy) {
+				continue
+			}
+			index := sourceIndex(path, i)
+			x := cl + xrange.Translate(vx)
+			y := cb - yrange.Translate(vy)
 
 			dotWidth := defaultDotWidth
 			if style.DotWidthProvider != nil {
-				dotWidth = style.DotWidthProvider(xrange, yrange, i, vx, vy)
+				dotWidth = style.DotWidthProvider(xrange, yrange, index, vx, vy)
 			}
 
 			if style.DotColorProvider != nil {
-				dotColor := style.DotColorProvider(xrange, yrange, i, vx, vy)
+				dotColor := style.DotColorProvider(xrange, yrange, index, vx, vy)
 
 				r.SetFillColor(dotColor)
 				r.SetStrokeColor(dotColor)
@@ -194,3 +200,62 @@ func drawLineSeries(r render.Renderer, canvasBox render.Box, xrange, yrange sequ
 		}
 	}
 }
+
+// getBaseline returns the value fills and bars start from, which is zero
+// clamped to the range. For ranges not including zero, it is the bound
+// closest to zero, which is at the top of the canvas for descending ranges.
+func getBaseline(yrange sequence.Range) float64 {
+	min, max := yrange.GetMin(), yrange.GetMax()
+	if min > max {
+		min, max = max, min
+	}
+	return math.Max(min, math.Min(max, 0))
+}
+
+// lineSegments returns the canvas points of a line series, split into
+// continuous segments according to how missing values are handled.
+// Values with a missing (NaN or infinite) X component are always skipped.
+func lineSegments(canvasBox render.Box, xrange, yrange sequence.Range, mode render.LineGapMode, vs ValuesProvider) [][]linePoint {
+	cb := canvasBox.Bottom
+	cl := canvasBox.Left
+
+	var segments [][]linePoint
+	var current []linePoint
+	var gap bool
+	for i := 0; i < vs.Len(); i++ {
+		vx, vy := vs.GetValues(i)
+		if !mathutil.IsFinite(vx) {
+			continue
+		}
+
+		x := cl + xrange.Translate(vx)
+		if !mathutil.IsFinite(vy) {
+			switch mode {
+			case render.LineGapModeZero:
+				current = append(current, linePoint{x, cb - yrange.Translate(0)})
+			case render.LineGapModeConnect:
+			case render.LineGapModeStep:
+				gap = len(current) > 0
+			default:
+				if len(current) > 0 {
+					segments = append(segments, current)
+					current = nil
+				}
+			}
+			continue
+		}
+
+		y := cb - yrange.Translate(vy)
+		if gap {
+			// Hold the last value until the current one.
+			current = append(current, linePoint{x, current[len(current)-1].y})
+			gap = false
+		}
+		current = append(current, linePoint{x, y})
+	}
+
+	if len(current) > 0 {
+		segments = append(segments, current)
+	}
+	return segments
+}
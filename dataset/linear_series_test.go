@@ -0,0 +1,53 @@
+package dataset
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/unidoc/unichart/dataset/sequence"
+	"github.com/unidoc/unichart/render"
+)
+
+func TestLineSegmentsGapModes(t *testing.T) {
+	cs := ContinuousSeries{
+		XValues: []float64{0, 1, 2, 3, 4},
+		YValues: []float64{1, 2, math.NaN(), 4, math.Inf(1)},
+	}
+	canvasBox := render.Box{Right: 40, Bottom: 40}
+	xrange := &sequence.ContinuousRange{Min: 0, Max: 4, Domain: 40}
+	yrange := &sequence.ContinuousRange{Min: 0, Max: 4, Domain: 40}
+
+	segments := lineSegments(canvasBox, xrange, yrange, render.LineGapModeBreak, cs)
+	require.Equal(t, [][]linePoint{{{0, 30}, {10, 20}}, {{30, 0}}}, segments)
+
+	segments = lineSegments(canvasBox, xrange, yrange, render.LineGapModeConnect, cs)
+	require.Equal(t, [][]linePoint{{{0, 30}, {10, 20}, {30, 0}}}, segments)
+
+	segments = lineSegments(canvasBox, xrange, yrange, render.LineGapModeZero, cs)
+	require.Equal(t, [][]linePoint{{{0, 30}, {10, 20}, {20, 40}, {30, 0}, {40, 40}}}, segments)
+
+	segments = lineSegments(canvasBox, xrange, yrange, render.LineGapModeStep, cs)
+	require.Equal(t, [][]linePoint{{{0, 30}, {10, 20}, {30, 20}, {30, 0}}}, segments)
+}
+
+func TestLineSegmentsSkipsMissingX(t *testing.T) {
+	cs := ContinuousSeries{
+		XValues: []float64{math.NaN(), 1, 2},
+		YValues: []float64{1, 2, 3},
+	}
+	canvasBox := render.Box{Right: 20, Bottom: 20}
+	xrange := &sequence.ContinuousRange{Min: 0, Max: 2, Domain: 20}
+	yrange := &sequence.ContinuousRange{Min: 0, Max: 4, Domain: 20}
+
+	segments := lineSegments(canvasBox, xrange, yrange, render.LineGapModeBreak, cs)
+	require.Equal(t, [][]linePoint{{{10, 10}, {20, 5}}}, segments)
+}
+
+func TestGetBaseline(t *testing.T) {
+	require.Equal(t, 0.0, getBaseline(&sequence.ContinuousRange{Min: -5, Max: 5}))
+	require.Equal(t, 2.0, getBaseline(&sequence.ContinuousRange{Min: 2, Max: 8}))
+	require.Equal(t, -3.0, getBaseline(&sequence.ContinuousRange{Min: -9, Max: -3}))
+	require.Equal(t, 2.0, getBaseline(&sequence.ContinuousRange{Min: 2, Max: 8, Descending: true}))
+}
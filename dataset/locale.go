@@ -0,0 +1,355 @@
+package dataset
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	localesMu sync.RWMutex
+	locales   = map[string]Locale{}
+)
+
+func init() {
+	for _, l := range []Locale{localeEN, localeDE, localeFR, localeES, localeJA, localeZH} {
+		RegisterLocale(l)
+	}
+}
+
+// Locale holds the conventions used to format numbers and dates in a
+// language or region. Date formats are Go time layouts, in which month and
+// day names, as well as the AM/PM marks, are replaced with the names of the
+// locale.
+type Locale struct {
+	Name string
+
+	Number           NumberFormat
+	PercentSeparator string
+	CurrencySymbol   string
+	CurrencyDecimals int
+	CurrencySuffix   bool
+
+	Months      [12]string
+	ShortMonths [12]string
+	Days        [7]string
+	ShortDays   [7]string
+	AM          string
+	PM          string
+
+	DateFormat       string
+	DateHourFormat   string
+	DateMinuteFormat string
+	LongDateFormat   string
+}
+
+// RegisterLocale registers the specified locale, replacing the registered
+// locale with the same name, if any.
+func RegisterLocale(l Locale) {
+	localesMu.Lock()
+	defer localesMu.Unlock()
+	locales[normalizeLocaleName(l.Name)] = l
+}
+
+// GetLocale returns the locale registered with the specified name, such as
+// "de" or "en-US". Region specific names fall back to the locale of their
+// language if not registered.
+func GetLocale(name string) (Locale, bool) {
+	localesMu.RLock()
+	defer localesMu.RUnlock()
+
+	name = normalizeLocaleName(name)
+	if l, ok := locales[name]; ok {
+		return l, true
+	}
+	if i := strings.IndexByte(name, '-'); i > 0 {
+		l, ok := locales[name[:i]]
+		return l, ok
+	}
+	return Locale{}, false
+}
+
+// LocaleNames returns the sorted names of the registered locales.
+func LocaleNames() []string {
+	localesMu.RLock()
+	defer localesMu.RUnlock()
+
+	var names []string
+	for name := range locales {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// normalizeLocaleName returns the lower case form of a locale name, with
+// dashes separating its parts.
+func normalizeLocaleName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", "-"))
+}
+
+// FloatValueFormatter returns a formatter for values with the specified
+// number of decimals.
+func (l Locale) FloatValueFormatter(decimals int) ValueFormatter {
+	return func(v interface{}) string {
+		value, ok := toFloat64(v)
+		if !ok {
+			return ""
+		}
+		return l.Number.Format(value, decimals)
+	}
+}
+
+// PercentValueFormatter returns a formatter for percent values, with the
+// specified number of decimals.
+// NOTE: it normalizes the values, i.e. multiplies by 100.0.
+func (l Locale) PercentValueFormatter(decimals int) ValueFormatter {
+	return func(v interface{}) string {
+		value, ok := toFloat64(v)
+		if !ok {
+			return ""
+		}
+		return l.Number.Format(value*100, decimals) + l.PercentSeparator + "%"
+	}
+}
+
+// CurrencyValueFormatter returns a formatter for amounts in the currency of
+// the locale.
+func (l Locale) CurrencyValueFormatter() ValueFormatter {
+	if !l.CurrencySuffix {
+		return CurrencyValueFormatter(l.CurrencySymbol, l.CurrencyDecimals, l.Number)
+	}
+
+	return func(v interface{}) string {
+		value, ok := toFloat64(v)
+		if !ok {
+			return ""
+		}
+		return l.Number.Format(value, l.CurrencyDecimals) + " " + l.CurrencySymbol
+	}
+}
+
+// TimeValueFormatter returns a formatter for timestamps, using the date
+// format of the locale.
+func (l Locale) TimeValueFormatter() ValueFormatter {
+	return l.TimeValueFormatterWithFormat(l.DateFormat)
+}
+
+// TimeHourValueFormatter returns a formatter for timestamps, using the hour
+// format of the locale.
+func (l Locale) TimeHourValueFormatter() ValueFormatter {
+	return l.TimeValueFormatterWithFormat(l.DateHourFormat)
+}
+
+// TimeMinuteValueFormatter returns a formatter for timestamps, using the
+// minute format of the locale.
+func (l Locale) TimeMinuteValueFormatter() ValueFormatter {
+	return l.TimeValueFormatterWithFormat(l.DateMinuteFormat)
+}
+
+// TimeLongValueFormatter returns a formatter for timestamps, using the long
+// date format of the locale, which includes month names.
+func (l Locale) TimeLongValueFormatter() ValueFormatter {
+	return l.TimeValueFormatterWithFormat(l.LongDateFormat)
+}
+
+// TimeValueFormatterWithFormat returns a formatter for timestamps, using the
+// specified layout with the month and day names of the locale.
+func (l Locale) TimeValueFormatterWithFormat(layout string) ValueFormatter {
+	return func(v interface{}) string {
+		t, ok := toTime(v)
+		if !ok {
+			return ""
+		}
+		return l.FormatTime(t, layout)
+	}
+}
+
+// FormatTime formats the specified time using a Go time layout, with the
+// month and day names of the locale.
+func (l Locale) FormatTime(t time.Time, layout string) string {
+	names := []struct {
+		token string
+		name  func() string
+	}{
+		{"January", func() string { return l.Months[t.Month()-1] }},
+		{"Jan", func() string { return l.ShortMonths[t.Month()-1] }},
+		{"Monday", func() string { return l.Days[t.Weekday()] }},
+		{"Mon", func() string { return l.ShortDays[t.Weekday()] }},
+		{"PM", func() string { return l.meridiem(t) }},
+		{"pm", func() string { return strings.ToLower(l.meridiem(t)) }},
+	}
+
+	var sb strings.Builder
+	start := 0
+	for i := 0; i < len(layout); {
+		matched := false
+		for _, n := range names {
+			if !strings.HasPrefix(layout[i:], n.token) {
+				continue
+			}
+
+			name := n.name()
+			if name == "" {
+				// Keep the English name if the locale does not define one.
+				name = t.Format(n.token)
+			}
+			sb.WriteString(t.Format(layout[start:i]))
+			sb.WriteString(name)
+			i += len(n.token)
+			start, matched = i, true
+			break
+		}
+		if !matched {
+			i++
+		}
+	}
+	sb.WriteString(t.Format(layout[start:]))
+	return sb.String()
+}
+
+// meridiem returns the AM or PM mark of the specified time.
+func (l Locale) meridiem(t time.Time) string {
+	if t.Hour() < 12 {
+		return l.AM
+	}
+	return l.PM
+}
+
+// toTime converts a timestamp value to time.Time. Numeric values are
+// nanoseconds since the Unix epoch.
+func toTime(v interface{}) (time.Time, bool) {
+	switch t := v.(type) {
+	case time.Time:
+		return t, true
+	case int64:
+		return time.Unix(0, t), true
+	case float64:
+		if math.IsNaN(t) || math.IsInf(t, 0) {
+			return time.Time{}, false
+		}
+		return time.Unix(0, int64(t)), true
+	}
+	return time.Time{}, false
+}
+
+var localeEN = Locale{
+	Name:             "en",
+	Number:           DefaultNumberFormat,
+	CurrencySymbol:   "$",
+	CurrencyDecimals: 2,
+	Months: [12]string{"January", "February", "March", "April", "May", "June",
+		"July", "August", "September", "October", "November", "December"},
+	ShortMonths:      [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+	Days:             [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+	ShortDays:        [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+	AM:               "AM",
+	PM:               "PM",
+	DateFormat:       "01/02/2006",
+	DateHourFormat:   "01/02 3PM",
+	DateMinuteFormat: "01/02 3:04PM",
+	LongDateFormat:   "January 2, 2006",
+}
+
+var localeDE = Locale{
+	Name:             "de",
+	Number:           NumberFormat{DecimalSeparator: ",", GroupSeparator: ".", GroupSize: 3},
+	PercentSeparator: " ",
+	CurrencySymbol:   "€",
+	CurrencyDecimals: 2,
+	CurrencySuffix:   true,
+	Months: [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni",
+		"Juli", "August", "September", "Oktober", "November", "Dezember"},
+	ShortMonths: [12]string{"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni",
+		"Juli", "Aug.", "Sept.", "Okt.", "Nov.", "Dez."},
+	Days:             [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+	ShortDays:        [7]string{"So.", "Mo.", "Di.", "Mi.", "Do.", "Fr.", "Sa."},
+	AM:               "AM",
+	PM:               "PM",
+	DateFormat:       "02.01.2006",
+	DateHourFormat:   "02.01. 15 Uhr",
+	DateMinuteFormat: "02.01. 15:04",
+	LongDateFormat:   "2. January 2006",
+}
+
+var localeFR = Locale{
+	Name:             "fr",
+	Number:           NumberFormat{DecimalSeparator: ",", GroupSeparator: " ", GroupSize: 3},
+	PercentSeparator: " ",
+	CurrencySymbol:   "€",
+	CurrencyDecimals: 2,
+	CurrencySuffix:   true,
+	Months: [12]string{"janvier", "février", "mars", "avril", "mai", "juin",
+		"juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+	ShortMonths: [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin",
+		"juil.", "août", "sept.", "oct.", "nov.", "déc."},
+	Days:             [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+	ShortDays:        [7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+	AM:               "AM",
+	PM:               "PM",
+	DateFormat:       "02/01/2006",
+	DateHourFormat:   "02/01 15h",
+	DateMinuteFormat: "02/01 15:04",
+	LongDateFormat:   "2 January 2006",
+}
+
+var localeES = Locale{
+	Name:             "es",
+	Number:           NumberFormat{DecimalSeparator: ",", GroupSeparator: ".", GroupSize: 3},
+	PercentSeparator: " ",
+	CurrencySymbol:   "€",
+	CurrencyDecimals: 2,
+	CurrencySuffix:   true,
+	Months: [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio",
+		"julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+	ShortMonths: [12]string{"ene", "feb", "mar", "abr", "may", "jun",
+		"jul", "ago", "sept", "oct", "nov", "dic"},
+	Days:             [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+	ShortDays:        [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+	AM:               "a. m.",
+	PM:               "p. m.",
+	DateFormat:       "02/01/2006",
+	DateHourFormat:   "02/01 15h",
+	DateMinuteFormat: "02/01 15:04",
+	LongDateFormat:   "2 de January de 2006",
+}
+
+var localeJA = Locale{
+	Name:             "ja",
+	Number:           DefaultNumberFormat,
+	CurrencySymbol:   "¥",
+	CurrencyDecimals: 0,
+	Months: [12]string{"1月", "2月", "3月", "4月", "5月", "6月",
+		"7月", "8月", "9月", "10月", "11月", "12月"},
+	ShortMonths: [12]string{"1月", "2月", "3月", "4月", "5月", "6月",
+		"7月", "8月", "9月", "10月", "11月", "12月"},
+	Days:             [7]string{"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"},
+	ShortDays:        [7]string{"日", "月", "火", "水", "木", "金", "土"},
+	AM:               "午前",
+	PM:               "午後",
+	DateFormat:       "2006/01/02",
+	DateHourFormat:   "01/02 15時",
+	DateMinuteFormat: "01/02 15:04",
+	LongDateFormat:   "2006年1月2日",
+}
+
+var localeZH = Locale{
+	Name:             "zh",
+	Number:           DefaultNumberFormat,
+	CurrencySymbol:   "¥",
+	CurrencyDecimals: 2,
+	Months: [12]string{"一月", "二月", "三月", "四月", "五月", "六月",
+		"七月", "八月", "九月", "十月", "十一月", "十二月"},
+	ShortMonths: [12]string{"1月", "2月", "3月", "4月", "5月", "6月",
+		"7月", "8月", "9月", "10月", "11月", "12月"},
+	Days:             [7]string{"星期日", "星期一", "星期二", "星期三", "星期四", "星期五", "星期六"},
+	ShortDays:        [7]string{"周日", "周一", "周二", "周三", "周四", "周五", "周六"},
+	AM:               "上午",
+	PM:               "下午",
+	DateFormat:       "2006/01/02",
+	DateHourFormat:   "01/02 15时",
+	DateMinuteFormat: "01/02 15:04",
+	LongDateFormat:   "2006年1月2日",
+}
@@ -0,0 +1,79 @@
+package dataset
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetLocale(t *testing.T) {
+	for _, name := range []string{"en", "de", "fr", "es", "ja", "zh"} {
+		l, ok := GetLocale(name)
+		require.True(t, ok, name)
+		require.Equal(t, name, l.Name)
+	}
+
+	l, ok := GetLocale("de_AT")
+	require.True(t, ok)
+	require.Equal(t, "de", l.Name)
+
+	_, ok = GetLocale("xx")
+	require.False(t, ok)
+
+	RegisterLocale(Locale{Name: "en-GB", Number: DefaultNumberFormat, CurrencySymbol: "£", CurrencyDecimals: 2})
+	defer func() {
+		localesMu.Lock()
+		delete(locales, "en-gb")
+		localesMu.Unlock()
+	}()
+	l, ok = GetLocale("en-gb")
+	require.True(t, ok)
+	require.Equal(t, "£1,234.50", l.CurrencyValueFormatter()(1234.5))
+	require.Contains(t, LocaleNames(), "en-gb")
+}
+
+func TestLocaleNumberFormatters(t *testing.T) {
+	de, _ := GetLocale("de")
+	require.Equal(t, "1.234.567,89", de.FloatValueFormatter(2)(1234567.891))
+	require.Equal(t, "12,5 %", de.PercentValueFormatter(1)(0.125))
+	require.Equal(t, "-1.234,50 €", de.CurrencyValueFormatter()(-1234.5))
+
+	fr, _ := GetLocale("fr")
+	require.Equal(t, "1 234,5", fr.FloatValueFormatter(1)(1234.5))
+
+	ja, _ := GetLocale("ja")
+	require.Equal(t, "¥1,235", ja.CurrencyValueFormatter()(1234.6))
+
+	en, _ := GetLocale("en")
+	require.Equal(t, "12.5%", en.PercentValueFormatter(1)(0.125))
+	require.Equal(t, "", en.FloatValueFormatter(2)("x"))
+}
+
+func TestLocaleTimeFormatters(t *testing.T) {
+	d := time.Date(2024, time.March, 5, 14, 30, 0, 0, time.UTC)
+
+	de, _ := GetLocale("de")
+	require.Equal(t, "05.03.2024", de.TimeValueFormatter()(d))
+	require.Equal(t, "5. März 2024", de.TimeLongValueFormatter()(d))
+	require.Equal(t, "Dienstag, 5. März", de.FormatTime(d, "Monday, 2. January"))
+	require.Equal(t, "05.03. 14:30", de.TimeMinuteValueFormatter()(d.UnixNano()))
+
+	fr, _ := GetLocale("fr")
+	require.Equal(t, "mar. 5 mars 2024", fr.FormatTime(d, "Mon 2 Jan 2006"))
+
+	es, _ := GetLocale("es")
+	require.Equal(t, "5 de marzo de 2024", es.TimeLongValueFormatter()(float64(d.UnixNano())))
+
+	ja, _ := GetLocale("ja")
+	require.Equal(t, "2024年3月5日", ja.TimeLongValueFormatter()(d))
+	require.Equal(t, "午後2:30", ja.FormatTime(d, "PM3:04"))
+
+	zh, _ := GetLocale("zh")
+	require.Equal(t, "三月 星期二", zh.FormatTime(d, "January Monday"))
+
+	en, _ := GetLocale("en")
+	require.Equal(t, "03/05 2PM", en.TimeHourValueFormatter()(d))
+	require.Equal(t, "March 5, 2024", en.TimeLongValueFormatter()(d))
+	require.Equal(t, "", en.TimeValueFormatter()("x"))
+}
@@ -0,0 +1,137 @@
+package dataset
+
+import (
+	"fmt"
+
+	"github.com/unidoc/unichart/dataset/sequence"
+	"github.com/unidoc/unichart/mathutil"
+	"github.com/unidoc/unichart/render"
+)
+
+const (
+	// defaultLoessBandwidth is the default fraction of the values used by
+	// each local regression of LOESS series.
+	defaultLoessBandwidth = 0.3
+
+	// defaultLoessIterations is the default number of robustness iterations
+	// of LOESS series.
+	defaultLoessIterations = 2
+)
+
+// Interface Assertions.
+var (
+	_ Series              = (*LoessSeries)(nil)
+	_ FirstValuesProvider = (*LoessSeries)(nil)
+	_ LastValuesProvider  = (*LoessSeries)(nil)
+)
+
+// LoessSeries plots the LOESS (locally weighted scatterplot smoothing) fit
+// of the values of an inner series. Each value is smoothed by a weighted
+// linear regression over the nearest values, covering the Bandwidth
+// fraction of the series.
+type LoessSeries struct {
+	Name  string
+	Style render.Style
+	YAxis YAxisType
+
+	// Bandwidth is the fraction (0, 1] of the values used by each local
+	// regression. Larger bandwidths produce smoother fits.
+	Bandwidth float64
+	// Iterations is the number of robustness iterations, which reduce the
+	// influence of outliers. A negative value disables them.
+	Iterations  int
+	InnerSeries ValuesProvider
+
+	xvalues []float64
+	yvalues []float64
+	err     error
+}
+
+// GetName returns the name of the time series.
+func (ls LoessSeries) GetName() string {
+	return ls.Name
+}
+
+// GetStyle returns the line style.
+func (ls LoessSeries) GetStyle() render.Style {
+	return ls.Style
+}
+
+// GetYAxis returns which YAxis the series draws on.
+func (ls LoessSeries) GetYAxis() YAxisType {
+	return ls.YAxis
+}
+
+// GetBandwidth returns the fraction of the values used by each local
+// regression.
+func (ls LoessSeries) GetBandwidth() float64 {
+	if ls.Bandwidth <= 0 || ls.Bandwidth > 1 {
+		return defaultLoessBandwidth
+	}
+	return ls.Bandwidth
+}
+
+// GetIterations returns the number of robustness iterations.
+func (ls LoessSeries) GetIterations() int {
+	if ls.Iterations == 0 {
+		return defaultLoessIterations
+	}
+	if ls.Iterations < 0 {
+		return 0
+	}
+	return ls.Iterations
+}
+
+// Len returns the number of elements in the series.
+func (ls *LoessSeries) Len() int {
+	ls.ensureCachedValues()
+	return len(ls.xvalues)
+}
+
+// GetValues gets a value at a given index.
+func (ls *LoessSeries) GetValues(index int) (x, y float64) {
+	ls.ensureCachedValues()
+	if index < 0 || index >= len(ls.xvalues) {
+		return
+	}
+	return ls.xvalues[index], ls.yvalues[index]
+}
+
+// GetFirstValues computes the first smoothed value.
+func (ls *LoessSeries) GetFirstValues() (x, y float64) {
+	return ls.GetValues(0)
+}
+
+// GetLastValues computes the last smoothed value.
+func (ls *LoessSeries) GetLastValues() (x, y float64) {
+	return ls.GetValues(ls.Len() - 1)
+}
+
+// Render renders the series.
+func (ls *LoessSeries) Render(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, defaults render.Style) {
+	style := ls.Style.InheritFrom(defaults)
+	drawLineSeries(r, canvasBox, xrange, yrange, style, ls)
+}
+
+// Validate validates the series.
+func (ls *LoessSeries) Validate() error {
+	if ls.InnerSeries == nil {
+		return fmt.Errorf("loess series requires InnerSeries to be set")
+	}
+	ls.ensureCachedValues()
+	return ls.err
+}
+
+func (ls *LoessSeries) ensureCachedValues() {
+	if ls.xvalues != nil || ls.err != nil || ls.InnerSeries == nil {
+		return
+	}
+
+	xvalues, yvalues := regressionWindow(ls.InnerSeries, 0, ls.InnerSeries.Len())
+	fitted, err := mathutil.Loess(xvalues, yvalues, ls.GetBandwidth(), ls.GetIterations())
+	if err != nil {
+		ls.err = err
+		return
+	}
+	ls.xvalues, ls.yvalues = xvalues, fitted
+}
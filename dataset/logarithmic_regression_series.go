@@ -0,0 +1,140 @@
+package dataset
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/unidoc/unichart/dataset/sequence"
+	"github.com/unidoc/unichart/mathutil"
+	"github.com/unidoc/unichart/render"
+)
+
+// Interface Assertions.
+var (
+	_ Series              = (*LogarithmicRegressionSeries)(nil)
+	_ FirstValuesProvider = (*LogarithmicRegressionSeries)(nil)
+	_ LastValuesProvider  = (*LogarithmicRegressionSeries)(nil)
+)
+
+// LogarithmicRegressionSeries plots the logarithmic regression
+// `y = a + b*ln(x)` for the values of an inner series. The X values must be
+// positive.
+type LogarithmicRegressionSeries struct {
+	Name  string
+	Style render.Style
+	YAxis YAxisType
+
+	Limit       int
+	Offset      int
+	InnerSeries ValuesProvider
+
+	a      float64
+	b      float64
+	fitted bool
+}
+
+// Coefficients returns the `a` and `b` coefficients of the regression.
+func (lgrs *LogarithmicRegressionSeries) Coefficients() (a, b float64, err error) {
+	if err := lgrs.ensureFitted(); err != nil {
+		return 0, 0, err
+	}
+	return lgrs.a, lgrs.b, nil
+}
+
+// GetName returns the name of the time series.
+func (lgrs LogarithmicRegressionSeries) GetName() string {
+	return lgrs.Name
+}
+
+// GetStyle returns the line style.
+func (lgrs LogarithmicRegressionSeries) GetStyle() render.Style {
+	return lgrs.Style
+}
+
+// GetYAxis returns which YAxis the series draws on.
+func (lgrs LogarithmicRegressionSeries) GetYAxis() YAxisType {
+	return lgrs.YAxis
+}
+
+// Len returns the number of elements in the series.
+func (lgrs LogarithmicRegressionSeries) Len() int {
+	return mathutil.MinInt(lgrs.GetLimit(), lgrs.InnerSeries.Len()-lgrs.GetOffset())
+}
+
+// GetLimit returns the window size.
+func (lgrs LogarithmicRegressionSeries) GetLimit() int {
+	if lgrs.Limit == 0 {
+		return lgrs.InnerSeries.Len()
+	}
+	return lgrs.Limit
+}
+
+// GetEndIndex returns the effective limit end.
+func (lgrs LogarithmicRegressionSeries) GetEndIndex() int {
+	windowEnd := lgrs.GetOffset() + lgrs.GetLimit()
+	innerSeriesLastIndex := lgrs.InnerSeries.Len() - 1
+	return mathutil.MinInt(windowEnd, innerSeriesLastIndex)
+}
+
+// GetOffset returns the data offset.
+func (lgrs LogarithmicRegressionSeries) GetOffset() int {
+	if lgrs.Offset == 0 {
+		return 0
+	}
+	return lgrs.Offset
+}
+
+// GetValues gets a value at a given index.
+func (lgrs *LogarithmicRegressionSeries) GetValues(index int) (x, y float64) {
+	if lgrs.InnerSeries == nil || lgrs.InnerSeries.Len() == 0 {
+		return
+	}
+	if err := lgrs.ensureFitted(); err != nil {
+		return math.NaN(), math.NaN()
+	}
+	effectiveIndex := mathutil.MinInt(index+lgrs.GetOffset(), lgrs.InnerSeries.Len()-1)
+	x, _ = lgrs.InnerSeries.GetValues(effectiveIndex)
+	return x, lgrs.apply(x)
+}
+
+// GetFirstValues computes the first regression value.
+func (lgrs *LogarithmicRegressionSeries) GetFirstValues() (x, y float64) {
+	return lgrs.GetValues(0)
+}
+
+// GetLastValues computes the last regression value.
+func (lgrs *LogarithmicRegressionSeries) GetLastValues() (x, y float64) {
+	return lgrs.GetValues(lgrs.Len() - 1)
+}
+
+// Render renders the series.
+func (lgrs *LogarithmicRegressionSeries) Render(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, defaults render.Style) {
+	style := lgrs.Style.InheritFrom(defaults)
+	drawLineSeries(r, canvasBox, xrange, yrange, style, lgrs)
+}
+
+// Validate validates the series.
+func (lgrs *LogarithmicRegressionSeries) Validate() error {
+	if lgrs.InnerSeries == nil {
+		return fmt.Errorf("logarithmic regression series requires InnerSeries to be set")
+	}
+	return lgrs.ensureFitted()
+}
+
+func (lgrs *LogarithmicRegressionSeries) apply(x float64) float64 {
+	return lgrs.a + lgrs.b*math.Log(x)
+}
+
+func (lgrs *LogarithmicRegressionSeries) ensureFitted() error {
+	if lgrs.fitted {
+		return nil
+	}
+
+	xvalues, yvalues := regressionWindow(lgrs.InnerSeries, lgrs.GetOffset(), lgrs.Len())
+	a, b, err := mathutil.LogRegression(xvalues, yvalues)
+	if err != nil {
+		return err
+	}
+	lgrs.a, lgrs.b, lgrs.fitted = a, b, true
+	return nil
+}
@@ -0,0 +1,70 @@
+package dataset
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DefaultNumberFormat is the number format with a dot decimal separator and
+// comma separated groups of thousands.
+var DefaultNumberFormat = NumberFormat{
+	DecimalSeparator: ".",
+	GroupSeparator:   ",",
+	GroupSize:        3,
+}
+
+// NumberFormat represents the way numbers are written. Digits of the integer
+// part of numbers are grouped only if both the group separator and the group
+// size are specified.
+type NumberFormat struct {
+	DecimalSeparator string
+	GroupSeparator   string
+	GroupSize        int
+}
+
+// GetDecimalSeparator returns the decimal separator.
+func (nf NumberFormat) GetDecimalSeparator(defaults ...string) string {
+	if nf.DecimalSeparator == "" {
+		if len(defaults) > 0 {
+			return defaults[0]
+		}
+		return "."
+	}
+	return nf.DecimalSeparator
+}
+
+// Format returns the specified value with the specified number of decimals.
+func (nf NumberFormat) Format(v float64, decimals int) string {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+
+	s := strconv.FormatFloat(math.Abs(v), 'f', decimals, 64)
+	integer, fraction := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		integer, fraction = s[:i], s[i+1:]
+	}
+
+	var sb strings.Builder
+	if v < 0 && strings.Trim(s, "0.") != "" {
+		sb.WriteByte('-')
+	}
+
+	if nf.GroupSize > 0 && nf.GroupSeparator != "" {
+		for i, c := range integer {
+			if i > 0 && (len(integer)-i)%nf.GroupSize == 0 {
+				sb.WriteString(nf.GroupSeparator)
+			}
+			sb.WriteRune(c)
+		}
+	} else {
+		sb.WriteString(integer)
+	}
+
+	if fraction != "" {
+		sb.WriteString(nf.GetDecimalSeparator())
+		sb.WriteString(fraction)
+	}
+	return sb.String()
+}
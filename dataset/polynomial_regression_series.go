@@ -11,9 +11,11 @@ import (
 
 // Interface Assertions.
 var (
-	_ Series              = (*PolynomialRegressionSeries)(nil)
-	_ FirstValuesProvider = (*PolynomialRegressionSeries)(nil)
-	_ LastValuesProvider  = (*PolynomialRegressionSeries)(nil)
+	_ Series                  = (*PolynomialRegressionSeries)(nil)
+	_ FirstValuesProvider     = (*PolynomialRegressionSeries)(nil)
+	_ LastValuesProvider      = (*PolynomialRegressionSeries)(nil)
+	_ RegressionStatsProvider = (*PolynomialRegressionSeries)(nil)
+	_ ErrorBoundsProvider     = (*PolynomialRegressionSeries)(nil)
 )
 
 // PolynomialRegressionSeries implements a polynomial regression over a given
@@ -28,7 +30,17 @@ type PolynomialRegressionSeries struct {
 	Degree      int
 	InnerSeries ValuesProvider
 
+	// ConfidenceLevel is the level (e.g. 0.95) of the confidence band of
+	// the mean response drawn around the fit. Zero disables the band.
+	ConfidenceLevel float64
+	// PredictionLevel is the level (e.g. 0.95) of the prediction band of
+	// new observations drawn around the fit. Zero disables the band.
+	PredictionLevel float64
+	// BandStyle is the style of the confidence and prediction bands.
+	BandStyle render.Style
+
 	coeffs []float64
+	stats  *mathutil.RegressionStats
 }
 
 // GetName returns the name of the time series.
@@ -144,6 +156,36 @@ func (prs *PolynomialRegressionSeries) GetLastValues() (x, y float64) {
 	return
 }
 
+// Stats returns the fit statistics of the regression, such as the R^2
+// value, the residual standard error and the coefficient standard errors.
+func (prs *PolynomialRegressionSeries) Stats() (*mathutil.RegressionStats, error) {
+	if prs.stats == nil {
+		xvalues, yvalues := prs.values()
+		stats, err := mathutil.PolyRegressionStats(xvalues, yvalues, prs.Degree)
+		if err != nil {
+			return nil, err
+		}
+		prs.stats = stats
+	}
+	return prs.stats, nil
+}
+
+// GetErrorBounds returns the bounds of the confidence and prediction bands
+// at a given index.
+func (prs *PolynomialRegressionSeries) GetErrorBounds(index int) (xlow, xhigh, ylow, yhigh float64) {
+	x, y := prs.GetValues(index)
+	xlow, xhigh, ylow, yhigh = x, x, y, y
+	if prs.ConfidenceLevel <= 0 && prs.PredictionLevel <= 0 {
+		return
+	}
+
+	if stats, err := prs.Stats(); err == nil {
+		width := regressionBandWidth(stats, x, prs.ConfidenceLevel, prs.PredictionLevel)
+		ylow, yhigh = y-width, y+width
+	}
+	return
+}
+
 func (prs *PolynomialRegressionSeries) apply(v float64) (out float64) {
 	for index, coeff := range prs.coeffs {
 		out = out + (coeff * math.Pow(v, float64(index)))
@@ -175,5 +217,10 @@ func (prs *PolynomialRegressionSeries) values() (xvalues, yvalues []float64) {
 // Render renders the series.
 func (prs *PolynomialRegressionSeries) Render(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, defaults render.Style) {
 	style := prs.Style.InheritFrom(defaults)
+	if prs.ConfidenceLevel > 0 || prs.PredictionLevel > 0 {
+		if stats, err := prs.Stats(); err == nil {
+			drawRegressionBands(r, canvasBox, xrange, yrange, prs.BandStyle, style, stats, prs, prs.ConfidenceLevel, prs.PredictionLevel)
+		}
+	}
 	drawLineSeries(r, canvasBox, xrange, yrange, style, prs)
 }
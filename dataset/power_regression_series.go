@@ -0,0 +1,152 @@
+package dataset
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/unidoc/unichart/dataset/sequence"
+	"github.com/unidoc/unichart/mathutil"
+	"github.com/unidoc/unichart/render"
+)
+
+// Interface Assertions.
+var (
+	_ Series              = (*PowerRegressionSeries)(nil)
+	_ FirstValuesProvider = (*PowerRegressionSeries)(nil)
+	_ LastValuesProvider  = (*PowerRegressionSeries)(nil)
+)
+
+// PowerRegressionSeries plots the power law regression `y = a*x^b` for the
+// values of an inner series. The X and Y values must be positive. The
+// regression is fitted on X values scaled by their geometric mean, so that
+// large X values, such as times, do not overflow the fit.
+type PowerRegressionSeries struct {
+	Name  string
+	Style render.Style
+	YAxis YAxisType
+
+	Limit       int
+	Offset      int
+	InnerSeries ValuesProvider
+
+	a      float64
+	b      float64
+	scalex float64
+	fitted bool
+}
+
+// Coefficients returns the `a` and `b` coefficients of the regression.
+func (pwrs *PowerRegressionSeries) Coefficients() (a, b float64, err error) {
+	if err := pwrs.ensureFitted(); err != nil {
+		return 0, 0, err
+	}
+	return pwrs.a * math.Pow(pwrs.scalex, -pwrs.b), pwrs.b, nil
+}
+
+// GetName returns the name of the time series.
+func (pwrs PowerRegressionSeries) GetName() string {
+	return pwrs.Name
+}
+
+// GetStyle returns the line style.
+func (pwrs PowerRegressionSeries) GetStyle() render.Style {
+	return pwrs.Style
+}
+
+// GetYAxis returns which YAxis the series draws on.
+func (pwrs PowerRegressionSeries) GetYAxis() YAxisType {
+	return pwrs.YAxis
+}
+
+// Len returns the number of elements in the series.
+func (pwrs PowerRegressionSeries) Len() int {
+	return mathutil.MinInt(pwrs.GetLimit(), pwrs.InnerSeries.Len()-pwrs.GetOffset())
+}
+
+// GetLimit returns the window size.
+func (pwrs PowerRegressionSeries) GetLimit() int {
+	if pwrs.Limit == 0 {
+		return pwrs.InnerSeries.Len()
+	}
+	return pwrs.Limit
+}
+
+// GetEndIndex returns the effective limit end.
+func (pwrs PowerRegressionSeries) GetEndIndex() int {
+	windowEnd := pwrs.GetOffset() + pwrs.GetLimit()
+	innerSeriesLastIndex := pwrs.InnerSeries.Len() - 1
+	return mathutil.MinInt(windowEnd, innerSeriesLastIndex)
+}
+
+// GetOffset returns the data offset.
+func (pwrs PowerRegressionSeries) GetOffset() int {
+	if pwrs.Offset == 0 {
+		return 0
+	}
+	return pwrs.Offset
+}
+
+// GetValues gets a value at a given index.
+func (pwrs *PowerRegressionSeries) GetValues(index int) (x, y float64) {
+	if pwrs.InnerSeries == nil || pwrs.InnerSeries.Len() == 0 {
+		return
+	}
+	if err := pwrs.ensureFitted(); err != nil {
+		return math.NaN(), math.NaN()
+	}
+	effectiveIndex := mathutil.MinInt(index+pwrs.GetOffset(), pwrs.InnerSeries.Len()-1)
+	x, _ = pwrs.InnerSeries.GetValues(effectiveIndex)
+	return x, pwrs.apply(x)
+}
+
+// GetFirstValues computes the first regression value.
+func (pwrs *PowerRegressionSeries) GetFirstValues() (x, y float64) {
+	return pwrs.GetValues(0)
+}
+
+// GetLastValues computes the last regression value.
+func (pwrs *PowerRegressionSeries) GetLastValues() (x, y float64) {
+	return pwrs.GetValues(pwrs.Len() - 1)
+}
+
+// Render renders the series.
+func (pwrs *PowerRegressionSeries) Render(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, defaults render.Style) {
+	style := pwrs.Style.InheritFrom(defaults)
+	drawLineSeries(r, canvasBox, xrange, yrange, style, pwrs)
+}
+
+// Validate validates the series.
+func (pwrs *PowerRegressionSeries) Validate() error {
+	if pwrs.InnerSeries == nil {
+		return fmt.Errorf("power regression series requires InnerSeries to be set")
+	}
+	return pwrs.ensureFitted()
+}
+
+func (pwrs *PowerRegressionSeries) apply(x float64) float64 {
+	return pwrs.a * math.Pow(x/pwrs.scalex, pwrs.b)
+}
+
+func (pwrs *PowerRegressionSeries) ensureFitted() error {
+	if pwrs.fitted {
+		return nil
+	}
+
+	xvalues, yvalues := regressionWindow(pwrs.InnerSeries, pwrs.GetOffset(), pwrs.Len())
+	var logsum float64
+	for _, x := range xvalues {
+		logsum += math.Log(x)
+	}
+	scalex := math.Exp(logsum / float64(len(xvalues)))
+	scaled := make([]float64, len(xvalues))
+	for i, x := range xvalues {
+		scaled[i] = x / scalex
+	}
+
+	a, b, err := mathutil.PowerRegression(scaled, yvalues)
+	if err != nil {
+		return err
+	}
+	pwrs.a, pwrs.b, pwrs.scalex, pwrs.fitted = a, b, scalex, true
+	return nil
+}
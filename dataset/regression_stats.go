@@ -0,0 +1,145 @@
+package dataset
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/unidoc/unichart/dataset/sequence"
+	"github.com/unidoc/unichart/mathutil"
+	"github.com/unidoc/unichart/render"
+)
+
+// RegressionStatsProvider is a regression series which exposes the fit
+// statistics of its regression.
+type RegressionStatsProvider interface {
+	ValuesProvider
+	LastValuesProvider
+	Stats() (*mathutil.RegressionStats, error)
+}
+
+// RegressionEquation returns the equation of a fitted polynomial along with
+// its coefficient of determination, e.g. "y = 2.00x + 1.00, R^2 = 0.98".
+// The coefficients and the R^2 value are formatted using the specified
+// value formatter, which defaults to FloatValueFormatter.
+func RegressionEquation(stats *mathutil.RegressionStats, vfs ...ValueFormatter) string {
+	vf := ValueFormatter(FloatValueFormatter)
+	if len(vfs) > 0 && vfs[0] != nil {
+		vf = vfs[0]
+	}
+
+	var terms []string
+	for degree := len(stats.Coefficients) - 1; degree >= 0; degree-- {
+		coeff := stats.Coefficients[degree]
+		if coeff == 0 && degree > 0 {
+			continue
+		}
+
+		sign := "+"
+		if coeff < 0 {
+			sign = "-"
+		}
+
+		term := vf(math.Abs(coeff))
+		switch degree {
+		case 0:
+		case 1:
+			term += "x"
+		default:
+			term += fmt.Sprintf("x^%d", degree)
+		}
+
+		if len(terms) == 0 {
+			if sign == "-" {
+				term = "-" + term
+			}
+			terms = append(terms, term)
+			continue
+		}
+		terms = append(terms, sign, term)
+	}
+
+	return fmt.Sprintf("y = %s, R^2 = %s", strings.Join(terms, " "), vf(stats.RSquared))
+}
+
+// RegressionAnnotationSeries returns an annotation series showing the
+// equation and the R^2 value of a regression series, at its last value.
+func RegressionAnnotationSeries(rsp RegressionStatsProvider, vfs ...ValueFormatter) (AnnotationSeries, error) {
+	stats, err := rsp.Stats()
+	if err != nil {
+		return AnnotationSeries{}, err
+	}
+
+	var lastValue Value2
+	lastValue.XValue, lastValue.YValue = rsp.GetLastValues()
+	lastValue.Label = RegressionEquation(stats, vfs...)
+
+	var seriesName string
+	var seriesStyle render.Style
+	if typed, isTyped := rsp.(Series); isTyped {
+		seriesName = fmt.Sprintf("%s - Equation", typed.GetName())
+		seriesStyle = typed.GetStyle()
+	}
+
+	return AnnotationSeries{
+		Name:        seriesName,
+		Style:       seriesStyle,
+		Annotations: []Value2{lastValue},
+	}, nil
+}
+
+// regressionBandWidth returns the half width of the widest band drawn
+// around a regression at x.
+func regressionBandWidth(stats *mathutil.RegressionStats, x, confidenceLevel, predictionLevel float64) float64 {
+	var width float64
+	if confidenceLevel > 0 {
+		width = stats.ConfidenceInterval(x, confidenceLevel)
+	}
+	if predictionLevel > 0 {
+		width = math.Max(width, stats.PredictionInterval(x, predictionLevel))
+	}
+	if !mathutil.IsFinite(width) {
+		return 0
+	}
+	return width
+}
+
+// drawRegressionBands draws the prediction and confidence bands around the
+// values of a regression series. The bands are drawn like confidence band
+// series, using the specified band style.
+func drawRegressionBands(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, bandStyle, defaults render.Style, stats *mathutil.RegressionStats, vs ValuesProvider, confidenceLevel, predictionLevel float64) {
+	band := func(interval func(x, level float64) float64, level float64) {
+		if level <= 0 {
+			return
+		}
+
+		lower := ContinuousSeries{XValues: make([]float64, vs.Len()), YValues: make([]float64, vs.Len())}
+		upper := ContinuousSeries{XValues: make([]float64, vs.Len()), YValues: make([]float64, vs.Len())}
+		for i := 0; i < vs.Len(); i++ {
+			x, y := vs.GetValues(i)
+			width := interval(x, level)
+			lower.XValues[i], lower.YValues[i] = x, y-width
+			upper.XValues[i], upper.YValues[i] = x, y+width
+		}
+
+		ConfidenceBandSeries{Style: bandStyle, Lower: lower, Upper: upper}.Render(r, canvasBox, xrange, yrange, defaults)
+	}
+
+	band(stats.PredictionInterval, predictionLevel)
+	band(stats.ConfidenceInterval, confidenceLevel)
+}
+
+// regressionWindow returns the values of a window of an inner series.
+func regressionWindow(vs ValuesProvider, offset, length int) (xvalues, yvalues []float64) {
+	xvalues = make([]float64, 0, length)
+	yvalues = make([]float64, 0, length)
+	for index := offset; index < offset+length; index++ {
+		x, y := vs.GetValues(index)
+		if !mathutil.IsFinite(x) || !mathutil.IsFinite(y) {
+			continue
+		}
+		xvalues = append(xvalues, x)
+		yvalues = append(yvalues, y)
+	}
+	return
+}
@@ -0,0 +1,71 @@
+package dataset
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/unidoc/unichart/mathutil"
+)
+
+func TestRegressionEquation(t *testing.T) {
+	stats := &mathutil.RegressionStats{
+		Coefficients: []float64{-1, 0, 2.5},
+		RSquared:     0.987,
+	}
+	require.Equal(t, "y = 2.50x^2 - 1.00, R^2 = 0.99", RegressionEquation(stats))
+
+	vf := func(v interface{}) string {
+		return fmt.Sprintf("%.1f", v)
+	}
+	stats.Coefficients = []float64{0.5, -3}
+	require.Equal(t, "y = -3.0x + 0.5, R^2 = 1.0", RegressionEquation(stats, vf))
+}
+
+func TestLinearRegressionSeriesStats(t *testing.T) {
+	lrs := &LinearRegressionSeries{
+		InnerSeries: ContinuousSeries{
+			XValues: []float64{1, 2, 3, 4, 5, 6},
+			YValues: []float64{2.1, 3.9, 6.2, 7.8, 10.1, 0},
+		},
+		ConfidenceLevel: 0.95,
+	}
+
+	stats, err := lrs.Stats()
+	require.NoError(t, err)
+	require.InDelta(t, 1.99, stats.Coefficients[1], 1e-9)
+	require.InDelta(t, 0.99730533, stats.RSquared, 1e-8)
+
+	_, _, ylow, yhigh := lrs.GetErrorBounds(2)
+	require.InDelta(t, 6.02-0.26878643, ylow, 1e-7)
+	require.InDelta(t, 6.02+0.26878643, yhigh, 1e-7)
+
+	lrs.PredictionLevel = 0.95
+	_, _, ylow, yhigh = lrs.GetErrorBounds(2)
+	require.InDelta(t, 6.02-0.65838961, ylow, 1e-7)
+	require.InDelta(t, 6.02+0.65838961, yhigh, 1e-7)
+
+	as, err := RegressionAnnotationSeries(lrs)
+	require.NoError(t, err)
+	require.Len(t, as.Annotations, 1)
+	require.Equal(t, "y = 1.99x + 0.05, R^2 = 1.00", as.Annotations[0].Label)
+}
+
+func TestPolynomialRegressionSeriesStats(t *testing.T) {
+	prs := &PolynomialRegressionSeries{
+		Degree: 2,
+		InnerSeries: ContinuousSeries{
+			XValues: []float64{0, 1, 2, 3, 4, 5},
+			YValues: []float64{1, 6, 17, 34, 57, 0},
+		},
+	}
+
+	stats, err := prs.Stats()
+	require.NoError(t, err)
+	require.InDelta(t, 1.0, stats.RSquared, 1e-9)
+	require.InDelta(t, 3.0, stats.Coefficients[2], 1e-6)
+
+	_, _, ylow, yhigh := prs.GetErrorBounds(1)
+	require.Equal(t, ylow, yhigh)
+}
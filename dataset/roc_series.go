@@ -0,0 +1,137 @@
+package dataset
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/unidoc/unichart/dataset/sequence"
+	"github.com/unidoc/unichart/render"
+)
+
+const (
+	// defaultROCPeriod is the default number of values between the compared
+	// values of the rate of change.
+	defaultROCPeriod = 12
+)
+
+// Interface Assertions.
+var (
+	_ Series                 = (*ROCSeries)(nil)
+	_ FirstValuesProvider    = (*ROCSeries)(nil)
+	_ LastValuesProvider     = (*ROCSeries)(nil)
+	_ ValueFormatterProvider = (*ROCSeries)(nil)
+)
+
+// ROCSeries computes the rate of change of an inner series, which is the
+// relative change between each value and the value Period values before it,
+// formatted as a percentage. The first values are compared to the first
+// value of the series. Changes from zero values are undefined (NaN).
+type ROCSeries struct {
+	Name  string
+	Style render.Style
+	YAxis YAxisType
+
+	Period      int
+	InnerSeries ValuesProvider
+
+	cache []float64
+}
+
+// GetName returns the name of the time series.
+func (roc ROCSeries) GetName() string {
+	return roc.Name
+}
+
+// GetStyle returns the line style.
+func (roc ROCSeries) GetStyle() render.Style {
+	return roc.Style
+}
+
+// GetYAxis returns which YAxis the series draws on.
+func (roc ROCSeries) GetYAxis() YAxisType {
+	return roc.YAxis
+}
+
+// GetPeriod returns the window size.
+func (roc ROCSeries) GetPeriod() int {
+	if roc.Period == 0 {
+		return defaultROCPeriod
+	}
+	return roc.Period
+}
+
+// Len returns the number of elements in the series.
+func (roc ROCSeries) Len() int {
+	return roc.InnerSeries.Len()
+}
+
+// GetValueFormatters returns value formatter defaults for the series.
+func (roc ROCSeries) GetValueFormatters() (x, y ValueFormatter) {
+	x = FloatValueFormatter
+	if typed, isTyped := roc.InnerSeries.(ValueFormatterProvider); isTyped {
+		x, _ = typed.GetValueFormatters()
+	}
+	y = PercentValueFormatter
+	return
+}
+
+// GetValues gets a value at a given index.
+func (roc *ROCSeries) GetValues(index int) (x, y float64) {
+	if roc.InnerSeries == nil {
+		return
+	}
+	if roc.cache == nil {
+		roc.ensureCachedValues()
+	}
+	if index < 0 || index >= len(roc.cache) {
+		return
+	}
+	x, _ = roc.InnerSeries.GetValues(index)
+	y = roc.cache[index]
+	return
+}
+
+// GetFirstValues computes the first rate of change value.
+func (roc *ROCSeries) GetFirstValues() (x, y float64) {
+	return roc.GetValues(0)
+}
+
+// GetLastValues computes the last rate of change value.
+func (roc *ROCSeries) GetLastValues() (x, y float64) {
+	if roc.InnerSeries == nil {
+		return
+	}
+	return roc.GetValues(roc.InnerSeries.Len() - 1)
+}
+
+func (roc *ROCSeries) ensureCachedValues() {
+	values := indicatorValues(roc.InnerSeries)
+	period := roc.GetPeriod()
+
+	roc.cache = make([]float64, len(values))
+	for i, value := range values {
+		previous := values[0]
+		if i >= period {
+			previous = values[i-period]
+		}
+		if previous == 0 {
+			roc.cache[i] = math.NaN()
+			continue
+		}
+		roc.cache[i] = (value - previous) / math.Abs(previous)
+	}
+}
+
+// Render renders the series.
+func (roc *ROCSeries) Render(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, defaults render.Style) {
+	style := roc.Style.InheritFrom(defaults)
+	drawLineSeries(r, canvasBox, xrange, yrange, style, roc)
+}
+
+// Validate validates the series.
+func (roc *ROCSeries) Validate() error {
+	if roc.InnerSeries == nil {
+		return fmt.Errorf("roc series requires InnerSeries to be set")
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package dataset
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestROCSeries(t *testing.T) {
+	roc := &ROCSeries{
+		InnerSeries: ContinuousSeries{
+			XValues: []float64{1, 2, 3, 4, 5},
+			YValues: []float64{1, 2, 3, 0, 1},
+		},
+		Period: 1,
+	}
+	require.Nil(t, roc.Validate())
+
+	for index, value := range []float64{0, 1, 0.5, -1} {
+		_, y := roc.GetValues(index)
+		require.InDelta(t, value, y, 1e-9)
+	}
+	_, y := roc.GetLastValues()
+	require.True(t, math.IsNaN(y))
+
+	_, yf := roc.GetValueFormatters()
+	require.Equal(t, "50.00%", yf(0.5))
+}
+
+func TestROCSeriesEmpty(t *testing.T) {
+	roc := &ROCSeries{InnerSeries: ContinuousSeries{}}
+
+	x, y := roc.GetValues(0)
+	require.Zero(t, x)
+	require.Zero(t, y)
+	require.NotNil(t, roc.cache)
+
+	x, y = roc.GetLastValues()
+	require.Zero(t, x)
+	require.Zero(t, y)
+}
@@ -0,0 +1,374 @@
+package dataset
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/unidoc/unichart/dataset/sequence"
+	"github.com/unidoc/unichart/mathutil"
+	"github.com/unidoc/unichart/render"
+)
+
+const (
+	// defaultRollingPeriod is the default moving average period of rolling series.
+	defaultRollingPeriod = 10
+)
+
+// Interface Assertions.
+var (
+	_ Series                 = (*RollingTimeSeries)(nil)
+	_ FirstValuesProvider    = (*RollingTimeSeries)(nil)
+	_ LastValuesProvider     = (*RollingTimeSeries)(nil)
+	_ ValueFormatterProvider = (*RollingTimeSeries)(nil)
+	_ SnapshotProvider       = (*RollingTimeSeries)(nil)
+)
+
+// RollingTimeSeries is a time series holding a rolling window of values,
+// which is safe for concurrent use. Values are appended in chronological
+// order, usually by producer goroutines, and the oldest values are evicted
+// when the series exceeds its maximum length or time window.
+// Statistics (min, max, average and moving averages) are updated
+// incrementally. Charts draw a snapshot of the series, taken once per
+// render, in order to draw a consistent view of the series while values are
+// being appended.
+type RollingTimeSeries struct {
+	Name  string
+	Style render.Style
+	YAxis YAxisType
+
+	// MaxLen is the maximum number of values kept. Zero means no limit.
+	MaxLen int
+	// Window is the maximum time span of the values kept, relative to the
+	// latest value. Zero means no limit.
+	Window time.Duration
+	// Period is the number of values used by the moving averages.
+	Period int
+
+	mu     sync.RWMutex
+	times  *ValueBuffer
+	values *ValueBuffer
+
+	// appended is the total number of values ever appended, used to
+	// identify values in the min/max queues.
+	appended int
+	minQueue rollingQueue
+	maxQueue rollingQueue
+
+	sum        float64
+	count      int
+	periodSum  float64
+	periodSize int
+	periodN    int
+	ema        float64
+	emaSet     bool
+}
+
+// NewRollingTimeSeries returns a new rolling time series keeping at most
+// `maxLen` values within the specified time window. Zero values disable
+// the corresponding limit.
+func NewRollingTimeSeries(maxLen int, window time.Duration) *RollingTimeSeries {
+	return &RollingTimeSeries{
+		MaxLen: maxLen,
+		Window: window,
+	}
+}
+
+// GetName returns the name of the time series.
+func (rts *RollingTimeSeries) GetName() string {
+	return rts.Name
+}
+
+// GetStyle returns the line style.
+func (rts *RollingTimeSeries) GetStyle() render.Style {
+	return rts.Style
+}
+
+// GetYAxis returns which YAxis the series draws on.
+func (rts *RollingTimeSeries) GetYAxis() YAxisType {
+	return rts.YAxis
+}
+
+// GetPeriod returns the moving averages period.
+func (rts *RollingTimeSeries) GetPeriod() int {
+	if rts.Period <= 0 {
+		return defaultRollingPeriod
+	}
+	return rts.Period
+}
+
+// Append adds a value to the series, evicting the values falling outside
+// of the series limits.
+func (rts *RollingTimeSeries) Append(t time.Time, value float64) {
+	rts.mu.Lock()
+	defer rts.mu.Unlock()
+
+	if rts.times == nil {
+		rts.times = NewValueBuffer()
+		rts.values = NewValueBuffer()
+	}
+	rts.times.Enqueue(float64(t.UnixNano()))
+	rts.values.Enqueue(value)
+
+	id := rts.appended
+	rts.appended++
+
+	if mathutil.IsFinite(value) {
+		rts.sum += value
+		rts.count++
+
+		rts.minQueue.pushMin(id, value)
+		rts.maxQueue.pushMax(id, value)
+
+		if rts.emaSet {
+			sigma := 2.0 / (float64(rts.GetPeriod()) + 1)
+			rts.ema = rts.ema + sigma*(value-rts.ema)
+		} else {
+			rts.ema, rts.emaSet = value, true
+		}
+	}
+
+	// Update the moving average window, which holds the latest values.
+	rts.addToPeriod(value)
+	if rts.periodSize == rts.GetPeriod() {
+		rts.removeFromPeriod(rts.values.GetValue(rts.values.Len() - 1 - rts.periodSize))
+	} else {
+		rts.periodSize++
+	}
+
+	rts.evict()
+}
+
+// Len returns the number of values in the series.
+func (rts *RollingTimeSeries) Len() int {
+	rts.mu.RLock()
+	defer rts.mu.RUnlock()
+	return rts.len()
+}
+
+// GetValues gets the x, y values at a given index. Values evicted since the
+// length of the series was read are missing (NaN); use Snapshot in order to
+// read a consistent view of the series.
+func (rts *RollingTimeSeries) GetValues(index int) (x, y float64) {
+	rts.mu.RLock()
+	defer rts.mu.RUnlock()
+	if index < 0 || index >= rts.len() {
+		return math.NaN(), math.NaN()
+	}
+	return rts.times.GetValue(index), rts.values.GetValue(index)
+}
+
+// GetFirstValues gets the first values.
+func (rts *RollingTimeSeries) GetFirstValues() (x, y float64) {
+	return rts.GetValues(0)
+}
+
+// GetLastValues gets the last values.
+func (rts *RollingTimeSeries) GetLastValues() (x, y float64) {
+	rts.mu.RLock()
+	defer rts.mu.RUnlock()
+	if rts.len() == 0 {
+		return math.NaN(), math.NaN()
+	}
+	return rts.times.PeekBack(), rts.values.PeekBack()
+}
+
+// GetValueFormatters returns value formatter defaults for the series.
+func (rts *RollingTimeSeries) GetValueFormatters() (x, y ValueFormatter) {
+	return TimeValueFormatter, FloatValueFormatter
+}
+
+// Snapshot returns a copy of the current values of the series.
+func (rts *RollingTimeSeries) Snapshot() TimeSeries {
+	rts.mu.RLock()
+	defer rts.mu.RUnlock()
+
+	ts := TimeSeries{
+		Name:    rts.Name,
+		Style:   rts.Style,
+		YAxis:   rts.YAxis,
+		XValues: make([]time.Time, rts.len()),
+		YValues: make([]float64, rts.len()),
+	}
+	for i := range ts.XValues {
+		ts.XValues[i] = time.Unix(0, int64(rts.times.GetValue(i)))
+		ts.YValues[i] = rts.values.GetValue(i)
+	}
+	return ts
+}
+
+// GetSnapshot returns a copy of the current values of the series, which
+// charts draw instead of the series.
+func (rts *RollingTimeSeries) GetSnapshot() Series {
+	return rts.Snapshot()
+}
+
+// Min returns the minimum value of the series.
+func (rts *RollingTimeSeries) Min() float64 {
+	rts.mu.RLock()
+	defer rts.mu.RUnlock()
+	return rts.minQueue.front()
+}
+
+// Max returns the maximum value of the series.
+func (rts *RollingTimeSeries) Max() float64 {
+	rts.mu.RLock()
+	defer rts.mu.RUnlock()
+	return rts.maxQueue.front()
+}
+
+// Average returns the average of the values of the series.
+func (rts *RollingTimeSeries) Average() float64 {
+	rts.mu.RLock()
+	defer rts.mu.RUnlock()
+	if rts.count == 0 {
+		return math.NaN()
+	}
+	return rts.sum / float64(rts.count)
+}
+
+// MovingAverage returns the simple moving average of the latest values,
+// over the series period.
+func (rts *RollingTimeSeries) MovingAverage() float64 {
+	rts.mu.RLock()
+	defer rts.mu.RUnlock()
+	if rts.periodN == 0 {
+		return math.NaN()
+	}
+	return rts.periodSum / float64(rts.periodN)
+}
+
+// ExponentialMovingAverage returns the exponential moving average of all
+// the values appended to the series, using the smoothing factor of an
+// EMASeries with the same period.
+func (rts *RollingTimeSeries) ExponentialMovingAverage() float64 {
+	rts.mu.RLock()
+	defer rts.mu.RUnlock()
+	if !rts.emaSet {
+		return math.NaN()
+	}
+	return rts.ema
+}
+
+// Render renders the series.
+func (rts *RollingTimeSeries) Render(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, defaults render.Style) {
+	ts := rts.Snapshot()
+	drawLineSeries(r, canvasBox, xrange, yrange, ts.Style.InheritFrom(defaults), ts)
+}
+
+// Validate validates the series.
+func (rts *RollingTimeSeries) Validate() error {
+	if rts.MaxLen < 0 || rts.Window < 0 {
+		return fmt.Errorf("rolling time series limits must not be negative")
+	}
+	if rts.Len() == 0 {
+		return fmt.Errorf("rolling time series must have values set")
+	}
+	return nil
+}
+
+func (rts *RollingTimeSeries) len() int {
+	if rts.values == nil {
+		return 0
+	}
+	return rts.values.Len()
+}
+
+// evict removes the values exceeding the series limits.
+func (rts *RollingTimeSeries) evict() {
+	latest := rts.times.PeekBack()
+	for rts.values.Len() > 0 {
+		exceedsLen := rts.MaxLen > 0 && rts.values.Len() > rts.MaxLen
+		exceedsWindow := rts.Window > 0 && latest-rts.times.Peek() > float64(rts.Window)
+		if !exceedsLen && !exceedsWindow {
+			break
+		}
+
+		// The evicted value is part of the moving average window only if
+		// the window covers the whole series.
+		inPeriod := rts.values.Len() <= rts.periodSize
+		rts.times.Dequeue()
+		value := rts.values.Dequeue()
+
+		if inPeriod {
+			rts.removeFromPeriod(value)
+			rts.periodSize--
+		}
+		if mathutil.IsFinite(value) {
+			rts.sum -= value
+			rts.count--
+		}
+
+		oldest := rts.appended - rts.values.Len()
+		rts.minQueue.evict(oldest)
+		rts.maxQueue.evict(oldest)
+	}
+}
+
+func (rts *RollingTimeSeries) addToPeriod(value float64) {
+	if mathutil.IsFinite(value) {
+		rts.periodSum += value
+		rts.periodN++
+	}
+}
+
+func (rts *RollingTimeSeries) removeFromPeriod(value float64) {
+	if mathutil.IsFinite(value) {
+		rts.periodSum -= value
+		rts.periodN--
+	}
+}
+
+// rollingQueue is a monotonic queue used to track the minimum or maximum
+// of a sliding window of values in amortized constant time.
+type rollingQueue struct {
+	ids    []int
+	values []float64
+	head   int
+}
+
+func (q *rollingQueue) pushMin(id int, value float64) {
+	for len(q.values) > q.head && q.values[len(q.values)-1] >= value {
+		q.pop()
+	}
+	q.push(id, value)
+}
+
+func (q *rollingQueue) pushMax(id int, value float64) {
+	for len(q.values) > q.head && q.values[len(q.values)-1] <= value {
+		q.pop()
+	}
+	q.push(id, value)
+}
+
+func (q *rollingQueue) push(id int, value float64) {
+	// Reclaim the space of the removed elements once it dominates.
+	if q.head > 0 && q.head >= len(q.values)/2 {
+		n := copy(q.ids, q.ids[q.head:])
+		copy(q.values, q.values[q.head:])
+		q.ids, q.values = q.ids[:n], q.values[:n]
+		q.head = 0
+	}
+	q.ids = append(q.ids, id)
+	q.values = append(q.values, value)
+}
+
+func (q *rollingQueue) pop() {
+	q.ids = q.ids[:len(q.ids)-1]
+	q.values = q.values[:len(q.values)-1]
+}
+
+// evict removes the elements with an id lower than the specified one.
+func (q *rollingQueue) evict(oldest int) {
+	for q.head < len(q.ids) && q.ids[q.head] < oldest {
+		q.head++
+	}
+}
+
+func (q *rollingQueue) front() float64 {
+	if q.head >= len(q.values) {
+		return math.NaN()
+	}
+	return q.values[q.head]
+}
@@ -0,0 +1,109 @@
+package dataset
+
+import (
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRollingTimeSeriesMaxLen(t *testing.T) {
+	rts := NewRollingTimeSeries(3, 0)
+	rts.Period = 2
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, v := range []float64{5, 1, 4, 2, 3} {
+		rts.Append(start.Add(time.Duration(i)*time.Second), v)
+	}
+
+	require.Equal(t, 3, rts.Len())
+	require.Equal(t, 2.0, rts.Min())
+	require.Equal(t, 4.0, rts.Max())
+	require.Equal(t, 3.0, rts.Average())
+	require.Equal(t, 2.5, rts.MovingAverage())
+
+	ts := rts.Snapshot()
+	require.Equal(t, []float64{4, 2, 3}, ts.YValues)
+	require.Equal(t, start.Add(2*time.Second).Unix(), ts.XValues[0].Unix())
+
+	x, y := rts.GetLastValues()
+	require.Equal(t, float64(start.Add(4*time.Second).UnixNano()), x)
+	require.Equal(t, 3.0, y)
+}
+
+func TestRollingTimeSeriesWindow(t *testing.T) {
+	rts := NewRollingTimeSeries(0, 2*time.Second)
+	rts.Period = 10
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	rts.Append(start, 10)
+	rts.Append(start.Add(time.Second), math.NaN())
+	rts.Append(start.Add(2*time.Second), 2)
+	require.Equal(t, 3, rts.Len())
+	require.Equal(t, 10.0, rts.Max())
+	require.Equal(t, 6.0, rts.MovingAverage())
+
+	rts.Append(start.Add(3*time.Second), 4)
+	require.Equal(t, 3, rts.Len())
+	require.Equal(t, 2.0, rts.Min())
+	require.Equal(t, 4.0, rts.Max())
+	require.Equal(t, 3.0, rts.Average())
+	require.Equal(t, 3.0, rts.MovingAverage())
+}
+
+func TestRollingTimeSeriesEMA(t *testing.T) {
+	rts := NewRollingTimeSeries(0, 0)
+	require.True(t, math.IsNaN(rts.ExponentialMovingAverage()))
+	require.Error(t, rts.Validate())
+
+	rts.Period = 3
+	rts.Append(time.Now(), 2)
+	rts.Append(time.Now(), 4)
+	require.Equal(t, 3.0, rts.ExponentialMovingAverage())
+	require.NoError(t, rts.Validate())
+}
+
+func TestRollingTimeSeriesConcurrentAppend(t *testing.T) {
+	rts := NewRollingTimeSeries(100, 0)
+
+	var wg sync.WaitGroup
+	for p := 0; p < 4; p++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				rts.Append(time.Now(), float64(i))
+				_ = rts.Snapshot()
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, 100, rts.Len())
+	require.Equal(t, 999.0, rts.Max())
+}
+
+func TestRollingTimeSeriesMissingValues(t *testing.T) {
+	rts := NewRollingTimeSeries(2, 0)
+
+	x, y := rts.GetLastValues()
+	require.True(t, math.IsNaN(x))
+	require.True(t, math.IsNaN(y))
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		rts.Append(start.Add(time.Duration(i)*time.Second), float64(i))
+	}
+
+	// Values evicted since the length was read are missing, instead of
+	// being read as zeros.
+	x, y = rts.GetValues(2)
+	require.True(t, math.IsNaN(x))
+	require.True(t, math.IsNaN(y))
+
+	ts, ok := rts.GetSnapshot().(TimeSeries)
+	require.True(t, ok)
+	require.Equal(t, []float64{1, 2}, ts.YValues)
+}
@@ -0,0 +1,143 @@
+package dataset
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/unidoc/unichart/dataset/sequence"
+	"github.com/unidoc/unichart/render"
+)
+
+const (
+	// defaultRSIPeriod is the default number of values used to average the
+	// gains and losses of the RSI.
+	defaultRSIPeriod = 14
+)
+
+// Interface Assertions.
+var (
+	_ Series              = (*RSISeries)(nil)
+	_ FirstValuesProvider = (*RSISeries)(nil)
+	_ LastValuesProvider  = (*RSISeries)(nil)
+)
+
+// RSISeries computes the relative strength index of an inner series.
+// The RSI is a momentum oscillator ranging from 0 to 100, computed from the
+// ratio of the average gains and losses of the values, smoothed using
+// Wilder's method.
+type RSISeries struct {
+	Name  string
+	Style render.Style
+	YAxis YAxisType
+
+	Period      int
+	InnerSeries ValuesProvider
+
+	cache []float64
+}
+
+// GetName returns the name of the time series.
+func (rsi RSISeries) GetName() string {
+	return rsi.Name
+}
+
+// GetStyle returns the line style.
+func (rsi RSISeries) GetStyle() render.Style {
+	return rsi.Style
+}
+
+// GetYAxis returns which YAxis the series draws on.
+func (rsi RSISeries) GetYAxis() YAxisType {
+	return rsi.YAxis
+}
+
+// GetPeriod returns the window size.
+func (rsi RSISeries) GetPeriod() int {
+	if rsi.Period == 0 {
+		return defaultRSIPeriod
+	}
+	return rsi.Period
+}
+
+// Len returns the number of elements in the series.
+func (rsi RSISeries) Len() int {
+	return rsi.InnerSeries.Len()
+}
+
+// GetValues gets a value at a given index.
+func (rsi *RSISeries) GetValues(index int) (x, y float64) {
+	if rsi.InnerSeries == nil {
+		return
+	}
+	if rsi.cache == nil {
+		rsi.ensureCachedValues()
+	}
+	if index < 0 || index >= len(rsi.cache) {
+		return
+	}
+	x, _ = rsi.InnerSeries.GetValues(index)
+	y = rsi.cache[index]
+	return
+}
+
+// GetFirstValues computes the first RSI value.
+func (rsi *RSISeries) GetFirstValues() (x, y float64) {
+	return rsi.GetValues(0)
+}
+
+// GetLastValues computes the last RSI value.
+func (rsi *RSISeries) GetLastValues() (x, y float64) {
+	if rsi.InnerSeries == nil {
+		return
+	}
+	return rsi.GetValues(rsi.InnerSeries.Len() - 1)
+}
+
+func (rsi *RSISeries) ensureCachedValues() {
+	values := indicatorValues(rsi.InnerSeries)
+	rsi.cache = make([]float64, len(values))
+	if len(values) == 0 {
+		return
+	}
+
+	// The averages are seeded from the first changes of the values, so the
+	// change at index i affects the value at index i+1.
+	gains := make([]float64, len(values)-1)
+	losses := make([]float64, len(values)-1)
+	for i := range gains {
+		change := values[i+1] - values[i]
+		gains[i] = math.Max(change, 0)
+		losses[i] = math.Max(-change, 0)
+	}
+
+	period := rsi.GetPeriod()
+	averageGains := wilderAverage(gains, period)
+	averageLosses := wilderAverage(losses, period)
+
+	rsi.cache[0] = 50
+	for i := range averageGains {
+		switch {
+		case averageLosses[i] == 0 && averageGains[i] == 0:
+			rsi.cache[i+1] = 50
+		case averageLosses[i] == 0:
+			rsi.cache[i+1] = 100
+		default:
+			rs := averageGains[i] / averageLosses[i]
+			rsi.cache[i+1] = 100 - 100/(1+rs)
+		}
+	}
+}
+
+// Render renders the series.
+func (rsi *RSISeries) Render(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, defaults render.Style) {
+	style := rsi.Style.InheritFrom(defaults)
+	drawLineSeries(r, canvasBox, xrange, yrange, style, rsi)
+}
+
+// Validate validates the series.
+func (rsi *RSISeries) Validate() error {
+	if rsi.InnerSeries == nil {
+		return fmt.Errorf("rsi series requires InnerSeries to be set")
+	}
+	return nil
+}
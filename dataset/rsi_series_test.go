@@ -0,0 +1,44 @@
+package dataset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRSISeries(t *testing.T) {
+	rsi := &RSISeries{
+		InnerSeries: ContinuousSeries{
+			XValues: []float64{1, 2, 3, 4, 5},
+			YValues: []float64{1, 2, 3, 2, 3},
+		},
+		Period: 2,
+	}
+	require.Nil(t, rsi.Validate())
+
+	// Changes: +1, +1, -1, +1. The averages are seeded from the first two.
+	expected := []float64{50, 100, 100, 50, 75}
+	for index, value := range expected {
+		x, y := rsi.GetValues(index)
+		require.Equal(t, float64(index+1), x)
+		require.InDelta(t, value, y, 1e-9)
+	}
+
+	x, y := rsi.GetLastValues()
+	require.Equal(t, 5.0, x)
+	require.InDelta(t, expected[4], y, 1e-9)
+	require.Equal(t, defaultRSIPeriod, RSISeries{}.GetPeriod())
+}
+
+func TestRSISeriesEmpty(t *testing.T) {
+	rsi := &RSISeries{InnerSeries: ContinuousSeries{}}
+
+	x, y := rsi.GetValues(0)
+	require.Zero(t, x)
+	require.Zero(t, y)
+	require.NotNil(t, rsi.cache)
+
+	x, y = rsi.GetLastValues()
+	require.Zero(t, x)
+	require.Zero(t, y)
+}
@@ -68,6 +68,24 @@ func TestWrapperuenceVariance(t *testing.T) {
 	require.Equal(t, 2.0, values.Variance())
 }
 
+func TestWrapperMedian(t *testing.T) {
+	require.Equal(t, 3.0, Wrapper{NewArraySequence(5, 1, 3, 2, 4)}.Median())
+	require.Equal(t, 2.5, Wrapper{NewArraySequence(4, 1, 3, 2)}.Median())
+	require.Equal(t, 7.0, Wrapper{NewArraySequence(7)}.Median())
+}
+
+func TestWrapperPercentile(t *testing.T) {
+	values := Wrapper{NewArraySequence(4, 1, 3, 2)}
+	require.Equal(t, 1.5, values.Percentile(0.25))
+	require.Equal(t, 3.5, values.Percentile(0.75))
+	require.Equal(t, 1.0, values.Percentile(0))
+	require.Equal(t, 4.0, values.Percentile(1))
+
+	// Small sequences do not index past their values.
+	require.Equal(t, 2.0, Wrapper{NewArraySequence(1, 2)}.Percentile(0.75))
+	require.Equal(t, 5.0, Wrapper{NewArraySequence(5)}.Percentile(0.75))
+}
+
 func TestSequenceNormalize(t *testing.T) {
 	normalized := NewArrayWrapper(1, 2, 3, 4, 5).Normalize().Values()
 
@@ -0,0 +1,89 @@
+package sequence
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// transformedRangeIterations is the number of bisection steps used to
+	// find the base value a transformed value originates from.
+	transformedRangeIterations = 64
+)
+
+// Interface Assertions.
+var (
+	_ Range = (*TransformedRange)(nil)
+)
+
+// TransformedRange is a range derived from a base range through a monotonic
+// transform, such as a unit conversion. Its bounds and domain follow the
+// base range, so setting them has no effect.
+type TransformedRange struct {
+	Base      Range
+	Transform func(v float64) float64
+}
+
+// IsDescending returns if the base range is descending.
+func (r TransformedRange) IsDescending() bool {
+	return r.Base.IsDescending()
+}
+
+// IsZero returns if the base range has been set or not.
+func (r TransformedRange) IsZero() bool {
+	return r.Base.IsZero()
+}
+
+// GetMin returns the smallest transformed bound of the base range.
+func (r TransformedRange) GetMin() float64 {
+	return math.Min(r.Transform(r.Base.GetMin()), r.Transform(r.Base.GetMax()))
+}
+
+// SetMin has no effect, as the bounds are derived from the base range.
+func (r *TransformedRange) SetMin(min float64) {}
+
+// GetMax returns the largest transformed bound of the base range.
+func (r TransformedRange) GetMax() float64 {
+	return math.Max(r.Transform(r.Base.GetMin()), r.Transform(r.Base.GetMax()))
+}
+
+// SetMax has no effect, as the bounds are derived from the base range.
+func (r *TransformedRange) SetMax(max float64) {}
+
+// GetDelta returns the difference between the min and max value.
+func (r TransformedRange) GetDelta() float64 {
+	return r.GetMax() - r.GetMin()
+}
+
+// GetDomain returns the domain of the base range.
+func (r TransformedRange) GetDomain() int {
+	return r.Base.GetDomain()
+}
+
+// SetDomain has no effect, as the domain is the one of the base range.
+func (r *TransformedRange) SetDomain(domain int) {}
+
+// String returns a simple string for the TransformedRange.
+func (r TransformedRange) String() string {
+	if r.GetDelta() == 0 {
+		return "TransformedRange [empty]"
+	}
+	return fmt.Sprintf("TransformedRange [%.2f,%.2f] => %d", r.GetMin(), r.GetMax(), r.GetDomain())
+}
+
+// Translate maps a transformed value into the domain, at the position of
+// the base value it is transformed from. Values outside of the range are
+// clamped to its bounds.
+func (r TransformedRange) Translate(value float64) int {
+	lo, hi := r.Base.GetMin(), r.Base.GetMax()
+	increasing := r.Transform(hi) >= r.Transform(lo)
+	for i := 0; i < transformedRangeIterations; i++ {
+		mid := (lo + hi) / 2
+		if (r.Transform(mid) < value) == increasing {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return r.Base.Translate((lo + hi) / 2)
+}
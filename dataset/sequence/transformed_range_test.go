@@ -0,0 +1,38 @@
+package sequence
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransformedRange(t *testing.T) {
+	base := &ContinuousRange{Min: 0, Max: 100, Domain: 500}
+	celsiusToFahrenheit := &TransformedRange{
+		Base:      base,
+		Transform: func(v float64) float64 { return v*9/5 + 32 },
+	}
+	require.Equal(t, 32.0, celsiusToFahrenheit.GetMin())
+	require.Equal(t, 212.0, celsiusToFahrenheit.GetMax())
+	require.Equal(t, 500, celsiusToFahrenheit.GetDomain())
+	require.Equal(t, base.Translate(50), celsiusToFahrenheit.Translate(122))
+
+	// Bounds and domain follow the base range.
+	celsiusToFahrenheit.SetMin(-40)
+	celsiusToFahrenheit.SetDomain(100)
+	require.Equal(t, 32.0, celsiusToFahrenheit.GetMin())
+	base.SetDomain(1000)
+	require.Equal(t, 1000, celsiusToFahrenheit.Translate(212))
+
+	// Decreasing transforms are supported.
+	base = &ContinuousRange{Min: 1, Max: 10, Domain: 900}
+	frequencyToPeriod := TransformedRange{
+		Base:      base,
+		Transform: func(v float64) float64 { return 1 / v },
+	}
+	require.Equal(t, 0.1, frequencyToPeriod.GetMin())
+	require.Equal(t, 1.0, frequencyToPeriod.GetMax())
+	require.Equal(t, 900, frequencyToPeriod.Translate(0.1))
+	require.Equal(t, base.Translate(2), frequencyToPeriod.Translate(0.5))
+	require.Equal(t, 0, frequencyToPeriod.Translate(5))
+}
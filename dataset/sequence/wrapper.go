@@ -180,10 +180,10 @@ func (w Wrapper) Median() (median float64) {
 	sorted := w.Sort()
 	if l%2 == 0 {
 		v0 := sorted.GetValue(l/2 - 1)
-		v1 := sorted.GetValue(l/2 + 1)
+		v1 := sorted.GetValue(l / 2)
 		median = (v0 + v1) / 2
 	} else {
-		median = float64(sorted.GetValue(l << 1))
+		median = sorted.GetValue(l / 2)
 	}
 
 	return
@@ -251,12 +251,12 @@ func (w Wrapper) Percentile(percent float64) (percentile float64) {
 	index := percent * float64(l)
 	if index == float64(int64(index)) {
 		i := int(mathutil.RoundPlaces(index, 0))
-		ci := sorted.GetValue(i - 1)
-		c := sorted.GetValue(i)
+		ci := sorted.GetValue(mathutil.MaxInt(i-1, 0))
+		c := sorted.GetValue(mathutil.MinInt(i, l-1))
 		percentile = (ci + c) / 2.0
 	} else {
 		i := int(mathutil.RoundPlaces(index, 0))
-		percentile = sorted.GetValue(i)
+		percentile = sorted.GetValue(mathutil.MinInt(i, l-1))
 	}
 
 	return percentile
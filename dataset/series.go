@@ -16,6 +16,17 @@ const (
 	YAxisSecondary YAxisType = 1
 )
 
+// XAxisType is a type of x-axis. It can either be primary or secondary.
+type XAxisType int
+
+const (
+	// XAxisPrimary is the primary axis, drawn under the canvas.
+	XAxisPrimary XAxisType = 0
+
+	// XAxisSecondary is the secondary axis, drawn above the canvas.
+	XAxisSecondary XAxisType = 1
+)
+
 // Series is an alias to Renderable.
 type Series interface {
 	GetName() string
@@ -24,3 +35,23 @@ type Series interface {
 	Validate() error
 	Render(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, s render.Style)
 }
+
+// SnapshotProvider is a series whose values can change while it is drawn,
+// such as a series appended to concurrently. Charts draw a snapshot of its
+// values, taken once per render.
+type SnapshotProvider interface {
+	GetSnapshot() Series
+}
+
+// XAxisProvider is a series which can draw on the secondary x-axis. Series
+// which are not XAxisProviders draw on the primary x-axis.
+type XAxisProvider interface {
+	GetXAxis() XAxisType
+}
+
+// MeasurableSeries is a series which can draw outside of the canvas box,
+// like annotations. The chart canvas is adjusted to fit the measured bounds.
+type MeasurableSeries interface {
+	Series
+	Measure(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, s render.Style) render.Box
+}
@@ -0,0 +1,149 @@
+package dataset
+
+import (
+	"fmt"
+
+	"github.com/unidoc/unichart/dataset/sequence"
+	"github.com/unidoc/unichart/render"
+)
+
+const (
+	// defaultStochasticPeriod is the default number of values used to find
+	// the lowest low and the highest high of the stochastic oscillator.
+	defaultStochasticPeriod = 14
+
+	// defaultStochasticSmoothing is the default number of values used to
+	// smooth the stochastic oscillator.
+	defaultStochasticSmoothing = 1
+)
+
+// Interface Assertions.
+var (
+	_ Series              = (*StochasticSeries)(nil)
+	_ FirstValuesProvider = (*StochasticSeries)(nil)
+	_ LastValuesProvider  = (*StochasticSeries)(nil)
+)
+
+// StochasticSeries computes the %K line of the stochastic oscillator, which
+// locates the close values (InnerSeries) within the range of the high and
+// low values of the period, from 0 to 100. The High and Low values default
+// to the close values. A Smoothing of 3 produces the slow stochastic. The %D
+// signal line can be drawn using an SMASeries over the stochastic series.
+type StochasticSeries struct {
+	Name  string
+	Style render.Style
+	YAxis YAxisType
+
+	Period      int
+	Smoothing   int
+	High        ValuesProvider
+	Low         ValuesProvider
+	InnerSeries ValuesProvider
+
+	cache []float64
+}
+
+// GetName returns the name of the time series.
+func (ss StochasticSeries) GetName() string {
+	return ss.Name
+}
+
+// GetStyle returns the line style.
+func (ss StochasticSeries) GetStyle() render.Style {
+	return ss.Style
+}
+
+// GetYAxis returns which YAxis the series draws on.
+func (ss StochasticSeries) GetYAxis() YAxisType {
+	return ss.YAxis
+}
+
+// GetPeriod returns the window size.
+func (ss StochasticSeries) GetPeriod() int {
+	if ss.Period == 0 {
+		return defaultStochasticPeriod
+	}
+	return ss.Period
+}
+
+// GetSmoothing returns the number of values averaged by the %K line.
+func (ss StochasticSeries) GetSmoothing() int {
+	if ss.Smoothing == 0 {
+		return defaultStochasticSmoothing
+	}
+	return ss.Smoothing
+}
+
+// Len returns the number of elements in the series.
+func (ss StochasticSeries) Len() int {
+	return ss.InnerSeries.Len()
+}
+
+// GetValues gets a value at a given index.
+func (ss *StochasticSeries) GetValues(index int) (x, y float64) {
+	if ss.InnerSeries == nil {
+		return
+	}
+	if ss.cache == nil {
+		ss.ensureCachedValues()
+	}
+	if index < 0 || index >= len(ss.cache) {
+		return
+	}
+	x, _ = ss.InnerSeries.GetValues(index)
+	y = ss.cache[index]
+	return
+}
+
+// GetFirstValues computes the first stochastic value.
+func (ss *StochasticSeries) GetFirstValues() (x, y float64) {
+	return ss.GetValues(0)
+}
+
+// GetLastValues computes the last stochastic value.
+func (ss *StochasticSeries) GetLastValues() (x, y float64) {
+	if ss.InnerSeries == nil {
+		return
+	}
+	return ss.GetValues(ss.InnerSeries.Len() - 1)
+}
+
+func (ss *StochasticSeries) ensureCachedValues() {
+	highs, lows, closes := highLowCloseValues(ss.High, ss.Low, ss.InnerSeries)
+
+	period := ss.GetPeriod()
+	values := make([]float64, len(closes))
+	for i := range closes {
+		lowest, highest := windowExtremes(highs, lows, i, period)
+		if highest == lowest {
+			values[i] = 50
+			continue
+		}
+		values[i] = 100 * (closes[i] - lowest) / (highest - lowest)
+	}
+
+	smoothing := ss.GetSmoothing()
+	ss.cache = make([]float64, len(values))
+	for i := range values {
+		var accum, count float64
+		for j := i; j >= 0 && j > i-smoothing; j-- {
+			accum += values[j]
+			count++
+		}
+		ss.cache[i] = accum / count
+	}
+}
+
+// Render renders the series.
+func (ss *StochasticSeries) Render(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, defaults render.Style) {
+	style := ss.Style.InheritFrom(defaults)
+	drawLineSeries(r, canvasBox, xrange, yrange, style, ss)
+}
+
+// Validate validates the series.
+func (ss *StochasticSeries) Validate() error {
+	if ss.InnerSeries == nil {
+		return fmt.Errorf("stochastic series requires InnerSeries to be set")
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package dataset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStochasticSeries(t *testing.T) {
+	xvalues := []float64{1, 2, 3}
+	ss := &StochasticSeries{
+		High:        ContinuousSeries{XValues: xvalues, YValues: []float64{2, 3, 4}},
+		Low:         ContinuousSeries{XValues: xvalues, YValues: []float64{1, 1, 2}},
+		InnerSeries: ContinuousSeries{XValues: xvalues, YValues: []float64{1.5, 2.5, 3}},
+		Period:      3,
+	}
+	require.Nil(t, ss.Validate())
+
+	for index, value := range []float64{50, 75, 200.0 / 3} {
+		_, y := ss.GetValues(index)
+		require.InDelta(t, value, y, 1e-9)
+	}
+
+	ss.Smoothing = 2
+	ss.cache = nil
+	for index, value := range []float64{50, 62.5, (75 + 200.0/3) / 2} {
+		_, y := ss.GetValues(index)
+		require.InDelta(t, value, y, 1e-9)
+	}
+
+	// Without high and low values, flat close values are centered.
+	flat := &StochasticSeries{InnerSeries: ContinuousSeries{XValues: xvalues, YValues: []float64{1, 1, 1}}}
+	_, y := flat.GetLastValues()
+	require.Equal(t, 50.0, y)
+}
+
+func TestStochasticSeriesEmpty(t *testing.T) {
+	ss := &StochasticSeries{InnerSeries: ContinuousSeries{}}
+
+	x, y := ss.GetValues(0)
+	require.Zero(t, x)
+	require.Zero(t, y)
+	require.NotNil(t, ss.cache)
+
+	x, y = ss.GetLastValues()
+	require.Zero(t, x)
+	require.Zero(t, y)
+}
@@ -0,0 +1,547 @@
+package dataset
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HeaderMode specifies how the first row of a table is interpreted.
+type HeaderMode int
+
+const (
+	// HeaderAuto treats the first row as a header if it contains labels
+	// above columns of numeric or time values.
+	HeaderAuto HeaderMode = iota
+	// HeaderPresent always treats the first row as a header.
+	HeaderPresent
+	// HeaderAbsent never treats the first row as a header.
+	HeaderAbsent
+)
+
+// MissingValuePolicy specifies how missing values are handled when
+// converting table columns to values.
+type MissingValuePolicy int
+
+const (
+	// MissingValueSkip skips the rows containing missing values.
+	MissingValueSkip MissingValuePolicy = iota
+	// MissingValueZero replaces missing values with zero.
+	MissingValueZero
+	// MissingValueNaN replaces missing values with NaN.
+	MissingValueNaN
+	// MissingValueError returns an error when a missing value is found.
+	MissingValueError
+)
+
+var (
+	// ErrMissingValue is returned when a table cell contains a missing value.
+	ErrMissingValue = errors.New("missing value")
+
+	// DefaultTableTimeLayouts are the layouts used to parse table times,
+	// if none are specified.
+	DefaultTableTimeLayouts = []string{
+		time.RFC3339Nano,
+		"2006-01-02T15:04:05",
+		"2006-01-02 15:04:05",
+		"2006-01-02 15:04",
+		"2006-01-02",
+		"2006/01/02",
+		"01/02/2006",
+	}
+
+	// DefaultTableMissingValues are the cell values treated as missing,
+	// if none are specified. Blank cells are always treated as missing.
+	DefaultTableMissingValues = []string{"NA", "N/A", "NaN", "null", "-"}
+)
+
+// TableOptions are the options used to load and convert tables.
+type TableOptions struct {
+	// Delimiter is the field delimiter. Defaults to a comma.
+	Delimiter rune
+	// Comment is the character marking comment lines, if not zero.
+	Comment rune
+	// Header specifies how the first row is interpreted.
+	Header HeaderMode
+
+	// TimeLayouts are the layouts used to parse times, in order.
+	TimeLayouts []string
+	// Location is the time zone of times without offset. Defaults to UTC.
+	Location *time.Location
+
+	// ThousandsSeparator is removed from numbers, if not zero.
+	ThousandsSeparator rune
+	// DecimalSeparator is the decimal separator. Defaults to a dot.
+	DecimalSeparator rune
+
+	// MissingValues are the cell values treated as missing.
+	MissingValues []string
+	// MissingValuePolicy specifies how missing values are handled.
+	MissingValuePolicy MissingValuePolicy
+}
+
+// GetDelimiter returns the field delimiter.
+func (o TableOptions) GetDelimiter() rune {
+	if o.Delimiter == 0 {
+		return ','
+	}
+	return o.Delimiter
+}
+
+// GetTimeLayouts returns the time layouts.
+func (o TableOptions) GetTimeLayouts() []string {
+	if len(o.TimeLayouts) == 0 {
+		return DefaultTableTimeLayouts
+	}
+	return o.TimeLayouts
+}
+
+// GetLocation returns the time zone of times without offset.
+func (o TableOptions) GetLocation() *time.Location {
+	if o.Location == nil {
+		return time.UTC
+	}
+	return o.Location
+}
+
+// GetDecimalSeparator returns the decimal separator.
+func (o TableOptions) GetDecimalSeparator() rune {
+	if o.DecimalSeparator == 0 {
+		return '.'
+	}
+	return o.DecimalSeparator
+}
+
+// GetMissingValues returns the cell values treated as missing.
+func (o TableOptions) GetMissingValues() []string {
+	if o.MissingValues == nil {
+		return DefaultTableMissingValues
+	}
+	return o.MissingValues
+}
+
+// Table is tabular data, typically loaded from CSV or TSV files, which
+// can be converted to series and chart values.
+type Table struct {
+	Header  []string
+	Rows    [][]string
+	Options TableOptions
+}
+
+// ReadTable reads a delimited table from the given reader.
+func ReadTable(r io.Reader, opts TableOptions) (*Table, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = opts.GetDelimiter()
+	reader.Comment = opts.Comment
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Table{Options: opts}
+	if len(records) == 0 {
+		return t, nil
+	}
+
+	if t.isHeader(records) {
+		t.Header = trimCells(records[0])
+		records = records[1:]
+	}
+	t.Rows = records
+	return t, nil
+}
+
+// ReadCSV reads a comma separated table from the given reader.
+func ReadCSV(r io.Reader, opts TableOptions) (*Table, error) {
+	if opts.Delimiter == 0 {
+		opts.Delimiter = ','
+	}
+	return ReadTable(r, opts)
+}
+
+// ReadTSV reads a tab separated table from the given reader.
+func ReadTSV(r io.Reader, opts TableOptions) (*Table, error) {
+	opts.Delimiter = '\t'
+	return ReadTable(r, opts)
+}
+
+// Len returns the number of rows.
+func (t *Table) Len() int {
+	return len(t.Rows)
+}
+
+// Width returns the number of columns.
+func (t *Table) Width() int {
+	width := len(t.Header)
+	for _, row := range t.Rows {
+		if len(row) > width {
+			width = len(row)
+		}
+	}
+	return width
+}
+
+// Column returns the index of a column, referenced either by name or by
+// its zero based index. Names are matched case insensitively.
+func (t *Table) Column(ref string) (int, error) {
+	ref = strings.TrimSpace(ref)
+	for index, name := range t.Header {
+		if strings.EqualFold(name, ref) {
+			return index, nil
+		}
+	}
+
+	if index, err := strconv.Atoi(ref); err == nil && index >= 0 && index < t.Width() {
+		return index, nil
+	}
+	return -1, fmt.Errorf("column not found: %s", ref)
+}
+
+// ColumnName returns the name of the column with the given index.
+func (t *Table) ColumnName(index int) string {
+	if index >= 0 && index < len(t.Header) {
+		return t.Header[index]
+	}
+	return ""
+}
+
+// Cell returns the trimmed contents of a cell.
+func (t *Table) Cell(row, col int) string {
+	if row < 0 || row >= len(t.Rows) || col < 0 || col >= len(t.Rows[row]) {
+		return ""
+	}
+	return strings.TrimSpace(t.Rows[row][col])
+}
+
+// IsMissing returns true if the specified cell value is missing.
+func (t *Table) IsMissing(s string) bool {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return true
+	}
+	for _, mv := range t.Options.GetMissingValues() {
+		if s == mv {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseFloat parses a number, using the separators of the table options.
+// A trailing percent sign is ignored, so "12%" is parsed as 12.
+// ErrMissingValue is returned for missing values.
+func (t *Table) ParseFloat(s string) (float64, error) {
+	if t.IsMissing(s) {
+		return 0, ErrMissingValue
+	}
+
+	s = strings.TrimSuffix(strings.TrimSpace(s), "%")
+	if sep := t.Options.ThousandsSeparator; sep != 0 {
+		s = strings.ReplaceAll(s, string(sep), "")
+	}
+	if sep := t.Options.GetDecimalSeparator(); sep != '.' {
+		s = strings.ReplaceAll(s, string(sep), ".")
+	}
+
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number: %s", s)
+	}
+	return v, nil
+}
+
+// ParseTime parses a time using the layouts and location of the table
+// options. ErrMissingValue is returned for missing values.
+func (t *Table) ParseTime(s string) (time.Time, error) {
+	if t.IsMissing(s) {
+		return time.Time{}, ErrMissingValue
+	}
+
+	s = strings.TrimSpace(s)
+	for _, layout := range t.Options.GetTimeLayouts() {
+		if v, err := time.ParseInLocation(layout, s, t.Options.GetLocation()); err == nil {
+			return v, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time: %s", s)
+}
+
+// Floats returns the values of a column. Missing values are handled
+// according to the missing value policy of the table options.
+func (t *Table) Floats(ref string) ([]float64, error) {
+	col, err := t.Column(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	var values []float64
+	for row := range t.Rows {
+		v, ok, err := t.float(row, col)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			values = append(values, v)
+		}
+	}
+	return values, nil
+}
+
+// ContinuousSeries returns a series using the specified columns as X and
+// Y values. The series is named after the Y column.
+func (t *Table) ContinuousSeries(xref, yref string) (ContinuousSeries, error) {
+	xcol, ycol, err := t.columns(xref, yref)
+	if err != nil {
+		return ContinuousSeries{}, err
+	}
+	return t.ContinuousSeriesAt(xcol, ycol)
+}
+
+// ContinuousSeriesAt returns a series using the columns with the specified
+// indices as X and Y values. The series is named after the Y column.
+func (t *Table) ContinuousSeriesAt(xcol, ycol int) (ContinuousSeries, error) {
+	cs := ContinuousSeries{Name: t.ColumnName(ycol)}
+	for row := range t.Rows {
+		x, err := t.ParseFloat(t.Cell(row, xcol))
+		if err == ErrMissingValue {
+			if t.Options.MissingValuePolicy == MissingValueError {
+				return cs, t.cellError(row, xcol, err)
+			}
+			continue
+		}
+		if err != nil {
+			return cs, t.cellError(row, xcol, err)
+		}
+
+		y, ok, err := t.float(row, ycol)
+		if err != nil {
+			return cs, err
+		}
+		if ok {
+			cs.XValues = append(cs.XValues, x)
+			cs.YValues = append(cs.YValues, y)
+		}
+	}
+	return cs, nil
+}
+
+// TimeSeries returns a series using the specified columns as time and Y
+// values. The series is named after the Y column.
+func (t *Table) TimeSeries(xref, yref string) (TimeSeries, error) {
+	xcol, ycol, err := t.columns(xref, yref)
+	if err != nil {
+		return TimeSeries{}, err
+	}
+	return t.TimeSeriesAt(xcol, ycol)
+}
+
+// TimeSeriesAt returns a series using the columns with the specified
+// indices as time and Y values. The series is named after the Y column.
+func (t *Table) TimeSeriesAt(xcol, ycol int) (TimeSeries, error) {
+	ts := TimeSeries{Name: t.ColumnName(ycol)}
+	for row := range t.Rows {
+		x, err := t.ParseTime(t.Cell(row, xcol))
+		if err == ErrMissingValue {
+			if t.Options.MissingValuePolicy == MissingValueError {
+				return ts, t.cellError(row, xcol, err)
+			}
+			continue
+		}
+		if err != nil {
+			return ts, t.cellError(row, xcol, err)
+		}
+
+		y, ok, err := t.float(row, ycol)
+		if err != nil {
+			return ts, err
+		}
+		if ok {
+			ts.XValues = append(ts.XValues, x)
+			ts.YValues = append(ts.YValues, y)
+		}
+	}
+	return ts, nil
+}
+
+// Values returns the values of a column, labeled using the values of
+// another column. The result can be used by bar, pie and donut charts.
+func (t *Table) Values(labelRef, valueRef string) ([]Value, error) {
+	lcol, vcol, err := t.columns(labelRef, valueRef)
+	if err != nil {
+		return nil, err
+	}
+	return t.ValuesAt(lcol, vcol)
+}
+
+// ValuesAt returns the values of the column with the specified index,
+// labeled using the values of another column.
+func (t *Table) ValuesAt(lcol, vcol int) ([]Value, error) {
+	var values []Value
+	for row := range t.Rows {
+		v, ok, err := t.float(row, vcol)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			values = append(values, Value{Label: t.Cell(row, lcol), Value: v})
+		}
+	}
+	return values, nil
+}
+
+// StackedValues returns a group of values for each row of the table,
+// along with the row labels and the column indices of the values. As rows
+// may skip missing values, the position of a value within its group does
+// not identify its column. The values of each group are labeled with the
+// names of their columns. If no value columns are specified, all the
+// columns except the label one are used.
+func (t *Table) StackedValues(labelRef string, valueRefs ...string) ([]string, [][]Value, [][]int, error) {
+	lcol, err := t.Column(labelRef)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var vcols []int
+	for _, ref := range valueRefs {
+		col, err := t.Column(ref)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		vcols = append(vcols, col)
+	}
+	return t.StackedValuesAt(lcol, vcols...)
+}
+
+// StackedValuesAt returns a group of values for each row of the table,
+// using the columns with the specified indices. If no value columns are
+// specified, all the columns except the label one are used.
+func (t *Table) StackedValuesAt(lcol int, vcols ...int) ([]string, [][]Value, [][]int, error) {
+	if len(vcols) == 0 {
+		for col := 0; col < t.Width(); col++ {
+			if col != lcol {
+				vcols = append(vcols, col)
+			}
+		}
+	}
+
+	var labels []string
+	var groups [][]Value
+	var columns [][]int
+	for row := range t.Rows {
+		var group []Value
+		var groupColumns []int
+		for _, col := range vcols {
+			v, ok, err := t.float(row, col)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			if ok {
+				group = append(group, Value{Label: t.ColumnName(col), Value: v})
+				groupColumns = append(groupColumns, col)
+			}
+		}
+		if len(group) > 0 {
+			labels = append(labels, t.Cell(row, lcol))
+			groups = append(groups, group)
+			columns = append(columns, groupColumns)
+		}
+	}
+	return labels, groups, columns, nil
+}
+
+// float parses the number of the specified cell and applies the missing
+// value policy. The returned flag is false if the row should be skipped.
+func (t *Table) float(row, col int) (float64, bool, error) {
+	v, err := t.ParseFloat(t.Cell(row, col))
+	if err == nil {
+		return v, true, nil
+	}
+	if err != ErrMissingValue {
+		return 0, false, t.cellError(row, col, err)
+	}
+
+	switch t.Options.MissingValuePolicy {
+	case MissingValueZero:
+		return 0, true, nil
+	case MissingValueNaN:
+		return math.NaN(), true, nil
+	case MissingValueError:
+		return 0, false, t.cellError(row, col, err)
+	}
+	return 0, false, nil
+}
+
+func (t *Table) columns(xref, yref string) (int, int, error) {
+	xcol, err := t.Column(xref)
+	if err != nil {
+		return 0, 0, err
+	}
+	ycol, err := t.Column(yref)
+	if err != nil {
+		return 0, 0, err
+	}
+	return xcol, ycol, nil
+}
+
+func (t *Table) cellError(row, col int, err error) error {
+	name := t.ColumnName(col)
+	if name == "" {
+		name = strconv.Itoa(col)
+	}
+	return fmt.Errorf("row %d, column %s: %v", row+1, name, err)
+}
+
+// isHeader returns true if the first of the specified records is a header.
+func (t *Table) isHeader(records [][]string) bool {
+	switch t.Options.Header {
+	case HeaderPresent:
+		return true
+	case HeaderAbsent:
+		return false
+	}
+
+	isValue := func(s string) bool {
+		if _, err := t.ParseFloat(s); err == nil {
+			return true
+		}
+		_, err := t.ParseTime(s)
+		return err == nil
+	}
+
+	first := records[0]
+	if len(records) == 1 {
+		for _, cell := range first {
+			if t.IsMissing(cell) || isValue(cell) {
+				return false
+			}
+		}
+		return true
+	}
+
+	second := records[1]
+	for col, cell := range first {
+		if t.IsMissing(cell) || isValue(cell) || col >= len(second) {
+			continue
+		}
+		if t.IsMissing(second[col]) || isValue(second[col]) {
+			return true
+		}
+	}
+	return false
+}
+
+func trimCells(cells []string) []string {
+	trimmed := make([]string, len(cells))
+	for i, cell := range cells {
+		trimmed[i] = strings.TrimSpace(cell)
+	}
+	return trimmed
+}
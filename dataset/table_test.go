@@ -0,0 +1,147 @@
+package dataset
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadTableHeaderDetection(t *testing.T) {
+	table, err := ReadCSV(strings.NewReader("date,value\n2024-01-01,1\n"), TableOptions{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"date", "value"}, table.Header)
+	require.Equal(t, 1, table.Len())
+
+	table, err = ReadCSV(strings.NewReader("a,1\nb,2\n"), TableOptions{})
+	require.NoError(t, err)
+	require.Nil(t, table.Header)
+	require.Equal(t, 2, table.Len())
+
+	table, err = ReadCSV(strings.NewReader("a,1\nb,2\n"), TableOptions{Header: HeaderPresent})
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "1"}, table.Header)
+	require.Equal(t, 1, table.Len())
+}
+
+func TestTableColumn(t *testing.T) {
+	table, err := ReadTSV(strings.NewReader("x\tY\n1\t2\n"), TableOptions{})
+	require.NoError(t, err)
+
+	col, err := table.Column("y")
+	require.NoError(t, err)
+	require.Equal(t, 1, col)
+
+	col, err = table.Column("0")
+	require.NoError(t, err)
+	require.Equal(t, 0, col)
+
+	_, err = table.Column("z")
+	require.Error(t, err)
+	_, err = table.Column("2")
+	require.Error(t, err)
+}
+
+func TestTableParseFloat(t *testing.T) {
+	table := &Table{Options: TableOptions{ThousandsSeparator: '.', DecimalSeparator: ','}}
+
+	v, err := table.ParseFloat("1.234.567,5")
+	require.NoError(t, err)
+	require.Equal(t, 1234567.5, v)
+
+	_, err = table.ParseFloat("NA")
+	require.Equal(t, ErrMissingValue, err)
+
+	_, err = table.ParseFloat("abc")
+	require.Error(t, err)
+
+	// Percent values are parsed as numbers.
+	v, err = table.ParseFloat(" 12,5% ")
+	require.NoError(t, err)
+	require.Equal(t, 12.5, v)
+
+	input := "name,share\nfoo,12%\nbar,88%\n"
+	table, err = ReadCSV(strings.NewReader(input), TableOptions{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"name", "share"}, table.Header)
+	values, err := table.Floats("share")
+	require.NoError(t, err)
+	require.Equal(t, []float64{12, 88}, values)
+}
+
+func TestTableTimeSeries(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	input := "day;value\n01.02.2024;1\n02.02.2024;\n03.02.2024;3\n"
+	table, err := ReadCSV(strings.NewReader(input), TableOptions{
+		Delimiter:   ';',
+		TimeLayouts: []string{"02.01.2006"},
+		Location:    loc,
+	})
+	require.NoError(t, err)
+
+	ts, err := table.TimeSeries("day", "value")
+	require.NoError(t, err)
+	require.Equal(t, "value", ts.Name)
+	require.Len(t, ts.XValues, 2)
+	require.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, loc).Unix(), ts.XValues[0].Unix())
+	require.Equal(t, []float64{1, 3}, ts.YValues)
+}
+
+func TestTableContinuousSeriesMissingValues(t *testing.T) {
+	input := "x,y\n1,\"1,000\"\n2,NA\n3,3\n"
+
+	table, err := ReadCSV(strings.NewReader(input), TableOptions{ThousandsSeparator: ','})
+	require.NoError(t, err)
+	cs, err := table.ContinuousSeries("x", "y")
+	require.NoError(t, err)
+	require.Equal(t, []float64{1, 3}, cs.XValues)
+	require.Equal(t, []float64{1000, 3}, cs.YValues)
+
+	table.Options.MissingValuePolicy = MissingValueZero
+	cs, err = table.ContinuousSeries("x", "y")
+	require.NoError(t, err)
+	require.Equal(t, []float64{1000, 0, 3}, cs.YValues)
+
+	table.Options.MissingValuePolicy = MissingValueNaN
+	cs, err = table.ContinuousSeries("x", "y")
+	require.NoError(t, err)
+	require.True(t, math.IsNaN(cs.YValues[1]))
+
+	table.Options.MissingValuePolicy = MissingValueError
+	_, err = table.ContinuousSeries("x", "y")
+	require.EqualError(t, err, "row 2, column y: missing value")
+}
+
+func TestTableValues(t *testing.T) {
+	input := "name,a,b\nfoo,1,2\nbar,3,\n"
+	table, err := ReadCSV(strings.NewReader(input), TableOptions{})
+	require.NoError(t, err)
+
+	values, err := table.Values("name", "a")
+	require.NoError(t, err)
+	require.Equal(t, []Value{{Label: "foo", Value: 1}, {Label: "bar", Value: 3}}, values)
+
+	values, err = table.ValuesAt(0, 2)
+	require.NoError(t, err)
+	require.Equal(t, []Value{{Label: "foo", Value: 2}}, values)
+
+	labels, groups, columns, err := table.StackedValues("name")
+	require.NoError(t, err)
+	require.Equal(t, []string{"foo", "bar"}, labels)
+	require.Equal(t, [][]Value{
+		{{Label: "a", Value: 1}, {Label: "b", Value: 2}},
+		{{Label: "a", Value: 3}},
+	}, groups)
+	require.Equal(t, [][]int{{1, 2}, {1}}, columns)
+
+	// Missing values do not shift the columns of the following values.
+	_, groups, columns, err = table.StackedValuesAt(0, 2, 1)
+	require.NoError(t, err)
+	require.Equal(t, [][]Value{
+		{{Label: "b", Value: 2}, {Label: "a", Value: 1}},
+		{{Label: "a", Value: 3}},
+	}, groups)
+	require.Equal(t, [][]int{{2, 1}, {1}}, columns)
+}
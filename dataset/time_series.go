@@ -14,6 +14,7 @@ var (
 	_ FirstValuesProvider    = (*TimeSeries)(nil)
 	_ LastValuesProvider     = (*TimeSeries)(nil)
 	_ ValueFormatterProvider = (*TimeSeries)(nil)
+	_ XAxisProvider          = (*TimeSeries)(nil)
 )
 
 // TimeSeries is a line on a chart.
@@ -21,6 +22,7 @@ type TimeSeries struct {
 	Name  string
 	Style render.Style
 	YAxis YAxisType
+	XAxis XAxisType
 
 	XValues []time.Time
 	YValues []float64
@@ -74,6 +76,11 @@ func (ts TimeSeries) GetYAxis() YAxisType {
 	return ts.YAxis
 }
 
+// GetXAxis returns which XAxis the series draws on.
+func (ts TimeSeries) GetXAxis() XAxisType {
+	return ts.XAxis
+}
+
 // Render renders the series.
 func (ts TimeSeries) Render(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, defaults render.Style) {
 	style := ts.Style.InheritFrom(defaults)
@@ -2,8 +2,12 @@ package dataset
 
 import (
 	"fmt"
+	"math"
 	"strconv"
+	"strings"
 	"time"
+
+	"github.com/unidoc/unichart/mathutil"
 )
 
 const (
@@ -60,14 +64,8 @@ func TimeValueFormatterWithFormat(format string) ValueFormatter {
 
 // TimeValueFormatterWithFormat is a ValueFormatter for timestamps with a given format.
 func formatTime(v interface{}, dateFormat string) string {
-	if typed, isTyped := v.(time.Time); isTyped {
-		return typed.Format(dateFormat)
-	}
-	if typed, isTyped := v.(int64); isTyped {
-		return time.Unix(0, typed).Format(dateFormat)
-	}
-	if typed, isTyped := v.(float64); isTyped {
-		return time.Unix(0, int64(typed)).Format(dateFormat)
+	if t, ok := toTime(v); ok {
+		return t.Format(dateFormat)
 	}
 	return ""
 }
@@ -125,3 +123,139 @@ func KValueFormatter(k float64, vf ValueFormatter) ValueFormatter {
 		return fmt.Sprintf("%0.0fσ %s", k, vf(v))
 	}
 }
+
+// siPrefixes holds the SI prefixes from 10^-24 to 10^24.
+var siPrefixes = []string{"y", "z", "a", "f", "p", "n", "µ", "m", "", "k", "M", "G", "T", "P", "E", "Z", "Y"}
+
+// binaryPrefixes holds the units of byte counts with binary prefixes.
+var binaryPrefixes = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+
+// SIValueFormatter is a formatter for values with SI prefixes, such as
+// 1.2k or 3.4M.
+func SIValueFormatter(v interface{}) string {
+	return SIValueFormatterWithDecimals(1)(v)
+}
+
+// SIValueFormatterWithDecimals returns a formatter for values with SI
+// prefixes, with at most the specified number of decimals.
+func SIValueFormatterWithDecimals(decimals int) ValueFormatter {
+	return func(v interface{}) string {
+		value, ok := toFloat64(v)
+		if !ok {
+			return ""
+		}
+		return formatScaled(value, 1000, -8, siPrefixes, decimals, "")
+	}
+}
+
+// BytesValueFormatter is a formatter for byte counts with binary prefixes,
+// such as 512 B, 1.5 KiB or 3 MiB.
+func BytesValueFormatter(v interface{}) string {
+	value, ok := toFloat64(v)
+	if !ok {
+		return ""
+	}
+	return formatScaled(value, 1024, 0, binaryPrefixes, 1, " ")
+}
+
+// DurationValueFormatter returns a formatter for durations expressed in the
+// specified unit, such as 1h30m or 250ms.
+func DurationValueFormatter(unit time.Duration) ValueFormatter {
+	return func(v interface{}) string {
+		if typed, isTyped := v.(time.Duration); isTyped {
+			return formatDuration(float64(typed))
+		}
+		value, ok := toFloat64(v)
+		if !ok {
+			return ""
+		}
+		return formatDuration(value * float64(unit))
+	}
+}
+
+// CurrencyValueFormatter returns a formatter for currency amounts, written
+// with the specified symbol, number of decimals and number format, such as
+// $1,234.50.
+func CurrencyValueFormatter(symbol string, decimals int, nf NumberFormat) ValueFormatter {
+	return func(v interface{}) string {
+		value, ok := toFloat64(v)
+		if !ok {
+			return ""
+		}
+
+		amount := nf.Format(math.Abs(value), decimals)
+		if value < 0 && nf.Format(value, decimals) != amount {
+			return "-" + symbol + amount
+		}
+		return symbol + amount
+	}
+}
+
+// formatScaled formats a value divided by the largest power of base not
+// greater than it, followed by the matching unit. The first unit matches
+// base^minExponent.
+func formatScaled(v, base float64, minExponent int, units []string, decimals int, separator string) string {
+	if v == 0 || math.IsNaN(v) || math.IsInf(v, 0) {
+		return strconv.FormatFloat(v, 'f', -1, 64) + separator + units[-minExponent]
+	}
+
+	maxExponent := minExponent + len(units) - 1
+	exponent := int(math.Floor(math.Log(math.Abs(v)) / math.Log(base)))
+	exponent = mathutil.MinInt(mathutil.MaxInt(exponent, minExponent), maxExponent)
+
+	scaled := v / math.Pow(base, float64(exponent))
+	rounded := strconv.FormatFloat(scaled, 'f', decimals, 64)
+	if r, _ := strconv.ParseFloat(rounded, 64); math.Abs(r) >= base && exponent < maxExponent {
+		exponent++
+		scaled = v / math.Pow(base, float64(exponent))
+		rounded = strconv.FormatFloat(scaled, 'f', decimals, 64)
+	}
+
+	if strings.Contains(rounded, ".") {
+		rounded = strings.TrimRight(strings.TrimRight(rounded, "0"), ".")
+	}
+	return rounded + separator + units[exponent-minExponent]
+}
+
+// formatDuration formats a duration in nanoseconds. Durations shorter than a
+// second are rounded to microseconds, and longer ones to seconds.
+func formatDuration(ns float64) string {
+	d := time.Duration(math.Round(ns))
+	if d > -time.Second && d < time.Second {
+		return d.Round(time.Microsecond).String()
+	}
+
+	var sb strings.Builder
+	if d < 0 {
+		sb.WriteByte('-')
+		d = -d
+	}
+	d = d.Round(time.Second)
+
+	for _, unit := range []struct {
+		duration time.Duration
+		suffix   string
+	}{{24 * time.Hour, "d"}, {time.Hour, "h"}, {time.Minute, "m"}, {time.Second, "s"}} {
+		if n := d / unit.duration; n > 0 {
+			sb.WriteString(strconv.FormatInt(int64(n), 10))
+			sb.WriteString(unit.suffix)
+			d -= n * unit.duration
+		}
+	}
+	return sb.String()
+}
+
+// toFloat64 converts a numeric value to float64.
+func toFloat64(v interface{}) (float64, bool) {
+	switch t := v.(type) {
+	case int:
+		return float64(t), true
+	case int64:
+		return float64(t), true
+	case float32:
+		return float64(t), true
+	case float64:
+		return t, true
+	}
+	return 0, false
+}
@@ -48,3 +48,43 @@ func TestFloatValueFormatterWithFormat(t *testing.T) {
 	require.Equal(t, "123.456", sv)
 	require.Equal(t, "123.000", FloatValueFormatterWithFormat(123, "%.3f"))
 }
+
+func TestSIValueFormatter(t *testing.T) {
+	require.Equal(t, "0", SIValueFormatter(0))
+	require.Equal(t, "500", SIValueFormatter(500))
+	require.Equal(t, "1.2k", SIValueFormatter(1234))
+	require.Equal(t, "3.4M", SIValueFormatter(3.4e6))
+	require.Equal(t, "1M", SIValueFormatter(999999.0))
+	require.Equal(t, "-2.5m", SIValueFormatter(-0.0025))
+	require.Equal(t, "1.23G", SIValueFormatterWithDecimals(2)(int64(1234567890)))
+	require.Equal(t, "", SIValueFormatter("x"))
+}
+
+func TestBytesValueFormatter(t *testing.T) {
+	require.Equal(t, "512 B", BytesValueFormatter(512))
+	require.Equal(t, "1.5 KiB", BytesValueFormatter(1536))
+	require.Equal(t, "3 MiB", BytesValueFormatter(3*1024*1024))
+	require.Equal(t, "1 GiB", BytesValueFormatter(float64(1<<30-1)))
+}
+
+func TestDurationValueFormatter(t *testing.T) {
+	seconds := DurationValueFormatter(time.Second)
+	require.Equal(t, "1h30m", seconds(5400))
+	require.Equal(t, "45s", seconds(45.2))
+	require.Equal(t, "1d2h5s", seconds(93605))
+	require.Equal(t, "-2m", seconds(-120))
+	require.Equal(t, "250ms", seconds(0.25))
+	require.Equal(t, "0s", seconds(0))
+	require.Equal(t, "1m30s", DurationValueFormatter(time.Millisecond)(90000))
+	require.Equal(t, "1h", seconds(time.Hour))
+}
+
+func TestCurrencyValueFormatter(t *testing.T) {
+	require.Equal(t, "$1,234.50", CurrencyValueFormatter("$", 2, DefaultNumberFormat)(1234.5))
+	require.Equal(t, "-$1,234,567", CurrencyValueFormatter("$", 0, DefaultNumberFormat)(-1234567))
+	require.Equal(t, "$0.00", CurrencyValueFormatter("$", 2, DefaultNumberFormat)(-0.001))
+
+	de := NumberFormat{DecimalSeparator: ",", GroupSeparator: ".", GroupSize: 3}
+	require.Equal(t, "€12.345,68", CurrencyValueFormatter("€", 2, de)(12345.678))
+	require.Equal(t, "1234.5", NumberFormat{}.Format(1234.5, 1))
+}
@@ -12,6 +12,19 @@ type BoundedValuesProvider interface {
 	GetBoundedValues(index int) (x, y1, y2 float64)
 }
 
+// ErrorBoundsProvider is a provider for the error bounds of the values of a
+// series, which are taken into account when computing the chart ranges.
+type ErrorBoundsProvider interface {
+	Len() int
+	GetErrorBounds(index int) (xlow, xhigh, ylow, yhigh float64)
+}
+
+// AxisBoundsProvider is a series which extends the ranges of the axes it is
+// drawn on, in order to include the returned values.
+type AxisBoundsProvider interface {
+	GetAxisBounds() (xvalues, yvalues []float64)
+}
+
 // FirstValuesProvider is a special type of value provider that can return
 // it's (potentially computed) first value.
 type FirstValuesProvider interface {
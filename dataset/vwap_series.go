@@ -0,0 +1,140 @@
+package dataset
+
+import (
+	"fmt"
+
+	"github.com/unidoc/unichart/dataset/sequence"
+	"github.com/unidoc/unichart/render"
+)
+
+// Interface Assertions.
+var (
+	_ Series              = (*VWAPSeries)(nil)
+	_ FirstValuesProvider = (*VWAPSeries)(nil)
+	_ LastValuesProvider  = (*VWAPSeries)(nil)
+)
+
+// VWAPSeries computes the volume weighted average price of the close values
+// (InnerSeries). The prices are the typical prices (high+low+close)/3, where
+// the High and Low values default to the close values, weighted by the
+// Volume values. The average is cumulative, unless a Period is set, in which
+// case it is computed over a rolling window.
+type VWAPSeries struct {
+	Name  string
+	Style render.Style
+	YAxis YAxisType
+
+	Period      int
+	High        ValuesProvider
+	Low         ValuesProvider
+	Volume      ValuesProvider
+	InnerSeries ValuesProvider
+
+	cache []float64
+}
+
+// GetName returns the name of the time series.
+func (vwap VWAPSeries) GetName() string {
+	return vwap.Name
+}
+
+// GetStyle returns the line style.
+func (vwap VWAPSeries) GetStyle() render.Style {
+	return vwap.Style
+}
+
+// GetYAxis returns which YAxis the series draws on.
+func (vwap VWAPSeries) GetYAxis() YAxisType {
+	return vwap.YAxis
+}
+
+// GetPeriod returns the window size. A period of 0 means the average is
+// cumulative.
+func (vwap VWAPSeries) GetPeriod() int {
+	if vwap.Period < 0 {
+		return 0
+	}
+	return vwap.Period
+}
+
+// Len returns the number of elements in the series.
+func (vwap VWAPSeries) Len() int {
+	return vwap.InnerSeries.Len()
+}
+
+// GetValues gets a value at a given index.
+func (vwap *VWAPSeries) GetValues(index int) (x, y float64) {
+	if vwap.InnerSeries == nil || vwap.Volume == nil {
+		return
+	}
+	if vwap.cache == nil {
+		vwap.ensureCachedValues()
+	}
+	if index < 0 || index >= len(vwap.cache) {
+		return
+	}
+	x, _ = vwap.InnerSeries.GetValues(index)
+	y = vwap.cache[index]
+	return
+}
+
+// GetFirstValues computes the first VWAP value.
+func (vwap *VWAPSeries) GetFirstValues() (x, y float64) {
+	return vwap.GetValues(0)
+}
+
+// GetLastValues computes the last VWAP value.
+func (vwap *VWAPSeries) GetLastValues() (x, y float64) {
+	if vwap.InnerSeries == nil {
+		return
+	}
+	return vwap.GetValues(vwap.InnerSeries.Len() - 1)
+}
+
+func (vwap *VWAPSeries) ensureCachedValues() {
+	highs, lows, closes := highLowCloseValues(vwap.High, vwap.Low, vwap.InnerSeries)
+
+	prices := make([]float64, len(closes))
+	volumes := make([]float64, len(closes))
+	for i := range closes {
+		prices[i] = (highs[i] + lows[i] + closes[i]) / 3
+		if i < vwap.Volume.Len() {
+			_, volumes[i] = vwap.Volume.GetValues(i)
+		}
+	}
+
+	period := vwap.GetPeriod()
+	vwap.cache = make([]float64, len(closes))
+	var accum, volume float64
+	for i := range closes {
+		accum += prices[i] * volumes[i]
+		volume += volumes[i]
+		if period > 0 && i >= period {
+			accum -= prices[i-period] * volumes[i-period]
+			volume -= volumes[i-period]
+		}
+
+		if volume == 0 {
+			vwap.cache[i] = prices[i]
+			continue
+		}
+		vwap.cache[i] = accum / volume
+	}
+}
+
+// Render renders the series.
+func (vwap *VWAPSeries) Render(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, defaults render.Style) {
+	style := vwap.Style.InheritFrom(defaults)
+	drawLineSeries(r, canvasBox, xrange, yrange, style, vwap)
+}
+
+// Validate validates the series.
+func (vwap *VWAPSeries) Validate() error {
+	if vwap.InnerSeries == nil {
+		return fmt.Errorf("vwap series requires InnerSeries to be set")
+	}
+	if vwap.Volume == nil {
+		return fmt.Errorf("vwap series requires Volume to be set")
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package dataset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVWAPSeries(t *testing.T) {
+	xvalues := []float64{1, 2, 3}
+	vwap := &VWAPSeries{
+		InnerSeries: ContinuousSeries{XValues: xvalues, YValues: []float64{1, 2, 3}},
+		Volume:      ContinuousSeries{XValues: xvalues, YValues: []float64{1, 1, 2}},
+	}
+	require.Nil(t, vwap.Validate())
+
+	for index, value := range []float64{1, 1.5, 2.25} {
+		_, y := vwap.GetValues(index)
+		require.InDelta(t, value, y, 1e-9)
+	}
+
+	rolling := &VWAPSeries{InnerSeries: vwap.InnerSeries, Volume: vwap.Volume, Period: 2}
+	_, y := rolling.GetLastValues()
+	require.InDelta(t, 8.0/3, y, 1e-9)
+
+	require.NotNil(t, (&VWAPSeries{InnerSeries: vwap.InnerSeries}).Validate())
+}
+
+func TestVWAPSeriesEmpty(t *testing.T) {
+	vwap := &VWAPSeries{Volume: ContinuousSeries{}, InnerSeries: ContinuousSeries{}}
+
+	x, y := vwap.GetValues(0)
+	require.Zero(t, x)
+	require.Zero(t, y)
+	require.NotNil(t, vwap.cache)
+
+	x, y = vwap.GetLastValues()
+	require.Zero(t, x)
+	require.Zero(t, y)
+}
@@ -0,0 +1,109 @@
+package dataset
+
+import (
+	"fmt"
+
+	"github.com/unidoc/unichart/dataset/sequence"
+	"github.com/unidoc/unichart/render"
+)
+
+const (
+	// defaultWMAPeriod is the default number of values to average.
+	defaultWMAPeriod = 16
+)
+
+// Interface Assertions.
+var (
+	_ Series              = (*WMASeries)(nil)
+	_ FirstValuesProvider = (*WMASeries)(nil)
+	_ LastValuesProvider  = (*WMASeries)(nil)
+)
+
+// WMASeries computes the linearly weighted moving average of an inner
+// series. The most recent value has a weight equal to the period, and the
+// weights of the previous values decrease linearly.
+type WMASeries struct {
+	Name  string
+	Style render.Style
+	YAxis YAxisType
+
+	Period      int
+	InnerSeries ValuesProvider
+
+	cache []float64
+}
+
+// GetName returns the name of the time series.
+func (wma WMASeries) GetName() string {
+	return wma.Name
+}
+
+// GetStyle returns the line style.
+func (wma WMASeries) GetStyle() render.Style {
+	return wma.Style
+}
+
+// GetYAxis returns which YAxis the series draws on.
+func (wma WMASeries) GetYAxis() YAxisType {
+	return wma.YAxis
+}
+
+// GetPeriod returns the window size.
+func (wma WMASeries) GetPeriod() int {
+	if wma.Period == 0 {
+		return defaultWMAPeriod
+	}
+	return wma.Period
+}
+
+// Len returns the number of elements in the series.
+func (wma WMASeries) Len() int {
+	return wma.InnerSeries.Len()
+}
+
+// GetValues gets a value at a given index.
+func (wma *WMASeries) GetValues(index int) (x, y float64) {
+	if wma.InnerSeries == nil {
+		return
+	}
+	if wma.cache == nil {
+		wma.ensureCachedValues()
+	}
+	if index < 0 || index >= len(wma.cache) {
+		return
+	}
+	x, _ = wma.InnerSeries.GetValues(index)
+	y = wma.cache[index]
+	return
+}
+
+// GetFirstValues computes the first moving average value.
+func (wma *WMASeries) GetFirstValues() (x, y float64) {
+	return wma.GetValues(0)
+}
+
+// GetLastValues computes the last moving average value.
+func (wma *WMASeries) GetLastValues() (x, y float64) {
+	if wma.InnerSeries == nil {
+		return
+	}
+	return wma.GetValues(wma.InnerSeries.Len() - 1)
+}
+
+func (wma *WMASeries) ensureCachedValues() {
+	wma.cache = weightedMovingAverage(indicatorValues(wma.InnerSeries), wma.GetPeriod())
+}
+
+// Render renders the series.
+func (wma *WMASeries) Render(r render.Renderer, canvasBox render.Box, xrange, yrange sequence.Range, defaults render.Style) {
+	style := wma.Style.InheritFrom(defaults)
+	drawLineSeries(r, canvasBox, xrange, yrange, style, wma)
+}
+
+// Validate validates the series.
+func (wma *WMASeries) Validate() error {
+	if wma.InnerSeries == nil {
+		return fmt.Errorf("wma series requires InnerSeries to be set")
+	}
+	return nil
+}
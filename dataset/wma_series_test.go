@@ -0,0 +1,36 @@
+package dataset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWMASeries(t *testing.T) {
+	wma := &WMASeries{
+		InnerSeries: ContinuousSeries{
+			XValues: []float64{1, 2, 3, 4},
+			YValues: []float64{1, 2, 3, 4},
+		},
+		Period: 3,
+	}
+	require.Nil(t, wma.Validate())
+
+	for index, value := range []float64{1, 1.6, 14.0 / 6, 20.0 / 6} {
+		_, y := wma.GetValues(index)
+		require.InDelta(t, value, y, 1e-9)
+	}
+}
+
+func TestWMASeriesEmpty(t *testing.T) {
+	wma := &WMASeries{InnerSeries: ContinuousSeries{}}
+
+	x, y := wma.GetValues(0)
+	require.Zero(t, x)
+	require.Zero(t, y)
+	require.NotNil(t, wma.cache)
+
+	x, y = wma.GetLastValues()
+	require.Zero(t, x)
+	require.Zero(t, y)
+}
@@ -0,0 +1,621 @@
+package unichart
+
+import (
+	"errors"
+	"io"
+	"math"
+
+	"github.com/unidoc/unichart/dataset"
+	"github.com/unidoc/unichart/dataset/sequence"
+	"github.com/unidoc/unichart/mathutil"
+	"github.com/unidoc/unichart/render"
+)
+
+const (
+	// defaultGaugeSweepAngle is the default angle in degrees covered by the
+	// arc of gauge charts.
+	defaultGaugeSweepAngle = 240.0
+
+	// defaultGaugeMajorTicks is the default number of major ticks of gauge
+	// charts, including the bounds of the scale.
+	defaultGaugeMajorTicks = 6
+
+	// defaultGaugeArcWidthRatio is the default width of the arc of gauge
+	// charts, relative to their radius.
+	defaultGaugeArcWidthRatio = 0.12
+
+	// defaultGaugeCenterRatio is the minimum space kept below the center of
+	// gauge charts, relative to their radius, for the center value.
+	defaultGaugeCenterRatio = 0.3
+
+	// defaultGaugeMarkerRatio is the size of the min and max markers of
+	// gauge charts, relative to their radius.
+	defaultGaugeMarkerRatio = 0.05
+)
+
+// GaugeIndicator specifies how the value of a gauge chart is indicated.
+type GaugeIndicator int
+
+const (
+	// GaugeIndicatorNeedle points a needle from the center of the gauge
+	// at the value.
+	GaugeIndicatorNeedle GaugeIndicator = iota
+
+	// GaugeIndicatorArc fills the arc of the gauge from the minimum up to
+	// the value.
+	GaugeIndicatorArc
+)
+
+// GaugeZone is a colored range of values along the arc of a gauge chart,
+// such as a warning or a critical zone.
+type GaugeZone struct {
+	Min   float64
+	Max   float64
+	Style render.Style
+}
+
+// GaugeChart is a chart that indicates a value on a circular scale, like a
+// speedometer. The arc of the scale is centered on the top of the chart and
+// covers the sweep angle, clockwise from the minimum to the maximum. Values
+// outside of the scale are indicated at its bounds.
+type GaugeChart struct {
+	Title         string
+	TitleStyle    render.Style
+	Subtitle      string
+	SubtitleStyle render.Style
+	Caption       string
+	CaptionStyle  render.Style
+
+	Font         render.Font
+	Background   render.Style
+	Canvas       render.Style
+	ColorPalette render.ColorPalette
+
+	// Min and Max are the bounds of the scale.
+	Min float64
+	Max float64
+
+	// Value is the value indicated by the gauge.
+	Value float64
+
+	// SweepAngle is the angle in degrees covered by the arc.
+	SweepAngle float64
+
+	// ArcWidth is the width of the arc in pixels. It defaults to a fraction
+	// of the radius of the gauge.
+	ArcWidth int
+	ArcStyle render.Style
+
+	// Zones are colored over the arc, in order.
+	Zones []GaugeZone
+
+	Indicator      GaugeIndicator
+	IndicatorStyle render.Style
+
+	// MajorTicks places the labeled ticks of the scale. It defaults to
+	// evenly spaced ticks, including the bounds of the scale.
+	MajorTicks TickLocator
+	TickStyle  render.Style
+
+	// MinorTicks is the number of unlabeled ticks between consecutive major
+	// ticks.
+	MinorTicks     int
+	MinorTickStyle render.Style
+
+	// MinMarker and MaxMarker, if set, mark values on the outside of the
+	// arc, such as the lowest and highest values recorded.
+	MinMarker   *float64
+	MaxMarker   *float64
+	MarkerStyle render.Style
+
+	// ValueFormatter formats the tick labels and the value displayed in
+	// the center of the gauge.
+	ValueFormatter dataset.ValueFormatter
+	ValueStyle     render.Style
+
+	Elements []render.Renderable
+
+	width  int
+	height int
+	dpi    float64
+}
+
+// DPI returns the DPI for the chart.
+func (gc *GaugeChart) DPI() float64 {
+	if gc.dpi == 0 {
+		return defaultDPI
+	}
+	return gc.dpi
+}
+
+// SetDPI sets the DPI for the chart.
+func (gc *GaugeChart) SetDPI(dpi float64) {
+	gc.dpi = dpi
+}
+
+// GetFont returns the text font.
+func (gc *GaugeChart) GetFont() render.Font {
+	return gc.Font
+}
+
+// Width returns the chart width or the default value.
+func (gc *GaugeChart) Width() int {
+	if gc.width == 0 {
+		return defaultChartWidth
+	}
+	return gc.width
+}
+
+// SetWidth sets the chart width.
+func (gc *GaugeChart) SetWidth(width int) {
+	gc.width = width
+}
+
+// Height returns the chart height or the default value.
+func (gc *GaugeChart) Height() int {
+	if gc.height == 0 {
+		return defaultChartHeight
+	}
+	return gc.height
+}
+
+// SetHeight sets the chart height.
+func (gc *GaugeChart) SetHeight(height int) {
+	gc.height = height
+}
+
+// GetSweepAngle returns the angle in degrees covered by the arc.
+func (gc *GaugeChart) GetSweepAngle(defaults ...float64) float64 {
+	if gc.SweepAngle == 0 {
+		if len(defaults) > 0 {
+			return defaults[0]
+		}
+		return defaultGaugeSweepAngle
+	}
+	return gc.SweepAngle
+}
+
+// GetMajorTicks returns the locator of the major ticks.
+func (gc *GaugeChart) GetMajorTicks(defaults ...TickLocator) TickLocator {
+	if gc.MajorTicks == nil {
+		if len(defaults) > 0 {
+			return defaults[0]
+		}
+		return FixedCountTickLocator{Count: defaultGaugeMajorTicks}
+	}
+	return gc.MajorTicks
+}
+
+// GetValueFormatter returns the value formatter of the chart.
+func (gc *GaugeChart) GetValueFormatter() dataset.ValueFormatter {
+	if gc.ValueFormatter != nil {
+		return gc.ValueFormatter
+	}
+	return dataset.FloatValueFormatter
+}
+
+// Render renders the chart with the given renderer to the given io.Writer.
+func (gc *GaugeChart) Render(rp render.RendererProvider, w io.Writer) error {
+	if !(gc.Max > gc.Min) {
+		return errors.New("invalid gauge range; max must be greater than min")
+	}
+	if sweep := gc.GetSweepAngle(); sweep < 0 || sweep > 360 {
+		return errors.New("invalid gauge sweep angle; must be between 0 and 360 degrees")
+	}
+
+	r, err := rp(gc.Width(), gc.Height())
+	if err != nil {
+		return err
+	}
+	r.SetDPI(gc.DPI())
+
+	gc.drawBackground(r)
+
+	canvasBox := gc.getDefaultCanvasBox(r)
+	canvasBox.Draw(r, gc.Canvas.InheritFrom(gc.styleDefaultsCanvas()))
+
+	cx, cy, radius := gc.getGeometry(canvasBox)
+	arcWidth := gc.getArcWidth(radius)
+
+	if gc.Indicator == GaugeIndicatorArc {
+		// Zones are drawn as a strip along the outer edge of the arc, so
+		// that they remain visible under the indicator.
+		zoneWidth := arcWidth / 4
+		gc.drawArc(r, cx, cy, radius-zoneWidth, arcWidth-zoneWidth)
+		gc.drawZones(r, cx, cy, radius, zoneWidth)
+		gc.drawArcIndicator(r, cx, cy, radius-zoneWidth, arcWidth-zoneWidth)
+	} else {
+		gc.drawArc(r, cx, cy, radius, arcWidth)
+		gc.drawZones(r, cx, cy, radius, arcWidth)
+	}
+
+	gc.drawTicks(r, cx, cy, radius-arcWidth)
+	gc.drawMarkers(r, cx, cy, radius)
+
+	if gc.Indicator == GaugeIndicatorArc {
+		gc.drawValue(r, cx, cy, radius)
+	} else {
+		gc.drawNeedle(r, cx, cy, radius-arcWidth)
+		gc.drawValue(r, cx, cy+int(radius*defaultGaugeCenterRatio/2), radius)
+	}
+	gc.drawTitle(r)
+
+	for _, a := range gc.Elements {
+		a(r, canvasBox, gc.styleDefaultsElements())
+	}
+
+	return r.Save(w)
+}
+
+// getGeometry returns the center and the radius of the largest gauge fitting
+// in the canvas. The space below the center depends on how far the arc
+// extends below it, and leaves room for the center value. Room is also left
+// around the arc for the markers, if any.
+func (gc *GaugeChart) getGeometry(canvasBox render.Box) (cx, cy int, radius float64) {
+	halfSweep := mathutil.DegreesToRadians(gc.GetSweepAngle() / 2)
+	below := math.Max(-math.Cos(halfSweep), defaultGaugeCenterRatio)
+
+	radius = math.Min(float64(canvasBox.Width())/2, float64(canvasBox.Height())/(1+below))
+	cx = canvasBox.Left + canvasBox.Width()/2
+	cy = canvasBox.Top + int((float64(canvasBox.Height())-radius*(1+below))/2+radius)
+
+	if gc.MinMarker != nil || gc.MaxMarker != nil {
+		radius /= 1 + gc.getMarkerLength(1)
+	}
+	return cx, cy, radius
+}
+
+// getMarkerLength returns the length of the markers outside of an arc with
+// the specified outer radius.
+func (gc *GaugeChart) getMarkerLength(radius float64) float64 {
+	return radius * defaultGaugeMarkerRatio * 1.5
+}
+
+// getArcWidth returns the width of the arc for the specified radius.
+func (gc *GaugeChart) getArcWidth(radius float64) float64 {
+	if gc.ArcWidth > 0 {
+		return math.Min(float64(gc.ArcWidth), radius)
+	}
+	return math.Max(radius*defaultGaugeArcWidthRatio, 1)
+}
+
+// getAngle returns the angle in radians at which the specified value is
+// indicated, clockwise from the top of the gauge. Values outside of the
+// scale are clamped to its bounds.
+func (gc *GaugeChart) getAngle(value float64) float64 {
+	sweep := gc.GetSweepAngle()
+	ratio := (math.Max(gc.Min, math.Min(value, gc.Max)) - gc.Min) / (gc.Max - gc.Min)
+	return mathutil.DegreesToRadians(-sweep/2 + ratio*sweep)
+}
+
+// drawBand draws the section of the arc between the specified values, with
+// the specified outer radius and width.
+func (gc *GaugeChart) drawBand(r render.Renderer, cx, cy int, radius, width, from, to float64, style render.Style) {
+	start, end := gc.getAngle(from), gc.getAngle(to)
+	if end <= start {
+		return
+	}
+
+	// The angles of gauges are clockwise from the top, while the angles of
+	// arcs are clockwise from the right.
+	inner := radius - width
+	x, y := mathutil.CirclePoint(cx, cy, radius, start)
+	ix, iy := mathutil.CirclePoint(cx, cy, inner, end)
+
+	style.WriteToRenderer(r)
+	r.MoveTo(x, y)
+	r.ArcTo(cx, cy, radius, radius, start-math.Pi/2, end-start)
+	r.LineTo(ix, iy)
+	r.ArcTo(cx, cy, inner, inner, end-math.Pi/2, start-end)
+	r.Close()
+	r.FillStroke()
+	r.ResetStyle()
+}
+
+func (gc *GaugeChart) drawArc(r render.Renderer, cx, cy int, radius, width float64) {
+	arcStyle := gc.ArcStyle.InheritFrom(gc.styleDefaultsArc())
+	if arcStyle.Hidden {
+		return
+	}
+	gc.drawBand(r, cx, cy, radius, width, gc.Min, gc.Max, arcStyle)
+}
+
+func (gc *GaugeChart) drawZones(r render.Renderer, cx, cy int, radius, width float64) {
+	for index, zone := range gc.Zones {
+		zoneStyle := zone.Style.InheritFrom(gc.styleDefaultsZone(index))
+		if zoneStyle.Hidden {
+			continue
+		}
+		gc.drawBand(r, cx, cy, radius, width, math.Min(zone.Min, zone.Max), math.Max(zone.Min, zone.Max), zoneStyle)
+	}
+}
+
+func (gc *GaugeChart) drawArcIndicator(r render.Renderer, cx, cy int, radius, width float64) {
+	indicatorStyle := gc.IndicatorStyle.InheritFrom(gc.styleDefaultsArcIndicator())
+	if indicatorStyle.Hidden {
+		return
+	}
+	gc.drawBand(r, cx, cy, radius, width, gc.Min, gc.Value, indicatorStyle)
+}
+
+// getTicks returns the major ticks of the scale and the values of the minor
+// ticks between them.
+func (gc *GaugeChart) getTicks() ([]Tick, []float64) {
+	ra := &sequence.ContinuousRange{Min: gc.Min, Max: gc.Max}
+	ticks := locateTicks(gc.GetMajorTicks(), ra, gc.GetValueFormatter())
+
+	var minor []float64
+	if gc.MinorTicks > 0 {
+		for i := 1; i < len(ticks); i++ {
+			step := (ticks[i].Value - ticks[i-1].Value) / float64(gc.MinorTicks+1)
+			for j := 1; j <= gc.MinorTicks; j++ {
+				minor = append(minor, ticks[i-1].Value+float64(j)*step)
+			}
+		}
+	}
+	return ticks, minor
+}
+
+// drawTicks draws the ticks and the tick labels of the scale inside of the
+// arc, whose inner radius is specified.
+func (gc *GaugeChart) drawTicks(r render.Renderer, cx, cy int, radius float64) {
+	tickStyle := gc.TickStyle.InheritFrom(gc.styleDefaultsTicks(radius))
+	if tickStyle.Hidden {
+		return
+	}
+
+	tickLength := radius / 10
+	ticks, minor := gc.getTicks()
+
+	minorStyle := gc.MinorTickStyle.InheritFrom(tickStyle)
+	if !minorStyle.Hidden {
+		minorStyle.WriteToRenderer(r)
+		for _, v := range minor {
+			gc.drawTick(r, cx, cy, radius, tickLength/2, v)
+		}
+		r.Stroke()
+		r.ResetStyle()
+	}
+
+	tickStyle.WriteToRenderer(r)
+	for _, t := range ticks {
+		gc.drawTick(r, cx, cy, radius, tickLength, t.Value)
+	}
+	r.Stroke()
+
+	labelRadius := radius - tickLength - float64(defaultXAxisMargin)
+	for _, t := range ticks {
+		tb := render.Text.Measure(r, t.Label, tickStyle)
+
+		// Labels are pushed inwards by half their size, so that their
+		// edges closest to the arc are at the same distance from it.
+		angle := gc.getAngle(t.Value)
+		offset := math.Abs(math.Sin(angle))*float64(tb.Width())/2 + math.Abs(math.Cos(angle))*float64(tb.Height())/2
+		lx, ly := mathutil.CirclePoint(cx, cy, labelRadius-offset, angle)
+		render.Text.DrawWithin(r, t.Label, centeredBox(lx, ly, tb), tickStyle)
+	}
+	r.ResetStyle()
+}
+
+// drawTick adds the tick of the specified value and length to the current
+// path, inwards from the specified radius.
+func (gc *GaugeChart) drawTick(r render.Renderer, cx, cy int, radius, length, value float64) {
+	angle := gc.getAngle(value)
+	x0, y0 := mathutil.CirclePoint(cx, cy, radius, angle)
+	x1, y1 := mathutil.CirclePoint(cx, cy, radius-length, angle)
+	r.MoveTo(x0, y0)
+	r.LineTo(x1, y1)
+}
+
+// drawMarkers draws the min and max markers outside of the arc, whose outer
+// radius is specified, pointing at their values.
+func (gc *GaugeChart) drawMarkers(r render.Renderer, cx, cy int, radius float64) {
+	markerStyle := gc.MarkerStyle.InheritFrom(gc.styleDefaultsMarker())
+	if markerStyle.Hidden {
+		return
+	}
+
+	// The markers are as wide as their size, at their base.
+	length := gc.getMarkerLength(radius)
+	spread := defaultGaugeMarkerRatio
+	for _, marker := range []*float64{gc.MinMarker, gc.MaxMarker} {
+		if marker == nil {
+			continue
+		}
+
+		angle := gc.getAngle(*marker)
+		tx, ty := mathutil.CirclePoint(cx, cy, radius, angle)
+		lx, ly := mathutil.CirclePoint(cx, cy, radius+length, angle-spread)
+		rx, ry := mathutil.CirclePoint(cx, cy, radius+length, angle+spread)
+
+		markerStyle.WriteToRenderer(r)
+		r.MoveTo(tx, ty)
+		r.LineTo(lx, ly)
+		r.LineTo(rx, ry)
+		r.Close()
+		r.FillStroke()
+	}
+	r.ResetStyle()
+}
+
+// drawNeedle draws a needle from the center of the gauge at the value,
+// reaching the specified radius.
+func (gc *GaugeChart) drawNeedle(r render.Renderer, cx, cy int, radius float64) {
+	needleStyle := gc.IndicatorStyle.InheritFrom(gc.styleDefaultsNeedle())
+	if needleStyle.Hidden {
+		return
+	}
+
+	hubRadius := math.Max(radius/12, 3)
+	angle := gc.getAngle(gc.Value)
+	tx, ty := mathutil.CirclePoint(cx, cy, radius, angle)
+	lx, ly := mathutil.CirclePoint(cx, cy, hubRadius/2, angle-math.Pi/2)
+	rx, ry := mathutil.CirclePoint(cx, cy, hubRadius/2, angle+math.Pi/2)
+
+	needleStyle.WriteToRenderer(r)
+	r.MoveTo(tx, ty)
+	r.LineTo(lx, ly)
+	r.LineTo(rx, ry)
+	r.Close()
+	r.FillStroke()
+
+	needleStyle.WriteToRenderer(r)
+	r.Circle(hubRadius, cx, cy)
+	r.FillStroke()
+	r.ResetStyle()
+}
+
+// drawValue draws the formatted value of the gauge, centered on the
+// specified point.
+func (gc *GaugeChart) drawValue(r render.Renderer, x, y int, radius float64) {
+	valueStyle := gc.ValueStyle.InheritFrom(gc.styleDefaultsValue(radius))
+	if valueStyle.Hidden {
+		return
+	}
+
+	label := gc.GetValueFormatter()(gc.Value)
+	valueStyle.WriteToRenderer(r)
+	tb := render.Text.Measure(r, label, valueStyle)
+
+	// The value is drawn below the hub of the needle.
+	if gc.Indicator == GaugeIndicatorNeedle {
+		y += tb.Height() / 2
+	}
+	render.Text.DrawWithin(r, label, centeredBox(x, y, tb), valueStyle)
+	r.ResetStyle()
+}
+
+// centeredBox returns a box of the size of the specified text box, centered
+// on the specified point.
+func centeredBox(x, y int, tb render.Box) render.Box {
+	left, top := x-tb.Width()/2, y-tb.Height()/2
+	return render.NewBox(top, left, left+tb.Width(), top+tb.Height())
+}
+
+func (gc *GaugeChart) drawBackground(r render.Renderer) {
+	render.Box{
+		Right:  gc.Width(),
+		Bottom: gc.Height(),
+	}.Draw(r, gc.Background.InheritFrom(gc.styleDefaultsBackground()))
+}
+
+func (gc *GaugeChart) drawTitle(r render.Renderer) {
+	gc.getTitles().draw(r, gc.box())
+}
+
+func (gc *GaugeChart) getTitles() chartTitles {
+	return newChartTitles(gc.styleDefaultsTitle(),
+		gc.Title, gc.TitleStyle, gc.Subtitle, gc.SubtitleStyle, gc.Caption, gc.CaptionStyle)
+}
+
+func (gc *GaugeChart) getDefaultCanvasBox(r render.Renderer) render.Box {
+	return gc.getTitles().adjust(r, gc.box())
+}
+
+// box returns the chart bounds as a box.
+func (gc *GaugeChart) box() render.Box {
+	dpr := gc.Background.Padding.GetRight(defaultBackgroundPadding.Right)
+	dpb := gc.Background.Padding.GetBottom(defaultBackgroundPadding.Bottom)
+
+	return render.Box{
+		Top:    gc.Background.Padding.GetTop(defaultBackgroundPadding.Top),
+		Left:   gc.Background.Padding.GetLeft(defaultBackgroundPadding.Left),
+		Right:  gc.Width() - dpr,
+		Bottom: gc.Height() - dpb,
+	}
+}
+
+func (gc *GaugeChart) styleDefaultsBackground() render.Style {
+	return render.Style{
+		FillColor:   gc.GetColorPalette().BackgroundColor(),
+		StrokeColor: gc.GetColorPalette().BackgroundStrokeColor(),
+		StrokeWidth: render.DefaultStrokeWidth,
+	}
+}
+
+func (gc *GaugeChart) styleDefaultsCanvas() render.Style {
+	return render.Style{
+		FillColor:   gc.GetColorPalette().CanvasColor(),
+		StrokeColor: gc.GetColorPalette().CanvasStrokeColor(),
+		StrokeWidth: defaultCanvasStrokeWidth,
+	}
+}
+
+func (gc *GaugeChart) styleDefaultsArc() render.Style {
+	return render.Style{
+		FillColor: render.ColorLightGray,
+	}
+}
+
+func (gc *GaugeChart) styleDefaultsZone(index int) render.Style {
+	return render.Style{
+		FillColor: gc.GetColorPalette().GetSeriesColor(index + 1),
+	}
+}
+
+func (gc *GaugeChart) styleDefaultsArcIndicator() render.Style {
+	return render.Style{
+		FillColor: gc.GetColorPalette().GetSeriesColor(0),
+	}
+}
+
+func (gc *GaugeChart) styleDefaultsNeedle() render.Style {
+	return render.Style{
+		FillColor:   gc.GetColorPalette().TextColor(),
+		StrokeColor: gc.GetColorPalette().TextColor(),
+		StrokeWidth: defaultAxisLineWidth,
+	}
+}
+
+func (gc *GaugeChart) styleDefaultsMarker() render.Style {
+	return render.Style{
+		FillColor:   gc.GetColorPalette().AxisStrokeColor(),
+		StrokeColor: gc.GetColorPalette().AxisStrokeColor(),
+		StrokeWidth: defaultAxisLineWidth,
+	}
+}
+
+func (gc *GaugeChart) styleDefaultsTicks(radius float64) render.Style {
+	return render.Style{
+		StrokeColor:         gc.GetColorPalette().AxisStrokeColor(),
+		StrokeWidth:         defaultAxisLineWidth,
+		Font:                gc.GetFont(),
+		FontSize:            math.Max(radius/14, defaultAxisFontSize),
+		FontColor:           gc.GetColorPalette().TextColor(),
+		TextHorizontalAlign: render.TextHorizontalAlignCenter,
+		TextVerticalAlign:   render.TextVerticalAlignMiddle,
+	}
+}
+
+func (gc *GaugeChart) styleDefaultsValue(radius float64) render.Style {
+	return render.Style{
+		Font:                gc.GetFont(),
+		FontSize:            math.Max(radius/6, defaultTitleFontSize),
+		FontColor:           gc.GetColorPalette().TextColor(),
+		TextHorizontalAlign: render.TextHorizontalAlignCenter,
+		TextVerticalAlign:   render.TextVerticalAlignMiddle,
+	}
+}
+
+func (gc *GaugeChart) styleDefaultsTitle() render.Style {
+	return render.Style{
+		Font:                gc.GetFont(),
+		FontColor:           gc.GetColorPalette().TextColor(),
+		FontSize:            defaultTitleFontSize,
+		TextHorizontalAlign: render.TextHorizontalAlignCenter,
+	}
+}
+
+func (gc *GaugeChart) styleDefaultsElements() render.Style {
+	return render.Style{
+		Font: gc.GetFont(),
+	}
+}
+
+// GetColorPalette returns the color palette for the chart.
+func (gc *GaugeChart) GetColorPalette() render.ColorPalette {
+	if gc.ColorPalette != nil {
+		return gc.ColorPalette
+	}
+	return render.AlternateColorPalette
+}
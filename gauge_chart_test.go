@@ -0,0 +1,68 @@
+package unichart
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/unidoc/unichart/render/raster"
+)
+
+func TestGaugeChartRender(t *testing.T) {
+	min, max := 10.0, 90.0
+	gc := GaugeChart{
+		Max:        100,
+		Value:      75,
+		MinorTicks: 4,
+		Zones: []GaugeZone{
+			{Min: 80, Max: 100},
+		},
+		MinMarker: &min,
+		MaxMarker: &max,
+	}
+
+	buf := bytes.NewBuffer(nil)
+	require.NoError(t, gc.Render(raster.NewRenderer, buf))
+	require.NotZero(t, buf.Len())
+
+	gc.Indicator = GaugeIndicatorArc
+	buf.Reset()
+	require.NoError(t, gc.Render(raster.NewRenderer, buf))
+	require.NotZero(t, buf.Len())
+
+	require.Error(t, (&GaugeChart{}).Render(raster.NewRenderer, buf))
+	require.Error(t, (&GaugeChart{Max: 1, SweepAngle: 400}).Render(raster.NewRenderer, buf))
+}
+
+func TestGaugeChartAngles(t *testing.T) {
+	gc := GaugeChart{Min: 0, Max: 100}
+
+	// The default arc is centered on the top of the gauge.
+	require.InDelta(t, -2*math.Pi/3, gc.getAngle(0), 1e-9)
+	require.InDelta(t, 0, gc.getAngle(50), 1e-9)
+	require.InDelta(t, 2*math.Pi/3, gc.getAngle(100), 1e-9)
+
+	// Values outside of the scale are clamped to its bounds.
+	require.InDelta(t, 2*math.Pi/3, gc.getAngle(150), 1e-9)
+
+	gc.SweepAngle = 180
+	require.InDelta(t, -math.Pi/2, gc.getAngle(-10), 1e-9)
+}
+
+func TestGaugeChartTicks(t *testing.T) {
+	gc := GaugeChart{Min: 0, Max: 100, MinorTicks: 1}
+
+	ticks, minor := gc.getTicks()
+	require.Len(t, ticks, defaultGaugeMajorTicks)
+	require.Equal(t, 0.0, ticks[0].Value)
+	require.Equal(t, 100.0, ticks[len(ticks)-1].Value)
+	require.Equal(t, []float64{10, 30, 50, 70, 90}, minor)
+
+	gc.MajorTicks = FixedStepTickLocator{Step: 25}
+	gc.MinorTicks = 0
+	ticks, minor = gc.getTicks()
+	require.Len(t, ticks, 5)
+	require.Empty(t, minor)
+}
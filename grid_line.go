@@ -75,3 +75,27 @@ func GenerateGridLines(ticks []Tick, majorStyle, minorStyle render.Style) []Grid
 	}
 	return gl
 }
+
+// GenerateMinorGridLines generates grid lines for the specified major and
+// minor ticks. Unlike GenerateGridLines, all the major ticks get major grid
+// lines, while the minor ticks get minor grid lines.
+func GenerateMinorGridLines(ticks, minorTicks []Tick, majorStyle, minorStyle render.Style) []GridLine {
+	var gl []GridLine
+	if len(ticks) >= 3 {
+		for _, t := range ticks[1 : len(ticks)-1] {
+			gl = append(gl, GridLine{
+				Style: majorStyle,
+				Value: t.Value,
+			})
+		}
+	}
+
+	for _, t := range minorTicks {
+		gl = append(gl, GridLine{
+			Style:   minorStyle,
+			IsMinor: true,
+			Value:   t.Value,
+		})
+	}
+	return gl
+}
@@ -38,7 +38,7 @@ func Legend(c *Chart, userDefaults ...render.Style) render.Renderable {
 		var lines []render.Style
 		for index, s := range c.Series {
 			if !s.GetStyle().Hidden {
-				if _, isAnnotationSeries := s.(dataset.AnnotationSeries); !isAnnotationSeries {
+				if !isAnnotationSeries(s) {
 					labels = append(labels, s.GetName())
 					lines = append(lines, s.GetStyle().InheritFrom(c.styleDefaultsSeries(index)))
 				}
@@ -64,7 +64,7 @@ func Legend(c *Chart, userDefaults ...render.Style) render.Renderable {
 		labelCount := 0
 		for x := 0; x < len(labels); x++ {
 			if len(labels[x]) > 0 {
-				tb := r.MeasureText(labels[x])
+				tb := render.Text.Measure(r, labels[x], legendStyle)
 				if labelCount > 0 {
 					legendContent.Bottom += defaultMinimumTickVerticalSpacing
 				}
@@ -93,10 +93,10 @@ func Legend(c *Chart, userDefaults ...render.Style) render.Renderable {
 					ycursor += defaultMinimumTickVerticalSpacing
 				}
 
-				tb := r.MeasureText(label)
+				tb := render.Text.Measure(r, label, legendStyle)
 
 				ty := ycursor + tb.Height()
-				r.Text(label, tx, ty)
+				render.Text.Draw(r, label, tx, ty, legendStyle)
 
 				th2 := tb.Height() >> 1
 
@@ -151,7 +151,7 @@ func LegendThin(c *Chart, userDefaults ...render.Style) render.Renderable {
 		var lines []render.Style
 		for index, s := range c.Series {
 			if !s.GetStyle().Hidden {
-				if _, isAnnotationSeries := s.(dataset.AnnotationSeries); !isAnnotationSeries {
+				if !isAnnotationSeries(s) {
 					labels = append(labels, s.GetName())
 					lines = append(lines, s.GetStyle().InheritFrom(c.styleDefaultsSeries(index)))
 				}
@@ -163,7 +163,7 @@ func LegendThin(c *Chart, userDefaults ...render.Style) render.Renderable {
 		var textBox render.Box
 		for x := 0; x < len(labels); x++ {
 			if len(labels[x]) > 0 {
-				textBox = r.MeasureText(labels[x])
+				textBox = render.Text.Measure(r, labels[x], legendStyle)
 				textHeight = mathutil.MaxInt(textBox.Height(), textHeight)
 				textWidth = mathutil.MaxInt(textBox.Width(), textWidth)
 			}
@@ -196,8 +196,8 @@ func LegendThin(c *Chart, userDefaults ...render.Style) render.Renderable {
 		for index := range labels {
 			label = labels[index]
 			if len(label) > 0 {
-				textBox = r.MeasureText(label)
-				r.Text(label, tx, ty)
+				textBox = render.Text.Measure(r, label, legendStyle)
+				render.Text.Draw(r, label, tx, ty, legendStyle)
 
 				lx = tx + textBox.Width() + lineTextGap
 				ly = ty - th2
@@ -248,7 +248,7 @@ func LegendLeft(c *Chart, userDefaults ...render.Style) render.Renderable {
 		var lines []render.Style
 		for index, s := range c.Series {
 			if !s.GetStyle().Hidden {
-				if _, isAnnotationSeries := s.(dataset.AnnotationSeries); !isAnnotationSeries {
+				if !isAnnotationSeries(s) {
 					labels = append(labels, s.GetName())
 					lines = append(lines, s.GetStyle().InheritFrom(c.styleDefaultsSeries(index)))
 				}
@@ -274,7 +274,7 @@ func LegendLeft(c *Chart, userDefaults ...render.Style) render.Renderable {
 		labelCount := 0
 		for x := 0; x < len(labels); x++ {
 			if len(labels[x]) > 0 {
-				tb := r.MeasureText(labels[x])
+				tb := render.Text.Measure(r, labels[x], legendStyle)
 				if labelCount > 0 {
 					legendContent.Bottom += defaultMinimumTickVerticalSpacing
 				}
@@ -303,10 +303,10 @@ func LegendLeft(c *Chart, userDefaults ...render.Style) render.Renderable {
 					ycursor += defaultMinimumTickVerticalSpacing
 				}
 
-				tb := r.MeasureText(label)
+				tb := render.Text.Measure(r, label, legendStyle)
 
 				ty := ycursor + tb.Height()
-				r.Text(label, tx, ty)
+				render.Text.Draw(r, label, tx, ty, legendStyle)
 
 				th2 := tb.Height() >> 1
 
@@ -328,3 +328,13 @@ func LegendLeft(c *Chart, userDefaults ...render.Style) render.Renderable {
 		}
 	}
 }
+
+// isAnnotationSeries returns true if the series draws annotations, which are
+// not listed in legends.
+func isAnnotationSeries(s dataset.Series) bool {
+	switch s.(type) {
+	case dataset.AnnotationSeries, dataset.AnnotationLayer, *dataset.AnnotationLayer:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+package mathutil
+
+import "math"
+
+const (
+	// betaMaxIterations is the maximum number of iterations used to
+	// evaluate the continued fraction of the incomplete beta function.
+	betaMaxIterations = 300
+
+	// betaEpsilon is the relative accuracy of the incomplete beta function.
+	betaEpsilon = 1e-14
+
+	// quantileIterations is the number of bisection iterations used to
+	// invert cumulative distribution functions.
+	quantileIterations = 200
+)
+
+// RegularizedIncompleteBeta returns the regularized incomplete beta function
+// I_x(a, b), for a, b > 0 and x in [0, 1].
+func RegularizedIncompleteBeta(a, b, x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+
+	lga, _ := math.Lgamma(a)
+	lgb, _ := math.Lgamma(b)
+	lgab, _ := math.Lgamma(a + b)
+	front := math.Exp(lgab - lga - lgb + a*math.Log(x) + b*math.Log(1-x))
+
+	// The continued fraction converges rapidly for x < (a+1)/(a+b+2). The
+	// symmetry relation is used otherwise.
+	if x < (a+1)/(a+b+2) {
+		return front * betaContinuedFraction(a, b, x) / a
+	}
+	return 1 - front*betaContinuedFraction(b, a, 1-x)/b
+}
+
+// betaContinuedFraction evaluates the continued fraction of the incomplete
+// beta function using the modified Lentz's method.
+func betaContinuedFraction(a, b, x float64) float64 {
+	const tiny = 1e-300
+
+	clamp := func(v float64) float64 {
+		if math.Abs(v) < tiny {
+			return tiny
+		}
+		return v
+	}
+
+	c := 1.0
+	d := 1 / clamp(1-(a+b)*x/(a+1))
+	h := d
+	for m := 1; m <= betaMaxIterations; m++ {
+		fm := float64(m)
+
+		// Even step.
+		aa := fm * (b - fm) * x / ((a + 2*fm - 1) * (a + 2*fm))
+		d = 1 / clamp(1+aa*d)
+		c = clamp(1 + aa/c)
+		h *= d * c
+
+		// Odd step.
+		aa = -(a + fm) * (a + b + fm) * x / ((a + 2*fm) * (a + 2*fm + 1))
+		d = 1 / clamp(1+aa*d)
+		c = clamp(1 + aa/c)
+		delta := d * c
+		h *= delta
+
+		if math.Abs(delta-1) < betaEpsilon {
+			break
+		}
+	}
+	return h
+}
+
+// StudentTCDF returns the cumulative distribution function of the Student's
+// t distribution with the specified degrees of freedom.
+func StudentTCDF(t, df float64) float64 {
+	if math.IsInf(t, 0) {
+		if t > 0 {
+			return 1
+		}
+		return 0
+	}
+
+	tail := 0.5 * RegularizedIncompleteBeta(df/2, 0.5, df/(df+t*t))
+	if t > 0 {
+		return 1 - tail
+	}
+	return tail
+}
+
+// StudentTQuantile returns the quantile function (inverse cumulative
+// distribution function) of the Student's t distribution with the specified
+// degrees of freedom, for a probability p in (0, 1).
+func StudentTQuantile(p, df float64) float64 {
+	if p <= 0 {
+		return math.Inf(-1)
+	}
+	if p >= 1 {
+		return math.Inf(1)
+	}
+
+	low, high := -1.0, 1.0
+	for StudentTCDF(low, df) > p {
+		low *= 2
+	}
+	for StudentTCDF(high, df) < p {
+		high *= 2
+	}
+
+	for i := 0; i < quantileIterations && high-low > 1e-12; i++ {
+		mid := (low + high) / 2
+		if StudentTCDF(mid, df) < p {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+	return (low + high) / 2
+}
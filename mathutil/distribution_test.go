@@ -0,0 +1,20 @@
+package mathutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStudentTCDF(t *testing.T) {
+	require.InDelta(t, 0.5, StudentTCDF(0, 5), 1e-12)
+	require.InDelta(t, 0.975, StudentTCDF(2.228138852, 10), 1e-8)
+	require.InDelta(t, 0.025, StudentTCDF(-2.228138852, 10), 1e-8)
+}
+
+func TestStudentTQuantile(t *testing.T) {
+	require.InDelta(t, 12.7062047, StudentTQuantile(0.975, 1), 1e-6)
+	require.InDelta(t, 3.18244631, StudentTQuantile(0.975, 3), 1e-7)
+	require.InDelta(t, 2.22813885, StudentTQuantile(0.975, 10), 1e-7)
+	require.InDelta(t, -1.64485363, StudentTQuantile(0.05, 1e6), 1e-5)
+}
@@ -70,6 +70,11 @@ func MinMax(values ...float64) (min, max float64) {
 	return
 }
 
+// IsFinite returns true if the value is neither NaN nor infinite.
+func IsFinite(value float64) bool {
+	return !math.IsNaN(value) && !math.IsInf(value, 0)
+}
+
 // DegreesToRadians returns degrees as radians.
 func DegreesToRadians(degrees float64) float64 {
 	return degrees * _d2r
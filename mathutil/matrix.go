@@ -227,17 +227,17 @@ func (m *Matrix) Row(row int) Vector {
 
 // SubMatrix returns a sub matrix from a given outer matrix.
 func (m *Matrix) SubMatrix(i, j, rows, cols int) *Matrix {
-	return &Matrix{
-		elements: m.elements[i*m.stride+j : i*m.stride+j+(rows-1)*m.stride+cols],
-		stride:   m.stride,
-		epsilon:  m.epsilon,
+	m2 := &Matrix{stride: cols, epsilon: m.epsilon, elements: make([]float64, rows*cols)}
+	for row := 0; row < rows; row++ {
+		copy(m2.elements[row*cols:(row+1)*cols], m.elements[(i+row)*m.stride+j:])
 	}
+	return m2
 }
 
 // ScaleRow applies a scale to an entire row.
 func (m *Matrix) ScaleRow(row int, scale float64) {
 	startIndex := row * m.stride
-	for i := startIndex; i < m.stride; i++ {
+	for i := startIndex; i < startIndex+m.stride; i++ {
 		m.elements[i] = m.elements[i] * scale
 	}
 }
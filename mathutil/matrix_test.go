@@ -342,3 +342,16 @@ func TestMatrixTranspose(t *testing.T) {
 	require.Equal(t, 10.0, m2.Get(0, 3))
 	require.Equal(t, 3.0, m2.Get(2, 0))
 }
+
+func TestMatrixInverse(t *testing.T) {
+	m := NewMatrix(2, 2, 5, 15, 15, 55)
+	inv, err := m.Inverse()
+	require.NoError(t, err)
+	require.InDelta(t, 1.1, inv.Get(0, 0), 1e-12)
+	require.InDelta(t, -0.3, inv.Get(0, 1), 1e-12)
+	require.InDelta(t, -0.3, inv.Get(1, 0), 1e-12)
+	require.InDelta(t, 0.1, inv.Get(1, 1), 1e-12)
+
+	sub := NewMatrix(3, 3, 1, 2, 3, 4, 5, 6, 7, 8, 9).SubMatrix(1, 1, 2, 2)
+	require.Equal(t, [][]float64{{5, 6}, {8, 9}}, sub.Arrays())
+}
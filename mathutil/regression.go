@@ -1,10 +1,21 @@
 package mathutil
 
-import "errors"
+import (
+	"errors"
+	"math"
+)
 
 var (
 	// ErrPolyRegArraysSameLength is a common error.
 	ErrPolyRegArraysSameLength = errors.New("polynomial array inputs must be the same length")
+
+	// ErrPolyRegNotEnoughValues is returned when there are fewer values than
+	// polynomial coefficients.
+	ErrPolyRegNotEnoughValues = errors.New("polynomial regression requires at least as many values as coefficients")
+
+	// ErrRegressionNonPositiveValue is returned when logarithmic values are
+	// required by a regression, but the input contains non-positive values.
+	ErrRegressionNonPositiveValue = errors.New("regression requires positive values")
 )
 
 // PolyRegression returns the polynomial regression of a given degree over
@@ -44,3 +55,205 @@ func PolyRegression(xvalues, yvalues []float64, degree int) ([]float64, error) {
 
 	return c, nil
 }
+
+// RegressionStats holds the fit statistics of a polynomial least squares
+// regression.
+type RegressionStats struct {
+	// Coefficients are the coefficients of the fitted polynomial, in
+	// increasing order of degree.
+	Coefficients []float64
+
+	// StandardErrors are the standard errors of the coefficients.
+	StandardErrors []float64
+
+	// RSquared is the coefficient of determination of the fit.
+	RSquared float64
+
+	// AdjustedRSquared is the coefficient of determination adjusted for the
+	// number of coefficients.
+	AdjustedRSquared float64
+
+	// ResidualStandardError is the estimated standard deviation of the
+	// residuals.
+	ResidualStandardError float64
+
+	// DegreesOfFreedom is the number of residual degrees of freedom, i.e.
+	// the number of values minus the number of coefficients.
+	DegreesOfFreedom int
+
+	// covariance is the unscaled covariance matrix of the coefficients.
+	covariance *Matrix
+}
+
+// PolyRegressionStats returns the polynomial regression of a given degree
+// over the given values, along with its fit statistics.
+func PolyRegressionStats(xvalues, yvalues []float64, degree int) (*RegressionStats, error) {
+	n := degree + 1
+	if len(xvalues) < n {
+		return nil, ErrPolyRegNotEnoughValues
+	}
+
+	coeffs, err := PolyRegression(xvalues, yvalues, degree)
+	if err != nil {
+		return nil, err
+	}
+
+	// Compute the inverse of the normal matrix (X^T X).
+	normal := ZeroMatrix(n, n)
+	for _, x := range xvalues {
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				normal.Set(i, j, normal.Get(i, j)+math.Pow(x, float64(i+j)))
+			}
+		}
+	}
+	covariance, err := normal.Inverse()
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &RegressionStats{
+		Coefficients:     coeffs,
+		DegreesOfFreedom: len(xvalues) - n,
+		covariance:       covariance,
+	}
+
+	mean := Mean(yvalues...)
+	var sse, sst float64
+	for i, x := range xvalues {
+		residual := yvalues[i] - stats.Predict(x)
+		sse += residual * residual
+		sst += (yvalues[i] - mean) * (yvalues[i] - mean)
+	}
+
+	stats.RSquared = 1
+	if sst > 0 {
+		stats.RSquared = 1 - sse/sst
+	}
+
+	stats.AdjustedRSquared = math.NaN()
+	stats.ResidualStandardError = math.NaN()
+	stats.StandardErrors = make([]float64, n)
+	if stats.DegreesOfFreedom > 0 {
+		dof := float64(stats.DegreesOfFreedom)
+		stats.AdjustedRSquared = 1 - (1-stats.RSquared)*float64(len(xvalues)-1)/dof
+		stats.ResidualStandardError = math.Sqrt(sse / dof)
+	}
+	for i := range stats.StandardErrors {
+		stats.StandardErrors[i] = stats.ResidualStandardError * math.Sqrt(covariance.Get(i, i))
+	}
+	return stats, nil
+}
+
+// Predict returns the value of the fitted polynomial at x.
+func (rs *RegressionStats) Predict(x float64) (y float64) {
+	for i := len(rs.Coefficients) - 1; i >= 0; i-- {
+		y = y*x + rs.Coefficients[i]
+	}
+	return y
+}
+
+// ConfidenceInterval returns the half width of the confidence interval of
+// the mean response at x, for the specified confidence level (e.g. 0.95).
+func (rs *RegressionStats) ConfidenceInterval(x, level float64) float64 {
+	return rs.interval(x, level, 0)
+}
+
+// PredictionInterval returns the half width of the prediction interval of a
+// new observation at x, for the specified confidence level (e.g. 0.95).
+func (rs *RegressionStats) PredictionInterval(x, level float64) float64 {
+	return rs.interval(x, level, 1)
+}
+
+func (rs *RegressionStats) interval(x, level, extra float64) float64 {
+	if rs.DegreesOfFreedom <= 0 || rs.covariance == nil {
+		return math.NaN()
+	}
+
+	// Compute the leverage x^T (X^T X)^-1 x of the point.
+	n := len(rs.Coefficients)
+	var leverage float64
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			leverage += math.Pow(x, float64(i)) * rs.covariance.Get(i, j) * math.Pow(x, float64(j))
+		}
+	}
+
+	t := StudentTQuantile(1-(1-level)/2, float64(rs.DegreesOfFreedom))
+	return t * rs.ResidualStandardError * math.Sqrt(extra+leverage)
+}
+
+// ExpRegression returns the coefficients of the exponential regression
+// y = a*e^(b*x) over the given values, fitted by linear least squares on
+// the logarithm of the Y values, which must be positive.
+func ExpRegression(xvalues, yvalues []float64) (a, b float64, err error) {
+	ly, err := logValues(yvalues)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	la, b, err := linearRegression(xvalues, ly)
+	return math.Exp(la), b, err
+}
+
+// LogRegression returns the coefficients of the logarithmic regression
+// y = a + b*ln(x) over the given values. The X values must be positive.
+func LogRegression(xvalues, yvalues []float64) (a, b float64, err error) {
+	lx, err := logValues(xvalues)
+	if err != nil {
+		return 0, 0, err
+	}
+	return linearRegression(lx, yvalues)
+}
+
+// PowerRegression returns the coefficients of the power law regression
+// y = a*x^b over the given values, fitted by linear least squares on the
+// logarithms of the values, which must be positive.
+func PowerRegression(xvalues, yvalues []float64) (a, b float64, err error) {
+	lx, err := logValues(xvalues)
+	if err != nil {
+		return 0, 0, err
+	}
+	ly, err := logValues(yvalues)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	la, b, err := linearRegression(lx, ly)
+	return math.Exp(la), b, err
+}
+
+// linearRegression returns the intercept and the slope of the linear least
+// squares regression over the given values.
+func linearRegression(xvalues, yvalues []float64) (intercept, slope float64, err error) {
+	if len(xvalues) != len(yvalues) {
+		return 0, 0, ErrPolyRegArraysSameLength
+	}
+	if len(xvalues) < 2 {
+		return 0, 0, ErrPolyRegNotEnoughValues
+	}
+
+	mx, my := Mean(xvalues...), Mean(yvalues...)
+	var sxx, sxy float64
+	for i, x := range xvalues {
+		sxx += (x - mx) * (x - mx)
+		sxy += (x - mx) * (yvalues[i] - my)
+	}
+	if sxx == 0 {
+		return 0, 0, errors.New("linear regression requires distinct x values")
+	}
+
+	slope = sxy / sxx
+	return my - slope*mx, slope, nil
+}
+
+func logValues(values []float64) ([]float64, error) {
+	logs := make([]float64, len(values))
+	for i, v := range values {
+		if v <= 0 {
+			return nil, ErrRegressionNonPositiveValue
+		}
+		logs[i] = math.Log(v)
+	}
+	return logs, nil
+}
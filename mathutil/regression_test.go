@@ -1,6 +1,7 @@
 package mathutil
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -19,3 +20,57 @@ func TestPoly(t *testing.T) {
 	require.InDelta(t, c[1], 2, defaultEpsilon)
 	require.InDelta(t, c[2], 3, defaultEpsilon)
 }
+
+func TestPolyRegressionStats(t *testing.T) {
+	xvalues := []float64{1, 2, 3, 4, 5}
+	yvalues := []float64{2.1, 3.9, 6.2, 7.8, 10.1}
+
+	stats, err := PolyRegressionStats(xvalues, yvalues, 1)
+	require.NoError(t, err)
+	require.Equal(t, 3, stats.DegreesOfFreedom)
+	require.InDelta(t, 0.05, stats.Coefficients[0], 1e-9)
+	require.InDelta(t, 1.99, stats.Coefficients[1], 1e-9)
+	require.InDelta(t, 0.99730533, stats.RSquared, 1e-8)
+	require.InDelta(t, 0.18885621, stats.ResidualStandardError, 1e-8)
+	require.InDelta(t, 0.19807406, stats.StandardErrors[0], 1e-8)
+	require.InDelta(t, 0.05972158, stats.StandardErrors[1], 1e-8)
+
+	require.InDelta(t, 6.02, stats.Predict(3), 1e-9)
+	require.InDelta(t, 0.26878643, stats.ConfidenceInterval(3, 0.95), 1e-7)
+	require.InDelta(t, 0.65838961, stats.PredictionInterval(3, 0.95), 1e-7)
+
+	_, err = PolyRegressionStats([]float64{1}, []float64{1}, 1)
+	require.Error(t, err)
+}
+
+func TestCurveRegressions(t *testing.T) {
+	xvalues := []float64{1, 2, 3, 4, 5}
+	exp := make([]float64, len(xvalues))
+	log := make([]float64, len(xvalues))
+	pow := make([]float64, len(xvalues))
+	for i, x := range xvalues {
+		exp[i] = 2 * math.Exp(0.5*x)
+		log[i] = 1 + 3*math.Log(x)
+		pow[i] = 2 * math.Pow(x, 1.5)
+	}
+
+	a, b, err := ExpRegression(xvalues, exp)
+	require.NoError(t, err)
+	require.InDelta(t, 2, a, 1e-9)
+	require.InDelta(t, 0.5, b, 1e-9)
+
+	a, b, err = LogRegression(xvalues, log)
+	require.NoError(t, err)
+	require.InDelta(t, 1, a, 1e-9)
+	require.InDelta(t, 3, b, 1e-9)
+
+	a, b, err = PowerRegression(xvalues, pow)
+	require.NoError(t, err)
+	require.InDelta(t, 2, a, 1e-9)
+	require.InDelta(t, 1.5, b, 1e-9)
+
+	_, _, err = ExpRegression(xvalues, []float64{1, 2, 0, 4, 5})
+	require.Equal(t, ErrRegressionNonPositiveValue, err)
+	_, _, err = LogRegression([]float64{-1, 2}, []float64{1, 2})
+	require.Equal(t, ErrRegressionNonPositiveValue, err)
+}
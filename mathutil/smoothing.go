@@ -0,0 +1,153 @@
+package mathutil
+
+import (
+	"math"
+	"sort"
+)
+
+// Loess returns the LOWESS (locally weighted scatterplot smoothing) fit of
+// the given values. Each fitted value is computed by a weighted linear
+// regression over the nearest `bandwidth` fraction of the values, using
+// tricube weights. Additional robustness iterations reduce the influence of
+// outliers. The fitted values are returned in the order of the input values.
+func Loess(xvalues, yvalues []float64, bandwidth float64, iterations int) ([]float64, error) {
+	if len(xvalues) != len(yvalues) {
+		return nil, ErrPolyRegArraysSameLength
+	}
+
+	n := len(xvalues)
+	fitted := make([]float64, n)
+	if n == 0 {
+		return fitted, nil
+	}
+
+	// Sort the values by X.
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return xvalues[order[i]] < xvalues[order[j]]
+	})
+	x := make([]float64, n)
+	y := make([]float64, n)
+	for i, index := range order {
+		x[i], y[i] = xvalues[index], yvalues[index]
+	}
+
+	span := int(math.Ceil(bandwidth * float64(n)))
+	span = MaxInt(MinInt(span, n), MinInt(2, n))
+
+	robustness := make([]float64, n)
+	for i := range robustness {
+		robustness[i] = 1
+	}
+
+	smoothed := make([]float64, n)
+	residuals := make([]float64, n)
+	for iteration := 0; iteration <= iterations; iteration++ {
+		left, right := 0, span-1
+		for i := 0; i < n; i++ {
+			// Slide the window of the nearest values.
+			for right+1 < n && x[i]-x[left] > x[right+1]-x[i] {
+				left++
+				right++
+			}
+			smoothed[i] = loessFit(x, y, robustness, i, left, right)
+		}
+
+		if iteration == iterations {
+			break
+		}
+
+		// Update the robustness weights from the residuals. Residuals within
+		// rounding errors of the fit are considered exact.
+		var scale float64
+		for i := range residuals {
+			residuals[i] = math.Abs(y[i] - smoothed[i])
+			scale = math.Max(scale, math.Abs(y[i]))
+		}
+		tolerance := 1e-12 * math.Max(1, scale)
+		for i, residual := range residuals {
+			if residual <= tolerance {
+				residuals[i] = 0
+			}
+		}
+		sorted := append([]float64{}, residuals...)
+		sort.Float64s(sorted)
+		if sorted[n-1] == 0 {
+			break
+		}
+		median := sorted[n/2]
+		if n%2 == 0 {
+			median = (sorted[n/2-1] + sorted[n/2]) / 2
+		}
+		for i, residual := range residuals {
+			// When most values are fitted exactly, the inexact ones are
+			// discarded as outliers.
+			u := math.Inf(1)
+			if median > 0 {
+				u = residual / (6 * median)
+			} else if residual == 0 {
+				u = 0
+			}
+			if u < 1 {
+				robustness[i] = (1 - u*u) * (1 - u*u)
+			} else {
+				robustness[i] = 0
+			}
+		}
+	}
+
+	for i, index := range order {
+		fitted[index] = smoothed[i]
+	}
+	return fitted, nil
+}
+
+// loessFit returns the weighted linear regression at the value of index i,
+// over the values within the specified window. Nil robustness weights are
+// considered to be 1.
+func loessFit(x, y, robustness []float64, i, left, right int) float64 {
+	h := math.Max(x[i]-x[left], x[right]-x[i])
+
+	// The regression is computed on the X offsets from x[i], so that large
+	// X values, such as times, do not lose precision.
+	var sw, swx, swy, swxx, swxy float64
+	for j := left; j <= right; j++ {
+		dx := x[j] - x[i]
+		w := 1.0
+		if robustness != nil {
+			w = robustness[j]
+		}
+		if h > 0 {
+			u := math.Abs(dx) / h
+			if u >= 1 {
+				continue
+			}
+			w *= (1 - u*u*u) * (1 - u*u*u) * (1 - u*u*u)
+		}
+
+		sw += w
+		swx += w * dx
+		swy += w * y[j]
+		swxx += w * dx * dx
+		swxy += w * dx * y[j]
+	}
+	if sw == 0 {
+		if robustness != nil {
+			// All the values in the window are outliers.
+			return loessFit(x, y, nil, i, left, right)
+		}
+		return y[i]
+	}
+
+	mx, my := swx/sw, swy/sw
+	variance := swxx/sw - mx*mx
+	if variance <= 1e-12*math.Max(h*h, mx*mx) {
+		return my
+	}
+
+	slope := (swxy/sw - mx*my) / variance
+	return my - slope*mx
+}
@@ -0,0 +1,31 @@
+package mathutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoess(t *testing.T) {
+	xvalues := []float64{5, 1, 3, 2, 4, 6, 7, 8, 9, 10}
+	yvalues := make([]float64, len(xvalues))
+	for i, x := range xvalues {
+		yvalues[i] = 2*x + 1
+	}
+
+	// A linear relationship is preserved, in the order of the input.
+	fitted, err := Loess(xvalues, yvalues, 0.5, 0)
+	require.NoError(t, err)
+	for i, x := range xvalues {
+		require.InDelta(t, 2*x+1, fitted[i], 1e-9)
+	}
+
+	// Robustness iterations discard outliers.
+	yvalues[4] = 100
+	fitted, err = Loess(xvalues, yvalues, 0.8, 3)
+	require.NoError(t, err)
+	require.InDelta(t, 9, fitted[4], 0.5)
+
+	_, err = Loess([]float64{1}, nil, 0.5, 0)
+	require.Error(t, err)
+}
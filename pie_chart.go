@@ -13,8 +13,12 @@ import (
 
 // PieChart is a chart that draws sections of a circle based on percentages.
 type PieChart struct {
-	Title      string
-	TitleStyle render.Style
+	Title         string
+	TitleStyle    render.Style
+	Subtitle      string
+	SubtitleStyle render.Style
+	Caption       string
+	CaptionStyle  render.Style
 
 	Font         render.Font
 	Background   render.Style
@@ -25,6 +29,10 @@ type PieChart struct {
 	Values   []dataset.Value
 	Elements []render.Renderable
 
+	// ValueFormatter, if set, labels the values without labels with
+	// their share of the total, such as dataset.PercentValueFormatter.
+	ValueFormatter dataset.ValueFormatter
+
 	width  int
 	height int
 	dpi    float64
@@ -89,7 +97,7 @@ func (pc *PieChart) Render(rp render.RendererProvider, w io.Writer) error {
 	}
 	r.SetDPI(pc.DPI(defaultDPI))
 
-	canvasBox := pc.getDefaultCanvasBox()
+	canvasBox := pc.getDefaultCanvasBox(r)
 	canvasBox = pc.getCircleAdjustedCanvasBox(canvasBox)
 
 	pc.drawBackground(r)
@@ -120,9 +128,12 @@ func (pc *PieChart) drawCanvas(r render.Renderer, canvasBox render.Box) {
 }
 
 func (pc *PieChart) drawTitle(r render.Renderer) {
-	if len(pc.Title) > 0 && !pc.TitleStyle.Hidden {
-		render.Text.DrawWithin(r, pc.Title, pc.Box(), pc.styleDefaultsTitle())
-	}
+	pc.getTitles().draw(r, pc.Box())
+}
+
+func (pc *PieChart) getTitles() chartTitles {
+	return newChartTitles(pc.styleDefaultsTitle(),
+		pc.Title, pc.TitleStyle, pc.Subtitle, pc.SubtitleStyle, pc.Caption, pc.CaptionStyle)
 }
 
 func (pc *PieChart) drawSlices(r render.Renderer, canvasBox render.Box, values []dataset.Value) {
@@ -158,29 +169,24 @@ func (pc *PieChart) drawSlices(r render.Renderer, canvasBox render.Box, values [
 	}
 
 	// Draw the labels.
+	var labels []valueLabel
 	total = 0
 	for index, v := range values {
-		v.Style.InheritFrom(pc.stylePieChartValue(index)).WriteToRenderer(r)
 		if len(v.Label) > 0 {
 			delta2 = mathutil.PercentToRadians(total + (v.Value / 2.0))
 			delta2 = mathutil.RadiansAdd(delta2, math.Pi/2.0)
 			lx, ly = mathutil.CirclePoint(cx, cy, labelRadius, delta2)
 
-			tb := r.MeasureText(v.Label)
-			lx = lx - (tb.Width() >> 1)
-			ly = ly + (tb.Height() >> 1)
-
-			if lx < 0 {
-				lx = 0
-			}
-			if ly < 0 {
-				lx = 0
-			}
-
-			r.Text(v.Label, lx, ly)
+			labels = append(labels, valueLabel{
+				text:  v.Label,
+				style: v.Style.InheritFrom(pc.stylePieChartValue(index)),
+				x:     lx,
+				y:     ly,
+			})
 		}
 		total = total + v.Value
 	}
+	drawValueLabels(r, canvasBox, labels)
 }
 
 func (pc *PieChart) finalizeValues(values []dataset.Value) ([]dataset.Value, error) {
@@ -188,11 +194,19 @@ func (pc *PieChart) finalizeValues(values []dataset.Value) ([]dataset.Value, err
 	if len(finalValues) == 0 {
 		return nil, fmt.Errorf("pie chart must contain at least (1) non-zero value")
 	}
+
+	if pc.ValueFormatter != nil {
+		for index, v := range finalValues {
+			if len(v.Label) == 0 {
+				finalValues[index].Label = pc.ValueFormatter(v.Value)
+			}
+		}
+	}
 	return finalValues, nil
 }
 
-func (pc *PieChart) getDefaultCanvasBox() render.Box {
-	return pc.Box()
+func (pc *PieChart) getDefaultCanvasBox(r render.Renderer) render.Box {
+	return pc.getTitles().adjust(r, pc.Box())
 }
 
 func (pc *PieChart) getCircleAdjustedCanvasBox(canvasBox render.Box) render.Box {
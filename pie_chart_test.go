@@ -0,0 +1,25 @@
+package unichart
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/unidoc/unichart/dataset"
+)
+
+func TestPieChartValueFormatter(t *testing.T) {
+	de, _ := dataset.GetLocale("de")
+	pc := PieChart{
+		Values: []dataset.Value{
+			{Value: 3},
+			{Value: 1, Label: "other"},
+		},
+		ValueFormatter: de.PercentValueFormatter(1),
+	}
+
+	values, err := pc.finalizeValues(pc.Values)
+	require.NoError(t, err)
+	require.Equal(t, "75,0 %", values[0].Label)
+	require.Equal(t, "other", values[1].Label)
+}
@@ -0,0 +1,323 @@
+package unichart
+
+import (
+	"errors"
+	"image/color"
+	"io"
+	"math"
+
+	"github.com/unidoc/unichart/mathutil"
+	"github.com/unidoc/unichart/render"
+)
+
+const (
+	// defaultProgressRingSpacing is the default spacing between the rings
+	// of multi-ring progress bars.
+	defaultProgressRingSpacing = 4
+
+	// defaultProgressRingsRatio is the default part of the radius of
+	// multi-ring progress bars covered by their rings. The rest is left for
+	// the center label.
+	defaultProgressRingsRatio = 0.6
+
+	// defaultProgressRingTrackAlpha is the alpha of the default track color
+	// of the rings of multi-ring progress bars.
+	defaultProgressRingTrackAlpha = 60
+)
+
+// ProgressRing is a ring of a multi-ring progress bar. The progress should
+// be between 0.0 - 1.0.
+type ProgressRing struct {
+	Label    string
+	Progress float64
+
+	// Style is the style of the progress of the ring.
+	Style render.Style
+
+	// TrackStyle is the style of the track under the progress of the ring.
+	TrackStyle render.Style
+}
+
+// MultiRingProgressBar is a component that renders concentric circular
+// progress bars, in the style of activity rings. The first ring is the
+// outermost one. The progress of each ring starts at the top of the
+// component, where the label of the ring is displayed.
+type MultiRingProgressBar struct {
+	// LabelStyle is the style for the labels of the rings.
+	LabelStyle render.Style
+
+	// CenterLabelStyle is the style for the label that will displayed in the center of the progress bar.
+	CenterLabelStyle render.Style
+
+	// ColorPalette is the color pallete that could be used to add colors in this progress bar.
+	ColorPalette render.ColorPalette
+
+	// Reversed is a flag where if the value is true then the progress bars would rendered counter clockwise.
+	Reversed bool
+
+	// RoundedEdgeStart is a flag to enable rounded edge at the start of the rings.
+	RoundedEdgeStart bool
+
+	// RoundedEdgeEnd is a flag to enable rounded edge at the end of the rings.
+	RoundedEdgeEnd bool
+
+	// RingWidth is the width of the rings. It defaults to the width of the
+	// rings filling a part of the radius of the component.
+	RingWidth int
+
+	// RingSpacing is the spacing between the rings.
+	RingSpacing int
+
+	Rings []ProgressRing
+
+	// size is the size of the progress bar in width and height.
+	size int
+	dpi  float64
+
+	// label is the text to be displayed in the center of the chart.
+	label string
+}
+
+// DPI returns the DPI of the progress bar.
+func (mp *MultiRingProgressBar) DPI() float64 {
+	if mp.dpi == 0 {
+		return defaultDPI
+	}
+	return mp.dpi
+}
+
+// SetDPI sets the DPI for the progress bar.
+func (mp *MultiRingProgressBar) SetDPI(dpi float64) {
+	mp.dpi = dpi
+}
+
+// Size returns the chart size or the default value.
+func (mp *MultiRingProgressBar) Size() int {
+	if mp.size == 0 {
+		return defaultChartWidth
+	}
+	return mp.size
+}
+
+// SetSize sets the chart size.
+func (mp *MultiRingProgressBar) SetSize(size int) {
+	mp.size = size
+}
+
+// SetLabel sets the label that would be displayed in the center of the progress bar.
+func (mp *MultiRingProgressBar) SetLabel(label string) {
+	mp.label = label
+}
+
+// GetLabel returns the label displayed in the center of the progress bar.
+func (mp *MultiRingProgressBar) GetLabel() string {
+	return mp.label
+}
+
+// Width returns the chart width.
+func (mp *MultiRingProgressBar) Width() int {
+	return mp.Size()
+}
+
+// SetWidth method is exists to fuifill the requirements of render.ChartRenderable interface.
+// To set width or height of this progress bar, use SetSize instead.
+func (mp *MultiRingProgressBar) SetWidth(width int) {
+}
+
+// Height returns the chart height.
+func (mp *MultiRingProgressBar) Height() int {
+	return mp.Size()
+}
+
+// SetHeight method is exists to fuifill the requirements of render.ChartRenderable interface.
+// To set width or height of this progress bar, use SetSize instead.
+func (mp *MultiRingProgressBar) SetHeight(height int) {
+}
+
+// GetRingSpacing returns the spacing between the rings.
+func (mp *MultiRingProgressBar) GetRingSpacing(defaults ...int) int {
+	if mp.RingSpacing == 0 {
+		if len(defaults) > 0 {
+			return defaults[0]
+		}
+		return defaultProgressRingSpacing
+	}
+	return mp.RingSpacing
+}
+
+// GetRingWidth returns the width of the rings.
+func (mp *MultiRingProgressBar) GetRingWidth() int {
+	if mp.RingWidth > 0 || len(mp.Rings) == 0 {
+		return mp.RingWidth
+	}
+
+	covered := float64(mp.Size()) / 2 * defaultProgressRingsRatio
+	return mathutil.MaxInt(int(covered)/len(mp.Rings)-mp.GetRingSpacing(), 1)
+}
+
+// GetColorPalette returns the color palette for the chart.
+func (mp *MultiRingProgressBar) GetColorPalette() render.ColorPalette {
+	if mp.ColorPalette != nil {
+		return mp.ColorPalette
+	}
+	return render.AlternateColorPalette
+}
+
+func (mp *MultiRingProgressBar) getRingStyle(index int) render.Style {
+	return mp.Rings[index].Style.InheritFrom(render.Style{
+		FillColor: mp.GetColorPalette().GetSeriesColor(index),
+	})
+}
+
+func (mp *MultiRingProgressBar) getTrackStyle(index int) render.Style {
+	trackColor := color.NRGBAModel.Convert(mp.getRingStyle(index).FillColor).(color.NRGBA)
+	trackColor.A = defaultProgressRingTrackAlpha
+
+	return mp.Rings[index].TrackStyle.InheritFrom(render.Style{
+		FillColor: trackColor,
+	})
+}
+
+func (mp *MultiRingProgressBar) getLabelStyle() render.Style {
+	return mp.LabelStyle.InheritFrom(render.Style{
+		FontSize:          math.Max(float64(mp.GetRingWidth())/2, render.DefaultFontSize/2),
+		FontColor:         mp.GetColorPalette().TextColor(),
+		TextVerticalAlign: render.TextVerticalAlignMiddle,
+	})
+}
+
+func (mp *MultiRingProgressBar) getCenterLabelStyle() render.Style {
+	return mp.CenterLabelStyle.InheritFrom(render.Style{
+		FontSize:            render.DefaultFontSize,
+		FontColor:           mp.GetColorPalette().TextColor(),
+		TextHorizontalAlign: render.TextHorizontalAlignCenter,
+		TextVerticalAlign:   render.TextVerticalAlignMiddle,
+	})
+}
+
+// getRingRadius returns the radius of the center line of the specified
+// ring.
+func (mp *MultiRingProgressBar) getRingRadius(index int) float64 {
+	width := mp.GetRingWidth()
+	return float64(mp.Size())/2 - float64(width)/2 - float64(index*(width+mp.GetRingSpacing()))
+}
+
+// drawRing draws the section of a ring, starting at the top, covering the
+// specified angle in radians. The direction of the section follows the
+// sign of the angle.
+func (mp *MultiRingProgressBar) drawRing(r render.Renderer, radius, width, delta float64, roundedStart, roundedEnd bool, style render.Style) {
+	if delta == 0 {
+		return
+	}
+
+	c := mp.Size() / 2
+	outer, inner := radius+width/2, radius-width/2
+	start := mathutil.DegreesToRadians(-90)
+	end := start + delta
+
+	// The rounded edges are half circles around the ends of the center
+	// line, bulging in the direction of the ring.
+	sweep := math.Copysign(math.Pi, delta)
+	ex, ey := mathutil.CirclePoint(c, c, radius, delta)
+
+	r.MoveTo(mathutil.CirclePoint(c, c, outer, 0))
+	r.ArcTo(c, c, outer, outer, start, delta)
+	if roundedEnd {
+		r.ArcTo(ex, ey, width/2, width/2, end, sweep)
+	} else {
+		r.LineTo(mathutil.CirclePoint(c, c, inner, delta))
+	}
+	r.ArcTo(c, c, inner, inner, end, -delta)
+	if roundedStart {
+		r.ArcTo(c, c-int(radius), width/2, width/2, start+math.Pi, sweep)
+	}
+	r.Close()
+
+	style.WriteToRenderer(r)
+	r.FillStroke()
+	r.ResetStyle()
+}
+
+func (mp *MultiRingProgressBar) drawRings(r render.Renderer) {
+	width := float64(mp.GetRingWidth())
+	for i, ring := range mp.Rings {
+		radius := mp.getRingRadius(i)
+		if radius-width/2 <= 0 {
+			break
+		}
+
+		if trackStyle := mp.getTrackStyle(i); !trackStyle.Hidden {
+			mp.drawRing(r, radius, width, 2*math.Pi, false, false, trackStyle)
+		}
+
+		progress := math.Max(0.0, math.Min(ring.Progress, 1.0))
+		delta := progress * 2 * math.Pi
+		if mp.Reversed {
+			delta = -delta
+		}
+
+		if ringStyle := mp.getRingStyle(i); !ringStyle.Hidden {
+			mp.drawRing(r, radius, width, delta, mp.RoundedEdgeStart, mp.RoundedEdgeEnd, ringStyle)
+		}
+	}
+}
+
+// drawLabels draws the labels of the rings next to their start, on the side
+// opposite to their progress.
+func (mp *MultiRingProgressBar) drawLabels(r render.Renderer) {
+	labelStyle := mp.getLabelStyle()
+	if labelStyle.Hidden {
+		return
+	}
+
+	c := mp.Size() / 2
+	gap := mp.GetRingWidth()/2 + mp.GetRingSpacing()
+	for i, ring := range mp.Rings {
+		if ring.Label == "" {
+			continue
+		}
+
+		y := c - int(mp.getRingRadius(i))
+		box := render.NewBox(y, 0, c-gap, y)
+		labelStyle.TextHorizontalAlign = render.TextHorizontalAlignRight
+		if mp.Reversed {
+			box = render.NewBox(y, c+gap, mp.Size(), y)
+			labelStyle.TextHorizontalAlign = render.TextHorizontalAlignLeft
+		}
+		render.Text.DrawWithin(r, ring.Label, box, labelStyle)
+	}
+}
+
+func (mp *MultiRingProgressBar) drawCenterLabel(r render.Renderer) {
+	labelStyle := mp.getCenterLabelStyle()
+	if labelStyle.Hidden || mp.label == "" || len(mp.Rings) == 0 {
+		return
+	}
+
+	inner := int(mp.getRingRadius(len(mp.Rings)-1)) - mp.GetRingWidth()/2
+	if inner <= 0 {
+		return
+	}
+
+	c := mp.Size() / 2
+	render.Text.DrawWithin(r, mp.label, render.NewBox(c-inner, c-inner, c+inner, c+inner), labelStyle)
+}
+
+// Render renders the progress bar with the given renderer to the given io.Writer.
+func (mp *MultiRingProgressBar) Render(rp render.RendererProvider, w io.Writer) error {
+	if len(mp.Rings) == 0 {
+		return errors.New("please provide at least one ring")
+	}
+
+	r, err := rp(mp.Size(), mp.Size())
+	if err != nil {
+		return err
+	}
+	r.SetDPI(mp.DPI())
+
+	mp.drawRings(r)
+	mp.drawLabels(r)
+	mp.drawCenterLabel(r)
+
+	return r.Save(w)
+}
@@ -0,0 +1,53 @@
+package unichart
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/unidoc/unichart/render/raster"
+)
+
+func TestMultiRingProgressBarRings(t *testing.T) {
+	mp := MultiRingProgressBar{
+		Rings: []ProgressRing{{Progress: 0.5}, {Progress: 0.25}, {Progress: 1}},
+	}
+	mp.SetSize(200)
+
+	// The rings fill a part of the radius by default.
+	require.Equal(t, 16, mp.GetRingWidth())
+	require.Equal(t, 92.0, mp.getRingRadius(0))
+	require.Equal(t, 72.0, mp.getRingRadius(1))
+	require.Equal(t, 52.0, mp.getRingRadius(2))
+
+	mp.RingWidth = 10
+	mp.RingSpacing = 2
+	require.Equal(t, 95.0, mp.getRingRadius(0))
+	require.Equal(t, 83.0, mp.getRingRadius(1))
+}
+
+func TestMultiRingProgressBarRender(t *testing.T) {
+	mp := MultiRingProgressBar{
+		RoundedEdgeStart: true,
+		RoundedEdgeEnd:   true,
+		Rings: []ProgressRing{
+			{Label: "build", Progress: 0.8},
+			{Label: "tests", Progress: 1.5},
+			{Label: "lint", Progress: 0},
+		},
+	}
+	mp.SetSize(200)
+	mp.SetLabel("label")
+
+	buf := bytes.NewBuffer(nil)
+	require.NoError(t, mp.Render(raster.NewRenderer, buf))
+	require.NotZero(t, buf.Len())
+
+	mp.Reversed = true
+	buf.Reset()
+	require.NoError(t, mp.Render(raster.NewRenderer, buf))
+	require.NotZero(t, buf.Len())
+
+	require.Error(t, (&MultiRingProgressBar{}).Render(raster.NewRenderer, buf))
+}
@@ -0,0 +1,374 @@
+package unichart
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/unidoc/unichart/dataset"
+	"github.com/unidoc/unichart/mathutil"
+	"github.com/unidoc/unichart/render"
+)
+
+const (
+	// defaultSegmentedProgressBarHeight is the default height of segmented
+	// progress bars, including their legend.
+	defaultSegmentedProgressBarHeight = 60
+
+	// defaultProgressLegendSpacing is the spacing between the bar and the
+	// legend of segmented progress bars, and between their legend items.
+	defaultProgressLegendSpacing = 10
+)
+
+// ProgressSegment is a component of a segmented progress bar, such as the
+// number of passed, failed or skipped tests of a build.
+type ProgressSegment struct {
+	Label string
+	Value float64
+	Style render.Style
+}
+
+// SegmentedProgressBar is a component that renders a linear progress bar
+// made of multiple stacked segments, and a legend of the segments below it.
+type SegmentedProgressBar struct {
+	// BackgroundStyle is the style for the background bar.
+	BackgroundStyle render.Style
+
+	// LegendStyle is the style for the legend under the progress bar.
+	LegendStyle render.Style
+
+	// ColorPalette is the color pallete that could be used to add colors in this progress bar.
+	ColorPalette render.ColorPalette
+
+	// RoundedEdgeStart is a flag to enable rounded edge at the start of the bar.
+	RoundedEdgeStart bool
+
+	// RoundedEdgeEnd is a flag to enable rounded edge at the end of the bar.
+	RoundedEdgeEnd bool
+
+	// Segments are drawn from the start of the bar, in order. Segments with
+	// non-positive values are not drawn, but are listed in the legend.
+	Segments []ProgressSegment
+
+	// Total is the value represented by the whole bar. If not set, the
+	// total of the segments fills the bar.
+	Total float64
+
+	// ValueFormatter, if set, adds the formatted values of the segments to
+	// their legend labels.
+	ValueFormatter dataset.ValueFormatter
+
+	width  int
+	height int
+	dpi    float64
+}
+
+// DPI returns the DPI of the progress bar.
+func (sp *SegmentedProgressBar) DPI() float64 {
+	if sp.dpi == 0 {
+		return defaultDPI
+	}
+	return sp.dpi
+}
+
+// SetDPI sets the DPI for the progress bar.
+func (sp *SegmentedProgressBar) SetDPI(dpi float64) {
+	sp.dpi = dpi
+}
+
+// Width returns the chart width or the default value.
+func (sp *SegmentedProgressBar) Width() int {
+	if sp.width == 0 {
+		return defaultChartWidth
+	}
+	return sp.width
+}
+
+// SetWidth sets the chart width.
+func (sp *SegmentedProgressBar) SetWidth(width int) {
+	sp.width = width
+}
+
+// Height returns the chart height, including the legend, or the default
+// value.
+func (sp *SegmentedProgressBar) Height() int {
+	if sp.height == 0 {
+		return defaultSegmentedProgressBarHeight
+	}
+	return sp.height
+}
+
+// SetHeight sets the chart height, including the legend.
+func (sp *SegmentedProgressBar) SetHeight(height int) {
+	sp.height = height
+}
+
+// GetTotal returns the value represented by the whole bar.
+func (sp *SegmentedProgressBar) GetTotal() float64 {
+	if sp.Total > 0 {
+		return sp.Total
+	}
+
+	var total float64
+	for _, s := range sp.Segments {
+		if s.Value > 0 {
+			total += s.Value
+		}
+	}
+	return total
+}
+
+// GetColorPalette returns the color palette for the chart.
+func (sp *SegmentedProgressBar) GetColorPalette() render.ColorPalette {
+	if sp.ColorPalette != nil {
+		return sp.ColorPalette
+	}
+	return render.AlternateColorPalette
+}
+
+func (sp *SegmentedProgressBar) getBackgroundStyle() render.Style {
+	return sp.BackgroundStyle.InheritFrom(sp.styleDefaultsBackground())
+}
+
+func (sp *SegmentedProgressBar) styleDefaultsBackground() render.Style {
+	return render.Style{
+		FillColor: render.ColorLightGray,
+	}
+}
+
+func (sp *SegmentedProgressBar) getSegmentStyle(index int) render.Style {
+	return sp.Segments[index].Style.InheritFrom(render.Style{
+		FillColor: sp.GetColorPalette().GetSeriesColor(index),
+	})
+}
+
+func (sp *SegmentedProgressBar) getLegendStyle() render.Style {
+	return sp.LegendStyle.InheritFrom(render.Style{
+		FontSize:  render.DefaultFontSize,
+		FontColor: sp.GetColorPalette().TextColor(),
+	})
+}
+
+// getSegmentExtents returns the start and end positions of the segments
+// along a bar of the specified width. Segments beyond the total are cut to
+// the end of the bar.
+func (sp *SegmentedProgressBar) getSegmentExtents(width int) [][2]int {
+	extents := make([][2]int, len(sp.Segments))
+
+	total := sp.GetTotal()
+	if total <= 0 {
+		return extents
+	}
+
+	var cursor float64
+	for i, s := range sp.Segments {
+		start := int(math.Round(float64(width) * math.Min(cursor/total, 1)))
+		if s.Value > 0 {
+			cursor += s.Value
+		}
+		extents[i] = [2]int{start, int(math.Round(float64(width) * math.Min(cursor/total, 1)))}
+	}
+	return extents
+}
+
+// getLegendLabel returns the legend label of the specified segment.
+func (sp *SegmentedProgressBar) getLegendLabel(s ProgressSegment) string {
+	if sp.ValueFormatter == nil {
+		return s.Label
+	}
+	return fmt.Sprintf("%s (%s)", s.Label, sp.ValueFormatter(s.Value))
+}
+
+// getLegendItems returns the boxes of the swatches and labels of the legend,
+// wrapped to the width of the chart, starting at the specified top.
+func (sp *SegmentedProgressBar) getLegendItems(r render.Renderer, top int) (swatches, labels []render.Box) {
+	legendStyle := sp.getLegendStyle()
+	if legendStyle.Hidden {
+		return nil, nil
+	}
+
+	x, y := 0, top
+	for _, s := range sp.Segments {
+		if s.Label == "" {
+			continue
+		}
+
+		tb := render.Text.Measure(r, sp.getLegendLabel(s), legendStyle)
+		size := tb.Height()
+		itemWidth := size + size/2 + tb.Width()
+		if x > 0 && x+itemWidth > sp.Width() {
+			x, y = 0, y+size+size/2
+		}
+
+		swatches = append(swatches, render.NewBox(y, x, x+size, y+size))
+		labels = append(labels, render.NewBox(y, x+size+size/2, x+itemWidth, y+size))
+		x += itemWidth + defaultProgressLegendSpacing
+	}
+	return swatches, labels
+}
+
+// getLegendHeight returns the height of the legend, including its spacing
+// from the bar.
+func (sp *SegmentedProgressBar) getLegendHeight(r render.Renderer) int {
+	_, labels := sp.getLegendItems(r, 0)
+	if len(labels) == 0 {
+		return 0
+	}
+	return labels[len(labels)-1].Bottom + defaultProgressLegendSpacing
+}
+
+func (sp *SegmentedProgressBar) drawBackground(r render.Renderer, height int) {
+	bgStyle := sp.getBackgroundStyle()
+	if bgStyle.Hidden {
+		return
+	}
+
+	box := render.NewBox(0, 0, sp.Width(), height)
+	drawRoundedBar(r, box, box.Left, box.Right, sp.RoundedEdgeStart, sp.RoundedEdgeEnd, bgStyle)
+}
+
+func (sp *SegmentedProgressBar) drawSegments(r render.Renderer, height int) {
+	extents := sp.getSegmentExtents(sp.Width())
+
+	// The segments are sections of a bar filled up to the end of the last
+	// segment, whose edges are rounded.
+	var filled int
+	for _, e := range extents {
+		filled = mathutil.MaxInt(filled, e[1])
+	}
+	box := render.NewBox(0, 0, filled, height)
+
+	for i, e := range extents {
+		segmentStyle := sp.getSegmentStyle(i)
+		if e[1] <= e[0] || segmentStyle.Hidden {
+			continue
+		}
+		drawRoundedBar(r, box, e[0], e[1], sp.RoundedEdgeStart, sp.RoundedEdgeEnd, segmentStyle)
+	}
+}
+
+func (sp *SegmentedProgressBar) drawLegend(r render.Renderer, top int) {
+	swatches, labels := sp.getLegendItems(r, top)
+
+	var index int
+	legendStyle := sp.getLegendStyle()
+	for i, s := range sp.Segments {
+		if s.Label == "" {
+			continue
+		}
+
+		swatchStyle := sp.getSegmentStyle(i)
+		swatchStyle.StrokeWidth = 0
+		swatches[index].Draw(r, swatchStyle)
+
+		render.Text.Draw(r, sp.getLegendLabel(s), labels[index].Left, labels[index].Bottom, legendStyle)
+		index++
+	}
+}
+
+// drawRoundedBar draws the section between the specified horizontal
+// positions of a bar filling the specified box, with the specified rounded
+// edges. The edges are rounded by up to half the height of the bar.
+func drawRoundedBar(r render.Renderer, box render.Box, from, to int, roundedStart, roundedEnd bool, style render.Style) {
+	if to <= from {
+		return
+	}
+
+	radius := math.Min(float64(box.Height()), float64(box.Width())) / 2
+	cy := float64(box.Top) + float64(box.Height())/2
+	x0, x1 := float64(from), float64(to)
+
+	// The centers of the rounded edges. Sections of the bar overlapping the
+	// rounded edges follow their outline.
+	sx, ex := float64(box.Left), float64(box.Right)
+	if roundedStart {
+		sx += radius
+	}
+	if roundedEnd {
+		ex -= radius
+	}
+
+	// angle returns the angle of the point of the outline of a rounded edge
+	// at the specified position, on its top or bottom half.
+	angle := func(x, cx float64, top bool) float64 {
+		a := math.Acos(math.Max(-1, math.Min((x-cx)/radius, 1)))
+		if top {
+			return 2*math.Pi - a
+		}
+		return a
+	}
+	outlineY := func(x float64, top bool) int {
+		var dy float64
+		if x < sx {
+			dy = math.Sqrt(math.Max(radius*radius-(x-sx)*(x-sx), 0))
+		} else if x > ex {
+			dy = math.Sqrt(math.Max(radius*radius-(x-ex)*(x-ex), 0))
+		} else {
+			dy = float64(box.Height()) / 2
+		}
+		if top {
+			return int(cy - dy)
+		}
+		return int(cy + dy)
+	}
+
+	r.MoveTo(from, outlineY(x0, true))
+	if x0 < sx {
+		a0 := angle(x0, sx, true)
+		r.ArcTo(int(sx), int(cy), radius, radius, a0, angle(math.Min(x1, sx), sx, true)-a0)
+	}
+	if x1 > sx && x0 < ex {
+		r.LineTo(int(math.Min(x1, ex)), box.Top)
+	}
+	if x1 > ex {
+		a0 := angle(math.Max(x0, ex), ex, true)
+		r.ArcTo(int(ex), int(cy), radius, radius, a0, angle(x1, ex, true)-a0)
+	}
+
+	r.LineTo(to, outlineY(x1, false))
+	if x1 > ex {
+		a0 := angle(x1, ex, false)
+		r.ArcTo(int(ex), int(cy), radius, radius, a0, angle(math.Max(x0, ex), ex, false)-a0)
+	}
+	if x1 > sx && x0 < ex {
+		r.LineTo(int(math.Max(x0, sx)), box.Bottom)
+	}
+	if x0 < sx {
+		a0 := angle(math.Min(x1, sx), sx, false)
+		r.ArcTo(int(sx), int(cy), radius, radius, a0, angle(x0, sx, false)-a0)
+	}
+	r.Close()
+
+	style.WriteToRenderer(r)
+	r.FillStroke()
+	r.ResetStyle()
+}
+
+// Render renders the progress bar with the given renderer to the given io.Writer.
+func (sp *SegmentedProgressBar) Render(rp render.RendererProvider, w io.Writer) error {
+	if len(sp.Segments) == 0 {
+		return errors.New("please provide at least one segment")
+	}
+
+	r, err := rp(sp.Width(), sp.Height())
+	if err != nil {
+		return err
+	}
+	r.SetDPI(sp.DPI())
+
+	// The bar fills the height left by the legend.
+	legendHeight := sp.getLegendHeight(r)
+	height := sp.Height() - legendHeight
+	if height <= 0 {
+		return errors.New("invalid height; no space left for the bar")
+	}
+
+	sp.drawBackground(r, height)
+	sp.drawSegments(r, height)
+	if legendHeight > 0 {
+		sp.drawLegend(r, height+defaultProgressLegendSpacing)
+	}
+
+	return r.Save(w)
+}
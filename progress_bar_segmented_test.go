@@ -0,0 +1,89 @@
+package unichart
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/unidoc/unichart/dataset"
+	"github.com/unidoc/unichart/render/raster"
+	"github.com/unidoc/unichart/render/svg"
+)
+
+func TestSegmentedProgressBarExtents(t *testing.T) {
+	sp := SegmentedProgressBar{
+		Segments: []ProgressSegment{
+			{Label: "passed", Value: 6},
+			{Label: "failed", Value: -1},
+			{Label: "skipped", Value: 2},
+		},
+	}
+
+	// The total of the segments fills the bar by default.
+	require.Equal(t, 8.0, sp.GetTotal())
+	require.Equal(t, [][2]int{{0, 75}, {75, 75}, {75, 100}}, sp.getSegmentExtents(100))
+
+	sp.Total = 10
+	require.Equal(t, [][2]int{{0, 60}, {60, 60}, {60, 80}}, sp.getSegmentExtents(100))
+
+	// Segments beyond the total are cut to the end of the bar.
+	sp.Total = 7
+	require.Equal(t, [][2]int{{0, 86}, {86, 86}, {86, 100}}, sp.getSegmentExtents(100))
+}
+
+func TestSegmentedProgressBarRender(t *testing.T) {
+	sp := SegmentedProgressBar{
+		RoundedEdgeStart: true,
+		RoundedEdgeEnd:   true,
+		Total:            20,
+		ValueFormatter:   dataset.IntValueFormatter,
+		Segments: []ProgressSegment{
+			{Label: "passed", Value: 12},
+			{Label: "failed", Value: 3},
+			{Label: "skipped", Value: 1},
+		},
+	}
+	require.Equal(t, "failed (3)", sp.getLegendLabel(sp.Segments[1]))
+
+	buf := bytes.NewBuffer(nil)
+	require.NoError(t, sp.Render(raster.NewRenderer, buf))
+	require.NotZero(t, buf.Len())
+
+	// The legend wraps to the width of the bar, leaving less space for it.
+	r, err := raster.NewRenderer(sp.Width(), sp.Height())
+	require.NoError(t, err)
+	height := sp.getLegendHeight(r)
+
+	sp.SetWidth(100)
+	require.Greater(t, sp.getLegendHeight(r), height)
+
+	sp.SetHeight(10)
+	require.Error(t, sp.Render(raster.NewRenderer, buf))
+	require.Error(t, (&SegmentedProgressBar{}).Render(raster.NewRenderer, buf))
+}
+
+func TestSegmentedProgressBarRichTextLegend(t *testing.T) {
+	sp := SegmentedProgressBar{
+		Segments: []ProgressSegment{
+			{Label: "<b>passed</b>", Value: 6},
+			{Label: "failed", Value: 2},
+		},
+	}
+
+	// The legend labels are measured without their markup.
+	r, err := raster.NewRenderer(sp.Width(), sp.Height())
+	require.NoError(t, err)
+	_, labels := sp.getLegendItems(r, 0)
+	require.Len(t, labels, 2)
+
+	plain := sp
+	plain.Segments = []ProgressSegment{{Label: "passed", Value: 6}, {Label: "failed", Value: 2}}
+	_, plainLabels := plain.getLegendItems(r, 0)
+	require.InDelta(t, plainLabels[0].Width(), labels[0].Width(), float64(plainLabels[0].Width())/4)
+
+	buf := bytes.NewBuffer(nil)
+	require.NoError(t, sp.Render(svg.NewRenderer, buf))
+	require.NotContains(t, buf.String(), "&lt;b&gt;")
+	require.Contains(t, buf.String(), "passed")
+}
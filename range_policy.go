@@ -0,0 +1,136 @@
+package unichart
+
+import (
+	"math"
+
+	"github.com/unidoc/unichart/dataset/sequence"
+	"github.com/unidoc/unichart/mathutil"
+)
+
+// RangeRounding specifies if the bounds of an axis range are rounded to nice
+// values.
+type RangeRounding int
+
+const (
+	// RangeRoundingUnset uses the default rounding of the axis.
+	RangeRoundingUnset RangeRounding = iota
+
+	// RangeRoundingNice rounds the bounds of the range, and extends them to
+	// the first and last ticks.
+	RangeRoundingNice
+
+	// RangeRoundingNone keeps the bounds of the range. Ticks outside of the
+	// range are left out.
+	RangeRoundingNone
+)
+
+// RangePolicy adjusts the range of an axis derived from the data, before
+// ticks are generated. Ranges set explicitly, or through the ticks of the
+// axis, are not adjusted. The adjustments are applied in the order of the
+// fields.
+type RangePolicy struct {
+	// MinDelta is the minimum delta of the range. Smaller ranges, such as
+	// the ones of flat data, are extended around their center.
+	MinDelta float64
+
+	// IncludeZero extends the range to include zero.
+	IncludeZero bool
+
+	// Symmetric extends the range to be symmetric around zero.
+	Symmetric bool
+
+	// PadPercent pads both ends of the range by a percentage of its delta.
+	PadPercent float64
+
+	// Rounding specifies if the bounds are rounded to nice values.
+	Rounding RangeRounding
+
+	// ClampMin and ClampMax limit the bounds of the range, if set.
+	ClampMin *float64
+	ClampMax *float64
+}
+
+// rounding returns if the bounds derived from the data are rounded, and if
+// the range is extended to its ticks. Unset rounding uses the specified
+// default for the bounds, and extends the range to its ticks.
+func (rp RangePolicy) rounding(roundsByDefault bool) (round, snap bool) {
+	switch rp.Rounding {
+	case RangeRoundingNice:
+		return true, true
+	case RangeRoundingNone:
+		return false, false
+	}
+	return roundsByDefault, true
+}
+
+// apply returns the bounds of a range derived from the data, adjusted by
+// the policy. The bounds are rounded if specified.
+func (rp RangePolicy) apply(min, max float64, round bool) (float64, float64) {
+	if min > max {
+		return min, max
+	}
+
+	if max-min < rp.MinDelta {
+		center := (min + max) / 2
+		min, max = center-rp.MinDelta/2, center+rp.MinDelta/2
+	}
+	if rp.IncludeZero {
+		min, max = math.Min(min, 0), math.Max(max, 0)
+	}
+	if rp.Symmetric {
+		bound := math.Max(math.Abs(min), math.Abs(max))
+		min, max = -bound, bound
+	}
+	if rp.PadPercent > 0 {
+		padding := (max - min) * rp.PadPercent / 100
+		min, max = min-padding, max+padding
+	}
+	if round {
+		roundTo := mathutil.RoundTo(max - min)
+		min, max = mathutil.RoundDown(min, roundTo), mathutil.RoundUp(max, roundTo)
+	}
+	return rp.clamp(min, max)
+}
+
+// clamp limits the specified bounds to the clamping bounds of the policy.
+func (rp RangePolicy) clamp(min, max float64) (float64, float64) {
+	if rp.ClampMin != nil {
+		min = math.Max(min, *rp.ClampMin)
+	}
+	if rp.ClampMax != nil {
+		max = math.Min(max, *rp.ClampMax)
+	}
+	return min, max
+}
+
+// isClamped returns true if the policy limits the bounds of the range.
+func (rp RangePolicy) isClamped() bool {
+	return rp.ClampMin != nil || rp.ClampMax != nil
+}
+
+// filterTicks returns the ticks within the range, if the range is not
+// extended to its ticks.
+func (rp RangePolicy) filterTicks(ra sequence.Range, ticks []Tick) []Tick {
+	if _, snap := rp.rounding(false); snap || ra == nil {
+		return ticks
+	}
+	return ticksWithin(ra, ticks)
+}
+
+// fitRange extends the range to its ticks, unless prevented by the policy,
+// and returns the ticks within the resulting range.
+func (rp RangePolicy) fitRange(ra sequence.Range, ticks []Tick) []Tick {
+	if _, snap := rp.rounding(false); !snap || ra == nil {
+		return ticks
+	}
+
+	setRangeToTicks(ra, ticks)
+	if !rp.isClamped() {
+		return ticks
+	}
+
+	min, max := rp.clamp(ra.GetMin(), ra.GetMax())
+	ra.SetMin(min)
+	ra.SetMax(max)
+	return ticksWithin(ra, ticks)
+}
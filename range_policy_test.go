@@ -0,0 +1,92 @@
+package unichart
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/unidoc/unichart/dataset"
+	"github.com/unidoc/unichart/dataset/sequence"
+)
+
+func TestRangePolicyApply(t *testing.T) {
+	testCases := []struct {
+		policy   RangePolicy
+		round    bool
+		min, max float64
+	}{
+		{RangePolicy{}, false, 3, 7},
+		{RangePolicy{}, true, 3, 7},
+		{RangePolicy{IncludeZero: true}, false, 0, 7},
+		{RangePolicy{Symmetric: true}, false, -7, 7},
+		{RangePolicy{PadPercent: 25}, false, 2, 8},
+		{RangePolicy{MinDelta: 10}, false, 0, 10},
+		{RangePolicy{ClampMin: floatPtr(4), ClampMax: floatPtr(6)}, false, 4, 6},
+		{RangePolicy{IncludeZero: true, PadPercent: 10}, false, -0.7, 7.7},
+	}
+
+	for _, tc := range testCases {
+		min, max := tc.policy.apply(3, 7, tc.round)
+		require.InDelta(t, tc.min, min, 1e-9, "%+v", tc.policy)
+		require.InDelta(t, tc.max, max, 1e-9, "%+v", tc.policy)
+	}
+
+	// Flat data is extended around its value.
+	min, max := RangePolicy{MinDelta: 2}.apply(5, 5, false)
+	require.Equal(t, 4.0, min)
+	require.Equal(t, 6.0, max)
+}
+
+func TestRangePolicyTicks(t *testing.T) {
+	ticks := []Tick{{Value: 0}, {Value: 5}, {Value: 10}}
+
+	// Ranges are extended to their ticks by default.
+	ra := &sequence.ContinuousRange{Min: 1, Max: 9}
+	require.Equal(t, ticks, RangePolicy{}.filterTicks(ra, ticks))
+	require.Equal(t, ticks, RangePolicy{}.fitRange(ra, ticks))
+	require.Equal(t, 0.0, ra.GetMin())
+	require.Equal(t, 10.0, ra.GetMax())
+
+	// Ranges without rounding keep their bounds.
+	ra = &sequence.ContinuousRange{Min: 1, Max: 9}
+	policy := RangePolicy{Rounding: RangeRoundingNone}
+	require.Equal(t, ticks[1:2], policy.filterTicks(ra, ticks))
+	policy.fitRange(ra, ticks)
+	require.Equal(t, 1.0, ra.GetMin())
+	require.Equal(t, 9.0, ra.GetMax())
+
+	// Clamped ranges are not extended past their bounds.
+	ra = &sequence.ContinuousRange{Min: 1, Max: 9}
+	policy = RangePolicy{ClampMax: floatPtr(9)}
+	require.Equal(t, ticks[:2], policy.fitRange(ra, ticks))
+	require.Equal(t, 0.0, ra.GetMin())
+	require.Equal(t, 9.0, ra.GetMax())
+}
+
+func TestChartRangePolicies(t *testing.T) {
+	c := Chart{
+		XAxis: XAxis{RangePolicy: RangePolicy{PadPercent: 10}},
+		YAxis: YAxis{RangePolicy: RangePolicy{IncludeZero: true, Rounding: RangeRoundingNone}},
+		Series: []dataset.Series{
+			dataset.ContinuousSeries{XValues: []float64{0, 10}, YValues: []float64{12, 17}},
+		},
+	}
+
+	xr, _, yr, _, _ := c.getRanges()
+	require.Equal(t, -1.0, xr.GetMin())
+	require.Equal(t, 11.0, xr.GetMax())
+	require.Equal(t, 0.0, yr.GetMin())
+	require.Equal(t, 17.0, yr.GetMax())
+
+	bc := BarChart{
+		YAxis: YAxis{RangePolicy: RangePolicy{MinDelta: 4, IncludeZero: true}},
+		Bars:  []dataset.Value{{Value: 5}, {Value: 5}},
+	}
+	yr = bc.getRanges()
+	require.Equal(t, 0.0, yr.GetMin())
+	require.Equal(t, 7.0, yr.GetMax())
+}
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
@@ -0,0 +1,31 @@
+package render
+
+import "math"
+
+// DrawErrorBar draws a horizontal or vertical error bar between two points,
+// using the error bar options of the specified style. Caps are drawn
+// perpendicular to the bar, at both of its ends.
+func DrawErrorBar(r Renderer, x0, y0, x1, y1 int, s Style) {
+	s.GetErrorBarOptions().WriteDrawingOptionsToRenderer(r)
+	defer r.ResetStyle()
+
+	r.MoveTo(x0, y0)
+	r.LineTo(x1, y1)
+	r.Stroke()
+
+	half := int(math.Round(s.GetErrorBarCapWidth() / 2))
+	if half <= 0 {
+		return
+	}
+
+	for _, p := range [][2]int{{x0, y0}, {x1, y1}} {
+		if x0 == x1 {
+			r.MoveTo(p[0]-half, p[1])
+			r.LineTo(p[0]+half, p[1])
+		} else {
+			r.MoveTo(p[0], p[1]-half)
+			r.LineTo(p[0], p[1]+half)
+		}
+		r.Stroke()
+	}
+}
@@ -0,0 +1,139 @@
+package render
+
+import (
+	"math"
+	"sort"
+
+	"github.com/unidoc/unichart/mathutil"
+)
+
+// PlaceLabels resolves the overlaps between the specified label boxes by
+// nudging them vertically, while keeping them as close as possible to their
+// original positions. Labels are never moved horizontally, and only labels
+// with overlapping horizontal extents are stacked together. The labels are
+// kept within the top and bottom of the bounds, unless the bounds are zero or
+// too small to fit them. The returned boxes are in the same order as the
+// specified ones.
+func PlaceLabels(boxes []Box, bounds Box, spacing int) []Box {
+	placed := make([]Box, len(boxes))
+	copy(placed, boxes)
+
+	for _, column := range labelColumns(boxes) {
+		placeLabelColumn(placed, column, bounds, spacing)
+	}
+	return placed
+}
+
+// labelCluster is a group of labels stacked on top of each other.
+type labelCluster struct {
+	indices []int
+	top     int
+	height  int
+
+	// targets is the sum of the tops the cluster would have in order to
+	// keep each of its labels at the original position.
+	targets float64
+}
+
+// labelColumns groups the indices of the boxes with horizontally
+// overlapping extents.
+func labelColumns(boxes []Box) [][]int {
+	parents := make([]int, len(boxes))
+	for i := range parents {
+		parents[i] = i
+	}
+
+	var find func(i int) int
+	find = func(i int) int {
+		if parents[i] != i {
+			parents[i] = find(parents[i])
+		}
+		return parents[i]
+	}
+
+	for i := range boxes {
+		for j := i + 1; j < len(boxes); j++ {
+			if boxes[i].Left <= boxes[j].Right && boxes[j].Left <= boxes[i].Right {
+				parents[find(j)] = find(i)
+			}
+		}
+	}
+
+	var columns [][]int
+	columnIndices := map[int]int{}
+	for i := range boxes {
+		root := find(i)
+		ci, ok := columnIndices[root]
+		if !ok {
+			ci = len(columns)
+			columnIndices[root] = ci
+			columns = append(columns, nil)
+		}
+		columns[ci] = append(columns[ci], i)
+	}
+	return columns
+}
+
+// placeLabelColumn stacks the overlapping labels of a column. Overlapping
+// labels are merged into clusters, positioned at the average of the
+// original positions of their labels.
+func placeLabelColumn(placed []Box, indices []int, bounds Box, spacing int) {
+	center := func(i int) int {
+		return placed[i].Top + placed[i].Height()>>1
+	}
+	sort.SliceStable(indices, func(i, j int) bool {
+		return center(indices[i]) < center(indices[j])
+	})
+
+	clusters := make([]labelCluster, len(indices))
+	for i, index := range indices {
+		clusters[i] = labelCluster{
+			indices: []int{index},
+			top:     placed[index].Top,
+			height:  placed[index].Height(),
+			targets: float64(placed[index].Top),
+		}
+		clusters[i].clamp(bounds)
+	}
+
+	for i := 1; i < len(clusters); {
+		prev, cur := clusters[i-1], clusters[i]
+		if cur.top >= prev.top+prev.height+spacing {
+			i++
+			continue
+		}
+
+		offset := prev.height + spacing
+		merged := labelCluster{
+			indices: append(append([]int{}, prev.indices...), cur.indices...),
+			height:  offset + cur.height,
+			targets: prev.targets + cur.targets - float64(len(cur.indices)*offset),
+		}
+		merged.top = int(math.Round(merged.targets / float64(len(merged.indices))))
+		merged.clamp(bounds)
+
+		clusters[i-1] = merged
+		clusters = append(clusters[:i], clusters[i+1:]...)
+		i = mathutil.MaxInt(i-1, 1)
+	}
+
+	for _, c := range clusters {
+		top := c.top
+		for _, index := range c.indices {
+			placed[index] = placed[index].Shift(0, top-placed[index].Top)
+			top += placed[index].Height() + spacing
+		}
+	}
+}
+
+// clamp moves the cluster within the top and bottom of the bounds.
+func (c *labelCluster) clamp(bounds Box) {
+	if bounds.IsZero() {
+		return
+	}
+	if c.height > bounds.Height() {
+		c.top = bounds.Top
+		return
+	}
+	c.top = mathutil.MaxInt(bounds.Top, mathutil.MinInt(c.top, bounds.Bottom-c.height))
+}
@@ -0,0 +1,46 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlaceLabelsStacksOverlappingLabels(t *testing.T) {
+	boxes := []Box{
+		{Top: 40, Left: 0, Right: 20, Bottom: 50},
+		{Top: 42, Left: 10, Right: 30, Bottom: 52},
+		{Top: 41, Left: 5, Right: 25, Bottom: 51},
+		{Top: 40, Left: 50, Right: 70, Bottom: 50},
+	}
+
+	placed := PlaceLabels(boxes, Box{}, 2)
+	require.Equal(t, Box{Top: 29, Left: 0, Right: 20, Bottom: 39}, placed[0])
+	require.Equal(t, Box{Top: 41, Left: 5, Right: 25, Bottom: 51}, placed[2])
+	require.Equal(t, Box{Top: 53, Left: 10, Right: 30, Bottom: 63}, placed[1])
+
+	// Labels not overlapping horizontally are left untouched.
+	require.Equal(t, boxes[3], placed[3])
+}
+
+func TestPlaceLabelsKeepsSeparateLabels(t *testing.T) {
+	boxes := []Box{
+		{Top: 0, Left: 0, Right: 20, Bottom: 10},
+		{Top: 20, Left: 0, Right: 20, Bottom: 30},
+	}
+	require.Equal(t, boxes, PlaceLabels(boxes, Box{Bottom: 100, Right: 100}, 2))
+}
+
+func TestPlaceLabelsRespectsBounds(t *testing.T) {
+	bounds := Box{Top: 10, Left: 0, Right: 100, Bottom: 100}
+	boxes := []Box{
+		{Top: 95, Left: 0, Right: 20, Bottom: 105},
+		{Top: 95, Left: 0, Right: 20, Bottom: 105},
+		{Top: 0, Left: 50, Right: 70, Bottom: 10},
+	}
+
+	placed := PlaceLabels(boxes, bounds, 0)
+	require.Equal(t, Box{Top: 80, Left: 0, Right: 20, Bottom: 90}, placed[0])
+	require.Equal(t, Box{Top: 90, Left: 0, Right: 20, Bottom: 100}, placed[1])
+	require.Equal(t, Box{Top: 10, Left: 50, Right: 70, Bottom: 20}, placed[2])
+}
@@ -0,0 +1,304 @@
+package raster
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"sort"
+)
+
+const (
+	// subsamples is the number of scanlines sampled per pixel row when
+	// filling polygons. It controls the vertical anti-aliasing quality.
+	subsamples = 4
+
+	// curveSegments is the number of line segments used to flatten curves.
+	curveSegments = 16
+)
+
+// point is a floating point coordinate.
+type point struct {
+	x, y float64
+}
+
+// polygon is a flattened sub-path.
+type polygon struct {
+	points []point
+	closed bool
+}
+
+// edge is a polygon edge used by the scanline filler.
+type edge struct {
+	x0, y0, x1, y1 float64
+	dir            int
+}
+
+// fillPolygons fills the given polygons using the non-zero winding rule.
+func fillPolygons(img *image.RGBA, polygons []polygon, c color.Color) {
+	var edges []edge
+	minY, maxY := math.Inf(1), math.Inf(-1)
+	for _, p := range polygons {
+		n := len(p.points)
+		if n < 2 {
+			continue
+		}
+		for i := 0; i < n; i++ {
+			a, b := p.points[i], p.points[(i+1)%n]
+			if a.y == b.y {
+				continue
+			}
+			e := edge{x0: a.x, y0: a.y, x1: b.x, y1: b.y, dir: 1}
+			if a.y > b.y {
+				e = edge{x0: b.x, y0: b.y, x1: a.x, y1: a.y, dir: -1}
+			}
+			edges = append(edges, e)
+			minY = math.Min(minY, e.y0)
+			maxY = math.Max(maxY, e.y1)
+		}
+	}
+	if len(edges) == 0 {
+		return
+	}
+
+	bounds := img.Bounds()
+	rowStart := int(math.Max(math.Floor(minY), float64(bounds.Min.Y)))
+	rowEnd := int(math.Min(math.Ceil(maxY), float64(bounds.Max.Y)))
+	width := bounds.Dx()
+
+	coverage := make([]float64, width+1)
+	type crossing struct {
+		x   float64
+		dir int
+	}
+	var crossings []crossing
+
+	for row := rowStart; row < rowEnd; row++ {
+		for i := range coverage {
+			coverage[i] = 0
+		}
+		touched := false
+
+		for s := 0; s < subsamples; s++ {
+			sy := float64(row) + (float64(s)+0.5)/subsamples
+
+			crossings = crossings[:0]
+			for _, e := range edges {
+				if sy < e.y0 || sy >= e.y1 {
+					continue
+				}
+				t := (sy - e.y0) / (e.y1 - e.y0)
+				crossings = append(crossings, crossing{x: e.x0 + t*(e.x1-e.x0), dir: e.dir})
+			}
+			if len(crossings) < 2 {
+				continue
+			}
+			sort.Slice(crossings, func(i, j int) bool { return crossings[i].x < crossings[j].x })
+
+			winding := 0
+			for i := 0; i < len(crossings)-1; i++ {
+				winding += crossings[i].dir
+				if winding == 0 {
+					continue
+				}
+				addSpan(coverage, crossings[i].x-float64(bounds.Min.X), crossings[i+1].x-float64(bounds.Min.X))
+				touched = true
+			}
+		}
+
+		if !touched {
+			continue
+		}
+		for i := 0; i < width; i++ {
+			if coverage[i] <= 0 {
+				continue
+			}
+			blend(img, bounds.Min.X+i, row, c, math.Min(coverage[i]/subsamples, 1))
+		}
+	}
+}
+
+// addSpan accumulates the horizontal coverage of the [x0, x1) span.
+func addSpan(coverage []float64, x0, x1 float64) {
+	width := float64(len(coverage) - 1)
+	x0 = math.Max(0, math.Min(x0, width))
+	x1 = math.Max(0, math.Min(x1, width))
+	if x1 <= x0 {
+		return
+	}
+
+	i0, i1 := int(math.Floor(x0)), int(math.Floor(x1))
+	if i0 == i1 {
+		coverage[i0] += x1 - x0
+		return
+	}
+
+	coverage[i0] += float64(i0+1) - x0
+	for i := i0 + 1; i < i1; i++ {
+		coverage[i]++
+	}
+	coverage[i1] += x1 - float64(i1)
+}
+
+// blend composes a color over the pixel at the given position, using the
+// specified coverage as an additional alpha factor.
+func blend(img *image.RGBA, x, y int, c color.Color, coverage float64) {
+	sr, sg, sb, sa := c.RGBA()
+	a := float64(sa) / 0xffff * coverage
+	if a <= 0 {
+		return
+	}
+
+	offset := img.PixOffset(x, y)
+	pix := img.Pix[offset : offset+4 : offset+4]
+
+	// Source colors are alpha premultiplied, as is the destination image.
+	k := coverage / 0xffff * 0xff
+	inv := 1 - a
+	pix[0] = uint8(math.Min(float64(sr)*k+float64(pix[0])*inv, 255))
+	pix[1] = uint8(math.Min(float64(sg)*k+float64(pix[1])*inv, 255))
+	pix[2] = uint8(math.Min(float64(sb)*k+float64(pix[2])*inv, 255))
+	pix[3] = uint8(math.Min(a*255+float64(pix[3])*inv, 255))
+}
+
+// strokePolygons returns the outline polygons of the given sub-paths,
+// stroked with the specified width and dash pattern.
+func strokePolygons(polygons []polygon, width float64, dashArray []float64) []polygon {
+	var output []polygon
+	hw := width / 2
+
+	for _, p := range polygons {
+		points := p.points
+		if p.closed && len(points) > 1 {
+			points = append(append([]point{}, points...), points[0])
+		}
+
+		for _, line := range dash(points, dashArray) {
+			for i := 0; i < len(line)-1; i++ {
+				output = append(output, segmentPolygon(line[i], line[i+1], hw))
+			}
+			for i := 0; i < len(line); i++ {
+				output = append(output, joinPolygon(line[i], hw))
+			}
+		}
+	}
+
+	return output
+}
+
+// segmentPolygon returns the rectangle covering a stroked line segment.
+// All the returned polygons share the same orientation, so overlapping
+// segments do not cancel each other out under the non-zero winding rule.
+func segmentPolygon(a, b point, hw float64) polygon {
+	dx, dy := b.x-a.x, b.y-a.y
+	length := math.Hypot(dx, dy)
+	if length == 0 {
+		return polygon{}
+	}
+	nx, ny := -dy/length*hw, dx/length*hw
+
+	return polygon{
+		closed: true,
+		points: []point{
+			{a.x + nx, a.y + ny},
+			{b.x + nx, b.y + ny},
+			{b.x - nx, b.y - ny},
+			{a.x - nx, a.y - ny},
+		},
+	}
+}
+
+// joinPolygon returns a round join approximation centered at the specified
+// point, with the same orientation as the segment polygons.
+func joinPolygon(c point, hw float64) polygon {
+	if hw < 1 {
+		return polygon{}
+	}
+
+	var points []point
+	for i := 0; i < curveSegments; i++ {
+		theta := -2 * math.Pi * float64(i) / curveSegments
+		points = append(points, point{c.x + hw*math.Cos(theta), c.y + hw*math.Sin(theta)})
+	}
+	return polygon{points: points, closed: true}
+}
+
+// dash splits a polyline according to the specified dash pattern.
+func dash(points []point, dashArray []float64) [][]point {
+	var total float64
+	for _, d := range dashArray {
+		total += d
+	}
+	if len(dashArray) == 0 || total <= 0 || len(points) < 2 {
+		return [][]point{points}
+	}
+
+	var output [][]point
+	current := []point{points[0]}
+
+	index := 0
+	remaining := dashArray[0]
+	on := true
+
+	for i := 0; i < len(points)-1; i++ {
+		a, b := points[i], points[i+1]
+		length := math.Hypot(b.x-a.x, b.y-a.y)
+		pos := 0.0
+
+		for length-pos > remaining {
+			pos += remaining
+			t := pos / length
+			p := point{a.x + (b.x-a.x)*t, a.y + (b.y-a.y)*t}
+			if on {
+				current = append(current, p)
+				output = append(output, current)
+				current = nil
+			} else {
+				current = []point{p}
+			}
+
+			on = !on
+			index = (index + 1) % len(dashArray)
+			remaining = dashArray[index]
+		}
+
+		remaining -= length - pos
+		if on {
+			current = append(current, b)
+		}
+	}
+
+	if on && len(current) > 1 {
+		output = append(output, current)
+	}
+	return output
+}
+
+// flattenArc returns the points of an elliptical arc.
+func flattenArc(cx, cy, rx, ry, startAngle, delta float64) []point {
+	segments := int(math.Ceil(math.Abs(delta) / (2 * math.Pi) * 4 * curveSegments))
+	if segments < 2 {
+		segments = 2
+	}
+
+	points := make([]point, 0, segments+1)
+	for i := 0; i <= segments; i++ {
+		theta := startAngle + delta*float64(i)/float64(segments)
+		points = append(points, point{cx + rx*math.Cos(theta), cy + ry*math.Sin(theta)})
+	}
+	return points
+}
+
+// flattenQuad returns the points of a quadratic Bézier curve, excluding
+// the start point.
+func flattenQuad(p0, p1, p2 point) []point {
+	points := make([]point, 0, curveSegments)
+	for i := 1; i <= curveSegments; i++ {
+		t := float64(i) / curveSegments
+		mt := 1 - t
+		points = append(points, point{
+			x: mt*mt*p0.x + 2*mt*t*p1.x + t*t*p2.x,
+			y: mt*mt*p0.y + 2*mt*t*p1.y + t*t*p2.y,
+		})
+	}
+	return points
+}
@@ -0,0 +1,283 @@
+package raster
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+
+	"github.com/unidoc/unichart/render"
+)
+
+const (
+	// defaultDPI is the default dots per inch of the renderer.
+	defaultDPI = 72.0
+)
+
+// Interface Assertions.
+var (
+	_ render.Renderer         = (*Renderer)(nil)
+	_ render.RendererProvider = NewRenderer
+)
+
+// Renderer is a render.Renderer which rasterizes charts into PNG images.
+// Text is drawn using a built-in bitmap font, scaled to the current font
+// size, so fonts set on the renderer only affect the size of the text.
+type Renderer struct {
+	img *image.RGBA
+	dpi float64
+
+	strokeColor     color.Color
+	fillColor       color.Color
+	strokeWidth     float64
+	strokeDashArray []float64
+
+	fontColor    color.Color
+	fontSize     float64
+	textRotation float64
+
+	polygons []polygon
+	current  int
+}
+
+// NewRenderer returns a new raster renderer with the specified canvas size.
+// It can be used as a render.RendererProvider.
+func NewRenderer(width, height int) (render.Renderer, error) {
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("invalid raster canvas size %dx%d", width, height)
+	}
+
+	r := &Renderer{
+		img:     image.NewRGBA(image.Rect(0, 0, width, height)),
+		dpi:     defaultDPI,
+		current: -1,
+	}
+	r.ResetStyle()
+	return r, nil
+}
+
+// Image returns the rendered image.
+func (r *Renderer) Image() *image.RGBA {
+	return r.img
+}
+
+// ResetStyle resets all the style related settings of the renderer.
+func (r *Renderer) ResetStyle() {
+	r.strokeColor = render.ColorTransparent
+	r.fillColor = render.ColorTransparent
+	r.strokeWidth = render.DefaultStrokeWidth
+	r.strokeDashArray = nil
+	r.fontColor = render.DefaultTextColor
+	r.fontSize = render.DefaultFontSize
+	r.textRotation = 0
+}
+
+// GetDPI gets the DPI for the renderer.
+func (r *Renderer) GetDPI() float64 {
+	return r.dpi
+}
+
+// SetDPI sets the DPI for the renderer.
+func (r *Renderer) SetDPI(dpi float64) {
+	if dpi > 0 {
+		r.dpi = dpi
+	}
+}
+
+// SetClassName sets the current class name. Class names have no effect on
+// raster output.
+func (r *Renderer) SetClassName(string) {}
+
+// SetStrokeColor sets the current stroke color.
+func (r *Renderer) SetStrokeColor(c color.Color) {
+	r.strokeColor = c
+}
+
+// SetFillColor sets the current fill color.
+func (r *Renderer) SetFillColor(c color.Color) {
+	r.fillColor = c
+}
+
+// SetStrokeWidth sets the stroke width.
+func (r *Renderer) SetStrokeWidth(width float64) {
+	r.strokeWidth = width
+}
+
+// SetStrokeDashArray sets the stroke dash array.
+func (r *Renderer) SetStrokeDashArray(dashArray []float64) {
+	r.strokeDashArray = dashArray
+}
+
+// MoveTo moves the cursor to the specified point.
+func (r *Renderer) MoveTo(x, y int) {
+	r.polygons = append(r.polygons, polygon{points: []point{{float64(x), float64(y)}}})
+	r.current = len(r.polygons) - 1
+}
+
+// LineTo draws a line to the specified point, starting from the previous one.
+func (r *Renderer) LineTo(x, y int) {
+	r.lineTo(point{float64(x), float64(y)})
+}
+
+// QuadCurveTo draws a quad curve. `cx` and `cy` are the Bézier control points.
+func (r *Renderer) QuadCurveTo(cx, cy, x, y int) {
+	if r.current < 0 {
+		r.MoveTo(cx, cy)
+	}
+
+	points := r.polygons[r.current].points
+	start := points[len(points)-1]
+	for _, p := range flattenQuad(start, point{float64(cx), float64(cy)}, point{float64(x), float64(y)}) {
+		r.lineTo(p)
+	}
+}
+
+// ArcTo draws an arc with a given center (`cx`, `cy`), a given set of
+// radii (`rx`, `ry`), a `startAngle` and `deltaAngle` (in radians).
+func (r *Renderer) ArcTo(cx, cy int, rx, ry, startAngle, delta float64) {
+	for _, p := range flattenArc(float64(cx), float64(cy), rx, ry, startAngle, delta) {
+		r.lineTo(p)
+	}
+}
+
+// Close finalizes a shape, closing the path.
+func (r *Renderer) Close() {
+	if r.current >= 0 {
+		r.polygons[r.current].closed = true
+		r.current = -1
+	}
+}
+
+// Stroke strokes the current path.
+func (r *Renderer) Stroke() {
+	r.draw(false, true)
+}
+
+// Fill fills the current path.
+func (r *Renderer) Fill() {
+	r.draw(true, false)
+}
+
+// FillStroke fills and strokes the current path.
+func (r *Renderer) FillStroke() {
+	r.draw(true, true)
+}
+
+// Circle draws a circle at the given coordinates, with a given radius.
+func (r *Renderer) Circle(radius float64, x, y int) {
+	points := flattenArc(float64(x), float64(y), radius, radius, 0, 2*math.Pi)
+	r.polygons = append(r.polygons, polygon{points: points, closed: true})
+	r.current = -1
+}
+
+// SetFont sets the current font. The raster renderer always draws text
+// using its built-in font.
+func (r *Renderer) SetFont(render.Font) {}
+
+// SetFontColor sets the current font color.
+func (r *Renderer) SetFontColor(c color.Color) {
+	r.fontColor = c
+}
+
+// SetFontSize sets the current font size.
+func (r *Renderer) SetFontSize(size float64) {
+	r.fontSize = size
+}
+
+// Text draws a text chunk. The `y` coordinate represents the baseline.
+func (r *Renderer) Text(body string, x, y int) {
+	if render.ColorIsZero(r.fontColor) {
+		return
+	}
+
+	scale := r.glyphScale()
+	cos, sin := math.Cos(r.textRotation), math.Sin(r.textRotation)
+	ox, oy := float64(x), float64(y)
+	transform := func(px, py float64) point {
+		return point{ox + px*cos - py*sin, oy + px*sin + py*cos}
+	}
+
+	var polygons []polygon
+	var cursor float64
+	for _, c := range body {
+		columns := glyph(c)
+		for col, bits := range columns {
+			for row := 0; row < glyphHeight; row++ {
+				if bits&(1<<uint(row)) == 0 {
+					continue
+				}
+
+				px := cursor + float64(col)*scale
+				py := float64(row-glyphAscent) * scale
+				polygons = append(polygons, polygon{
+					closed: true,
+					points: []point{
+						transform(px, py),
+						transform(px+scale, py),
+						transform(px+scale, py+scale),
+						transform(px, py+scale),
+					},
+				})
+			}
+		}
+		cursor += (glyphWidth + 1) * scale
+	}
+
+	fillPolygons(r.img, polygons, r.fontColor)
+}
+
+// MeasureText measures the specified text.
+func (r *Renderer) MeasureText(body string) render.Box {
+	count := len([]rune(body))
+	if count == 0 {
+		return render.Box{}
+	}
+
+	scale := r.glyphScale()
+	return render.Box{
+		Right:  int(math.Ceil(float64(count*(glyphWidth+1)-1) * scale)),
+		Bottom: int(math.Ceil(glyphAscent * scale)),
+	}
+}
+
+// SetTextRotation sets the rotation of the text.
+func (r *Renderer) SetTextRotation(radians float64) {
+	r.textRotation = radians
+}
+
+// ClearTextRotation clears rotation of the text.
+func (r *Renderer) ClearTextRotation() {
+	r.textRotation = 0
+}
+
+// Save saves the rendered image to the given writer, as a PNG.
+func (r *Renderer) Save(w io.Writer) error {
+	return png.Encode(w, r.img)
+}
+
+func (r *Renderer) lineTo(p point) {
+	if r.current < 0 {
+		r.polygons = append(r.polygons, polygon{})
+		r.current = len(r.polygons) - 1
+	}
+	r.polygons[r.current].points = append(r.polygons[r.current].points, p)
+}
+
+func (r *Renderer) draw(fill, stroke bool) {
+	if fill && !render.ColorIsZero(r.fillColor) {
+		fillPolygons(r.img, r.polygons, r.fillColor)
+	}
+	if stroke && r.strokeWidth > 0 && !render.ColorIsZero(r.strokeColor) {
+		fillPolygons(r.img, strokePolygons(r.polygons, r.strokeWidth, r.strokeDashArray), r.strokeColor)
+	}
+
+	r.polygons = nil
+	r.current = -1
+}
+
+// glyphScale returns the size of a font pixel for the current font size.
+func (r *Renderer) glyphScale() float64 {
+	return r.fontSize * r.dpi / defaultDPI / glyphHeight
+}
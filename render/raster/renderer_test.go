@@ -0,0 +1,86 @@
+package raster
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/unidoc/unichart/render"
+)
+
+func TestRendererFill(t *testing.T) {
+	rr, err := NewRenderer(20, 20)
+	require.NoError(t, err)
+	r := rr.(*Renderer)
+
+	r.SetFillColor(render.ColorBlack)
+	r.MoveTo(5, 5)
+	r.LineTo(15, 5)
+	r.LineTo(15, 15)
+	r.LineTo(5, 15)
+	r.Close()
+	r.Fill()
+
+	img := r.Image()
+	require.Equal(t, uint8(255), img.RGBAAt(10, 10).A)
+	require.Equal(t, uint8(51), img.RGBAAt(10, 10).R)
+	require.Equal(t, uint8(0), img.RGBAAt(2, 2).A)
+	require.Equal(t, uint8(0), img.RGBAAt(17, 17).A)
+}
+
+func TestRendererStroke(t *testing.T) {
+	rr, err := NewRenderer(20, 20)
+	require.NoError(t, err)
+	r := rr.(*Renderer)
+
+	r.SetStrokeColor(render.ColorRed)
+	r.SetStrokeWidth(2)
+	r.MoveTo(0, 10)
+	r.LineTo(20, 10)
+	r.Stroke()
+
+	img := r.Image()
+	require.Equal(t, uint8(217), img.RGBAAt(10, 10).R)
+	require.Equal(t, uint8(217), img.RGBAAt(10, 9).R)
+	require.Equal(t, uint8(0), img.RGBAAt(10, 5).A)
+}
+
+func TestRendererText(t *testing.T) {
+	rr, err := NewRenderer(100, 20)
+	require.NoError(t, err)
+	r := rr.(*Renderer)
+
+	r.SetFontSize(8)
+	r.SetFontColor(render.ColorBlack)
+	r.Text("I", 10, 15)
+
+	var painted int
+	img := r.Image()
+	for y := 0; y < 20; y++ {
+		for x := 0; x < 100; x++ {
+			if img.RGBAAt(x, y).A > 0 {
+				painted++
+			}
+		}
+	}
+	require.True(t, painted > 0)
+
+	box := r.MeasureText("abc")
+	require.Equal(t, 17, box.Width())
+	require.Equal(t, 7, box.Height())
+}
+
+func TestRendererSave(t *testing.T) {
+	r, err := NewRenderer(30, 10)
+	require.NoError(t, err)
+
+	buf := bytes.NewBuffer(nil)
+	require.NoError(t, r.Save(buf))
+
+	img, err := png.Decode(buf)
+	require.NoError(t, err)
+	require.Equal(t, 30, img.Bounds().Dx())
+	require.Equal(t, 10, img.Bounds().Dy())
+}
@@ -0,0 +1,474 @@
+package render
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/unidoc/unichart/mathutil"
+)
+
+const (
+	// richTextScriptScale is the font size of superscript and subscript runs,
+	// relative to the font size of the text.
+	richTextScriptScale = 0.7
+
+	// richTextSuperscriptRise is the distance superscript runs are raised
+	// by, relative to the height of the text.
+	richTextSuperscriptRise = 0.45
+
+	// richTextSubscriptDrop is the distance subscript runs are lowered by,
+	// relative to the height of the text.
+	richTextSubscriptDrop = 0.25
+)
+
+// TextScript is the vertical position of a run of rich text.
+type TextScript int
+
+const (
+	// TextScriptNormal draws the run on the baseline.
+	TextScriptNormal TextScript = iota
+
+	// TextScriptSuperscript draws the run raised above the baseline, with a
+	// smaller font size.
+	TextScriptSuperscript
+
+	// TextScriptSubscript draws the run lowered below the baseline, with a
+	// smaller font size.
+	TextScriptSubscript
+)
+
+// TextRun is a chunk of rich text drawn with the same style.
+type TextRun struct {
+	Text   string
+	Bold   bool
+	Color  color.Color
+	Script TextScript
+}
+
+// richTextTags are the supported markup tags.
+var richTextTags = map[string]bool{
+	"b":    true,
+	"sup":  true,
+	"sub":  true,
+	"font": true,
+}
+
+// richTextEntities are the supported markup character entities.
+var richTextEntities = map[string]string{
+	"&lt;":   "<",
+	"&gt;":   ">",
+	"&amp;":  "&",
+	"&quot;": `"`,
+}
+
+// IsRichText returns true if the specified text contains rich text markup.
+// Text without markup is drawn as is, without decoding entities.
+func IsRichText(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if value[i] != '<' {
+			continue
+		}
+		if _, _, _, n := parseRichTextTag(value[i:]); n > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseRichText parses a subset of HTML markup into text runs. The
+// supported tags are <b> for bold text, <sup> for superscripts, <sub> for
+// subscripts and <font color="#rrggbb"> for colored text, and they can be
+// nested. The &lt;, &gt;, &amp; and &quot; entities are decoded.
+// Unsupported or unmatched tags are kept as text.
+//
+// Example:
+//
+//	ParseRichText(`Area (m<sup>2</sup>), <font color="#d90074">x<sub>i</sub></font>`)
+func ParseRichText(value string) []TextRun {
+	type state struct {
+		tag string
+		run TextRun
+	}
+	stack := []state{{}}
+
+	var runs []TextRun
+	var text strings.Builder
+	flush := func() {
+		if text.Len() == 0 {
+			return
+		}
+		run := stack[len(stack)-1].run
+		run.Text = text.String()
+		if n := len(runs); n > 0 && runs[n-1].sameStyle(run) {
+			runs[n-1].Text += run.Text
+		} else {
+			runs = append(runs, run)
+		}
+		text.Reset()
+	}
+
+	for i := 0; i < len(value); {
+		switch value[i] {
+		case '<':
+			tag, closing, attrs, n := parseRichTextTag(value[i:])
+			if n == 0 {
+				break
+			}
+
+			if closing {
+				if len(stack) == 1 || stack[len(stack)-1].tag != tag {
+					break
+				}
+				flush()
+				stack = stack[:len(stack)-1]
+				i += n
+				continue
+			}
+
+			run := stack[len(stack)-1].run
+			switch tag {
+			case "b":
+				run.Bold = true
+			case "sup":
+				run.Script = TextScriptSuperscript
+			case "sub":
+				run.Script = TextScriptSubscript
+			case "font":
+				if c, ok := ParseColor(attrs["color"]); ok {
+					run.Color = c
+				}
+			}
+			flush()
+			stack = append(stack, state{tag: tag, run: run})
+			i += n
+			continue
+		case '&':
+			if end := strings.IndexByte(value[i:], ';'); end > 0 {
+				if decoded, ok := richTextEntities[value[i:i+end+1]]; ok {
+					text.WriteString(decoded)
+					i += end + 1
+					continue
+				}
+			}
+		}
+
+		text.WriteByte(value[i])
+		i++
+	}
+	flush()
+
+	return runs
+}
+
+// StripRichText returns the text of the specified rich text, without markup.
+func StripRichText(value string) string {
+	if !IsRichText(value) {
+		return value
+	}
+
+	var text strings.Builder
+	for _, run := range ParseRichText(value) {
+		text.WriteString(run.Text)
+	}
+	return text.String()
+}
+
+// ParseColor parses a color in the #rgb, #rrggbb or #rrggbbaa hex formats.
+func ParseColor(value string) (color.Color, bool) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "#")
+	if len(value) == 3 {
+		value = string([]byte{value[0], value[0], value[1], value[1], value[2], value[2]})
+	}
+	if len(value) == 6 {
+		value += "ff"
+	}
+	if len(value) != 8 {
+		return nil, false
+	}
+
+	v, err := strconv.ParseUint(value, 16, 32)
+	if err != nil {
+		return nil, false
+	}
+	return color.NRGBA{R: uint8(v >> 24), G: uint8(v >> 16), B: uint8(v >> 8), A: uint8(v)}, true
+}
+
+// formatRichText formats the specified text runs as rich text markup.
+func formatRichText(runs []TextRun) string {
+	var text strings.Builder
+	for _, run := range runs {
+		var closing []string
+		if run.Color != nil {
+			c := color.NRGBAModel.Convert(run.Color).(color.NRGBA)
+			fmt.Fprintf(&text, `<font color="#%02x%02x%02x%02x">`, c.R, c.G, c.B, c.A)
+			closing = append(closing, "</font>")
+		}
+		if run.Bold {
+			text.WriteString("<b>")
+			closing = append(closing, "</b>")
+		}
+		switch run.Script {
+		case TextScriptSuperscript:
+			text.WriteString("<sup>")
+			closing = append(closing, "</sup>")
+		case TextScriptSubscript:
+			text.WriteString("<sub>")
+			closing = append(closing, "</sub>")
+		}
+
+		for _, c := range run.Text {
+			switch c {
+			case '<':
+				text.WriteString("&lt;")
+			case '>':
+				text.WriteString("&gt;")
+			case '&':
+				text.WriteString("&amp;")
+			default:
+				text.WriteRune(c)
+			}
+		}
+		for i := len(closing) - 1; i >= 0; i-- {
+			text.WriteString(closing[i])
+		}
+	}
+	return text.String()
+}
+
+// parseRichTextTag parses the supported markup tag at the start of the
+// specified text. It returns the length of the tag, or 0 if the text does
+// not start with a supported tag.
+func parseRichTextTag(value string) (tag string, closing bool, attrs map[string]string, n int) {
+	end := strings.IndexByte(value, '>')
+	if len(value) < 3 || value[0] != '<' || end < 0 {
+		return "", false, nil, 0
+	}
+
+	body := value[1:end]
+	if strings.HasPrefix(body, "/") {
+		closing = true
+		body = body[1:]
+	}
+
+	fields := strings.Fields(body)
+	if len(fields) == 0 {
+		return "", false, nil, 0
+	}
+	tag = strings.ToLower(fields[0])
+	if !richTextTags[tag] || (closing && len(fields) > 1) {
+		return "", false, nil, 0
+	}
+
+	attrs = map[string]string{}
+	for _, field := range fields[1:] {
+		parts := strings.SplitN(field, "=", 2)
+		if len(parts) != 2 {
+			return "", false, nil, 0
+		}
+		attrs[strings.ToLower(parts[0])] = strings.Trim(parts[1], `"'`)
+	}
+	return tag, closing, attrs, end + 1
+}
+
+func (tr TextRun) sameStyle(other TextRun) bool {
+	if tr.Bold != other.Bold || tr.Script != other.Script {
+		return false
+	}
+	if tr.Color == nil || other.Color == nil {
+		return tr.Color == nil && other.Color == nil
+	}
+	return ColorToString(tr.Color) == ColorToString(other.Color)
+}
+
+// style returns the style the run is drawn with.
+func (tr TextRun) style(base Style) Style {
+	style := base
+	if tr.Color != nil {
+		style.FontColor = tr.Color
+	}
+	if tr.Script != TextScriptNormal {
+		style.FontSize = base.GetFontSize() * richTextScriptScale
+	}
+	return style
+}
+
+// boldOffset returns the horizontal offset bold runs are drawn twice with.
+func (tr TextRun) boldOffset(style Style) float64 {
+	if !tr.Bold {
+		return 0
+	}
+	return math.Max(1, style.GetFontSize()/DefaultFontSize)
+}
+
+// advance returns the horizontal distance between the start of the run
+// and the start of the next one.
+func (tr TextRun) advance(r Renderer, style Style) float64 {
+	style.WriteTextOptionsToRenderer(r)
+	spacing := r.MeasureText("x").Width()
+	return float64(r.MeasureText(tr.Text+"x").Width()-spacing) + tr.boldOffset(style)
+}
+
+// measureRichText measures the specified text runs. Superscripts extend the
+// height of the text; subscripts, like descenders, extend below it.
+func measureRichText(r Renderer, runs []TextRun, style Style) Box {
+	style.WriteTextOptionsToRenderer(r)
+	height := float64(r.MeasureText("x").Height())
+
+	var width, cursor, top float64
+	for _, run := range runs {
+		runStyle := run.style(style)
+		runStyle.WriteTextOptionsToRenderer(r)
+		runBox := r.MeasureText(run.Text)
+
+		rise := 0.0
+		if run.Script == TextScriptSuperscript {
+			rise = height * richTextSuperscriptRise
+		}
+		width = math.Max(width, cursor+float64(runBox.Width())+run.boldOffset(runStyle))
+		top = math.Max(top, float64(runBox.Height())+rise)
+		cursor += run.advance(r, runStyle)
+	}
+
+	return Box{
+		Right:  int(math.Ceil(width)),
+		Bottom: int(math.Ceil(top)),
+	}
+}
+
+// drawRichText draws the specified text runs, starting at the baseline
+// point (x, y), along the text rotation of the style.
+func drawRichText(r Renderer, runs []TextRun, x, y int, style Style) {
+	style.WriteTextOptionsToRenderer(r)
+	height := float64(r.MeasureText("x").Height())
+
+	theta := mathutil.DegreesToRadians(style.GetTextRotationDegrees())
+	cos, sin := math.Cos(theta), math.Sin(theta)
+	point := func(dx, dy float64) (int, int) {
+		return x + int(math.Round(dx*cos-dy*sin)), y + int(math.Round(dx*sin+dy*cos))
+	}
+
+	var cursor float64
+	for _, run := range runs {
+		runStyle := run.style(style)
+
+		var dy float64
+		switch run.Script {
+		case TextScriptSuperscript:
+			dy = -height * richTextSuperscriptRise
+		case TextScriptSubscript:
+			dy = height * richTextSubscriptDrop
+		}
+
+		runStyle.WriteTextOptionsToRenderer(r)
+		tx, ty := point(cursor, dy)
+		r.Text(run.Text, tx, ty)
+		if offset := run.boldOffset(runStyle); offset > 0 {
+			tx, ty = point(cursor+offset, dy)
+			r.Text(run.Text, tx, ty)
+		}
+		cursor += run.advance(r, runStyle)
+	}
+
+	style.WriteTextOptionsToRenderer(r)
+}
+
+// richRune is a rune of rich text, along with the run it belongs to.
+type richRune struct {
+	value rune
+	run   TextRun
+}
+
+// richRunes splits the specified text runs into runes.
+func richRunes(runs []TextRun) []richRune {
+	var runes []richRune
+	for _, run := range runs {
+		for _, c := range run.Text {
+			runes = append(runes, richRune{value: c, run: run})
+		}
+	}
+	return runes
+}
+
+// richRunesToRuns groups the specified runes into text runs.
+func richRunesToRuns(runes []richRune) []TextRun {
+	var runs []TextRun
+	for _, rr := range runes {
+		if n := len(runs); n > 0 && runs[n-1].sameStyle(rr.run) {
+			runs[n-1].Text += string(rr.value)
+			continue
+		}
+		run := rr.run
+		run.Text = string(rr.value)
+		runs = append(runs, run)
+	}
+	return runs
+}
+
+// trimRichRunes removes the leading and trailing whitespace of the
+// specified runes.
+func trimRichRunes(runes []richRune) []richRune {
+	isSpace := func(c rune) bool {
+		return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+	}
+	for len(runes) > 0 && isSpace(runes[0].value) {
+		runes = runes[1:]
+	}
+	for len(runes) > 0 && isSpace(runes[len(runes)-1].value) {
+		runes = runes[:len(runes)-1]
+	}
+	return runes
+}
+
+// wrapFitRichText splits the specified rich text into lines which fit
+// within the specified width, on words or on runes. The lines are returned
+// as rich text markup.
+func wrapFitRichText(r Renderer, value string, width int, style Style, words bool) []string {
+	measure := func(runes []richRune) int {
+		return measureRichText(r, richRunesToRuns(runes), style).Width()
+	}
+	format := func(runes []richRune) string {
+		if words {
+			runes = trimRichRunes(runes)
+		}
+		return formatRichText(richRunesToRuns(runes))
+	}
+
+	var output []string
+	var line, word []richRune
+	for _, rr := range richRunes(ParseRichText(value)) {
+		if rr.value == '\n' {
+			output = append(output, format(append(line, word...)))
+			line, word = nil, nil
+			continue
+		}
+
+		if !words {
+			if len(line) > 0 && measure(append(line[:len(line):len(line)], rr)) >= width {
+				output = append(output, format(line))
+				line = nil
+			}
+			line = append(line, rr)
+			continue
+		}
+
+		candidate := append(append(line[:len(line):len(line)], word...), rr)
+		if measure(candidate) >= width {
+			output = append(output, format(line))
+			line = word
+			word = []richRune{rr}
+			continue
+		}
+
+		if rr.value == ' ' || rr.value == '\t' {
+			line = append(append(line, word...), rr)
+			word = nil
+			continue
+		}
+		word = append(word, rr)
+	}
+
+	return append(output, format(append(line, word...)))
+}
@@ -0,0 +1,54 @@
+package render
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRichText(t *testing.T) {
+	red := color.NRGBA{R: 255, A: 255}
+	runs := ParseRichText(`Area (m<sup>2</sup>) <b>x<sub>i</sub> &lt; <font color="#f00">10</font></b>`)
+	require.Equal(t, []TextRun{
+		{Text: "Area (m"},
+		{Text: "2", Script: TextScriptSuperscript},
+		{Text: ") "},
+		{Text: "x", Bold: true},
+		{Text: "i", Bold: true, Script: TextScriptSubscript},
+		{Text: " < ", Bold: true},
+		{Text: "10", Bold: true, Color: red},
+	}, runs)
+
+	// Unsupported and unmatched tags are kept as text.
+	require.Equal(t, []TextRun{{Text: "a <i>b</i> </sup>c"}}, ParseRichText("a <i>b</i> </sup>c"))
+}
+
+func TestIsRichText(t *testing.T) {
+	require.True(t, IsRichText("10<sup>6</sup>"))
+	require.True(t, IsRichText(`<font color="#00ff00">ok</font>`))
+	require.False(t, IsRichText("a < b & c > d"))
+	require.False(t, IsRichText("<i>italic</i>"))
+
+	require.Equal(t, "106", StripRichText("10<sup>6</sup>"))
+	require.Equal(t, "a &lt; b", StripRichText("a &lt; b"))
+}
+
+func TestParseColor(t *testing.T) {
+	c, ok := ParseColor("#0a0")
+	require.True(t, ok)
+	require.Equal(t, color.NRGBA{G: 0xaa, A: 0xff}, c)
+
+	c, ok = ParseColor("#11223380")
+	require.True(t, ok)
+	require.Equal(t, color.NRGBA{R: 0x11, G: 0x22, B: 0x33, A: 0x80}, c)
+
+	_, ok = ParseColor("red")
+	require.False(t, ok)
+}
+
+func TestFormatRichText(t *testing.T) {
+	value := `a &lt; <b>b<sup>2</sup></b> <font color="#ff000080">c</font>`
+	runs := ParseRichText(value)
+	require.Equal(t, runs, ParseRichText(formatRichText(runs)))
+}
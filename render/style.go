@@ -21,6 +21,12 @@ const (
 
 	// DefaultLineSpacing is the default vertical distance between text lines.
 	DefaultLineSpacing = 5
+
+	// DefaultErrorBarWidth is the default error bar stroke width.
+	DefaultErrorBarWidth = 1.0
+
+	// DefaultErrorBarCapWidth is the default width of error bar caps.
+	DefaultErrorBarCapWidth = 6.0
 )
 
 var (
@@ -36,6 +42,47 @@ type (
 	SizeProvider func(xrange, yrange sequence.Range, index int, x, y float64) float64
 )
 
+// LineGapMode specifies how line series handle missing (NaN) values.
+type LineGapMode int
+
+const (
+	// LineGapModeUnset is the unset gap mode.
+	LineGapModeUnset LineGapMode = iota
+
+	// LineGapModeBreak breaks the line (and its fill) at missing values.
+	LineGapModeBreak
+
+	// LineGapModeConnect connects the values surrounding missing values.
+	LineGapModeConnect
+
+	// LineGapModeZero draws missing values as zero.
+	LineGapModeZero
+
+	// LineGapModeStep holds the last value until the next valid one.
+	LineGapModeStep
+)
+
+// DownsamplingMode specifies how line series with more values than can be
+// distinguished on the canvas are reduced before drawing. Dots are only
+// drawn at the kept values.
+type DownsamplingMode int
+
+const (
+	// DownsamplingModeUnset is the unset downsampling mode.
+	DownsamplingModeUnset DownsamplingMode = iota
+
+	// DownsamplingModeNone draws all the values.
+	DownsamplingModeNone
+
+	// DownsamplingModeLTTB reduces the values using the Largest-Triangle-
+	// Three-Buckets algorithm, to twice the number of canvas pixel columns.
+	DownsamplingModeLTTB
+
+	// DownsamplingModeMinMax keeps the first, last, minimum and maximum
+	// values of each canvas pixel column.
+	DownsamplingModeMinMax
+)
+
 // Font represents a generic font type.
 type Font interface {
 	String() string
@@ -58,6 +105,13 @@ type Style struct {
 	DotWidthProvider SizeProvider
 	DotColorProvider DotColorProvider
 
+	LineGapMode      LineGapMode
+	DownsamplingMode DownsamplingMode
+
+	ErrorBarColor    color.Color
+	ErrorBarWidth    float64
+	ErrorBarCapWidth float64
+
 	Font      Font
 	FontSize  float64
 	FontColor color.Color
@@ -236,6 +290,62 @@ func (s Style) GetDotWidth(defaults ...float64) float64 {
 	return s.DotWidth
 }
 
+// GetLineGapMode returns the missing values handling mode of line series.
+func (s Style) GetLineGapMode(defaults ...LineGapMode) LineGapMode {
+	if s.LineGapMode == LineGapModeUnset {
+		if len(defaults) > 0 {
+			return defaults[0]
+		}
+		return LineGapModeBreak
+	}
+	return s.LineGapMode
+}
+
+// GetDownsamplingMode returns the downsampling mode of line series.
+func (s Style) GetDownsamplingMode(defaults ...DownsamplingMode) DownsamplingMode {
+	if s.DownsamplingMode == DownsamplingModeUnset {
+		if len(defaults) > 0 {
+			return defaults[0]
+		}
+		return DownsamplingModeNone
+	}
+	return s.DownsamplingMode
+}
+
+// GetErrorBarColor returns the error bar color.
+func (s Style) GetErrorBarColor(defaults ...color.Color) color.Color {
+	if ColorIsZero(s.ErrorBarColor) {
+		if len(defaults) > 0 {
+			return defaults[0]
+		}
+		return ColorTransparent
+	}
+	return s.ErrorBarColor
+}
+
+// GetErrorBarWidth returns the error bar stroke width.
+func (s Style) GetErrorBarWidth(defaults ...float64) float64 {
+	if s.ErrorBarWidth == 0 {
+		if len(defaults) > 0 {
+			return defaults[0]
+		}
+		return DefaultErrorBarWidth
+	}
+	return s.ErrorBarWidth
+}
+
+// GetErrorBarCapWidth returns the width of the caps drawn at the ends of
+// error bars.
+func (s Style) GetErrorBarCapWidth(defaults ...float64) float64 {
+	if s.ErrorBarCapWidth == 0 {
+		if len(defaults) > 0 {
+			return defaults[0]
+		}
+		return DefaultErrorBarCapWidth
+	}
+	return s.ErrorBarCapWidth
+}
+
 // GetStrokeDashArray returns the stroke dash array.
 func (s Style) GetStrokeDashArray(defaults ...[]float64) []float64 {
 	if len(s.StrokeDashArray) == 0 {
@@ -392,6 +502,12 @@ func (s Style) InheritFrom(defaults Style) (final Style) {
 
 	final.DotWidthProvider = s.DotWidthProvider
 	final.DotColorProvider = s.DotColorProvider
+	final.LineGapMode = s.GetLineGapMode(defaults.LineGapMode)
+	final.DownsamplingMode = s.GetDownsamplingMode(defaults.DownsamplingMode)
+
+	final.ErrorBarColor = s.GetErrorBarColor(defaults.ErrorBarColor)
+	final.ErrorBarWidth = s.GetErrorBarWidth(defaults.ErrorBarWidth)
+	final.ErrorBarCapWidth = s.GetErrorBarCapWidth(defaults.ErrorBarCapWidth)
 
 	final.FillColor = s.GetFillColor(defaults.FillColor)
 	final.FontColor = s.GetFontColor(defaults.FontColor)
@@ -447,6 +563,17 @@ func (s Style) GetFillAndStrokeOptions() Style {
 	}
 }
 
+// GetErrorBarOptions returns the error bar components, as stroke options.
+// Error bars are drawn using the stroke color, unless an error bar color is
+// set.
+func (s Style) GetErrorBarOptions() Style {
+	return Style{
+		ClassName:   s.ClassName,
+		StrokeColor: s.GetErrorBarColor(s.GetStrokeColor(DefaultLineColor)),
+		StrokeWidth: s.GetErrorBarWidth(),
+	}
+}
+
 // GetTextOptions returns just the text components of the style.
 func (s Style) GetTextOptions() Style {
 	return Style{
@@ -0,0 +1,374 @@
+package svg
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"image/color"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/unidoc/unichart/mathutil"
+	"github.com/unidoc/unichart/render"
+)
+
+const (
+	// defaultDPI is the default dots per inch of the renderer.
+	defaultDPI = 72.0
+
+	// defaultFontFamily is the font family used when no font is set.
+	defaultFontFamily = "sans-serif"
+)
+
+// Interface Assertions.
+var (
+	_ render.Renderer         = (*Renderer)(nil)
+	_ render.RendererProvider = NewRenderer
+)
+
+// Renderer is a render.Renderer which outputs SVG documents.
+// Text is measured using approximate glyph widths, as no font files are
+// loaded by the renderer.
+type Renderer struct {
+	width  int
+	height int
+	dpi    float64
+
+	className       string
+	strokeColor     color.Color
+	fillColor       color.Color
+	strokeWidth     float64
+	strokeDashArray []float64
+
+	font         render.Font
+	fontColor    color.Color
+	fontSize     float64
+	textRotation float64
+
+	path     []string
+	elements []string
+}
+
+// NewRenderer returns a new SVG renderer with the specified canvas size.
+// It can be used as a render.RendererProvider.
+func NewRenderer(width, height int) (render.Renderer, error) {
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("invalid svg canvas size %dx%d", width, height)
+	}
+
+	r := &Renderer{
+		width:  width,
+		height: height,
+		dpi:    defaultDPI,
+	}
+	r.ResetStyle()
+	return r, nil
+}
+
+// ResetStyle resets all the style related settings of the renderer.
+func (r *Renderer) ResetStyle() {
+	r.className = ""
+	r.strokeColor = render.ColorTransparent
+	r.fillColor = render.ColorTransparent
+	r.strokeWidth = render.DefaultStrokeWidth
+	r.strokeDashArray = nil
+	r.font = nil
+	r.fontColor = render.DefaultTextColor
+	r.fontSize = render.DefaultFontSize
+	r.textRotation = 0
+}
+
+// GetDPI gets the DPI for the renderer.
+func (r *Renderer) GetDPI() float64 {
+	return r.dpi
+}
+
+// SetDPI sets the DPI for the renderer.
+func (r *Renderer) SetDPI(dpi float64) {
+	if dpi > 0 {
+		r.dpi = dpi
+	}
+}
+
+// SetClassName sets the current class name.
+func (r *Renderer) SetClassName(className string) {
+	r.className = className
+}
+
+// SetStrokeColor sets the current stroke color.
+func (r *Renderer) SetStrokeColor(c color.Color) {
+	r.strokeColor = c
+}
+
+// SetFillColor sets the current fill color.
+func (r *Renderer) SetFillColor(c color.Color) {
+	r.fillColor = c
+}
+
+// SetStrokeWidth sets the stroke width.
+func (r *Renderer) SetStrokeWidth(width float64) {
+	r.strokeWidth = width
+}
+
+// SetStrokeDashArray sets the stroke dash array.
+func (r *Renderer) SetStrokeDashArray(dashArray []float64) {
+	r.strokeDashArray = dashArray
+}
+
+// MoveTo moves the cursor to the specified point.
+func (r *Renderer) MoveTo(x, y int) {
+	r.path = append(r.path, fmt.Sprintf("M %d %d", x, y))
+}
+
+// LineTo draws a line to the specified point, starting from the previous one.
+func (r *Renderer) LineTo(x, y int) {
+	r.path = append(r.path, fmt.Sprintf("L %d %d", x, y))
+}
+
+// QuadCurveTo draws a quad curve. `cx` and `cy` are the Bézier control points.
+func (r *Renderer) QuadCurveTo(cx, cy, x, y int) {
+	r.path = append(r.path, fmt.Sprintf("Q %d %d %d %d", cx, cy, x, y))
+}
+
+// ArcTo draws an arc with a given center (`cx`, `cy`), a given set of
+// radii (`rx`, `ry`), a `startAngle` and `deltaAngle` (in radians).
+func (r *Renderer) ArcTo(cx, cy int, rx, ry, startAngle, delta float64) {
+	if delta == 0 {
+		return
+	}
+
+	// SVG arcs cannot describe a full ellipse with a single command, so
+	// large sweeps are split in two halves.
+	delta = math.Max(-2*math.Pi, math.Min(delta, 2*math.Pi))
+	if math.Abs(delta) > math.Pi {
+		half := delta / 2
+		r.ArcTo(cx, cy, rx, ry, startAngle, half)
+		r.ArcTo(cx, cy, rx, ry, startAngle+half, half)
+		return
+	}
+
+	sx := float64(cx) + rx*math.Cos(startAngle)
+	sy := float64(cy) + ry*math.Sin(startAngle)
+	ex := float64(cx) + rx*math.Cos(startAngle+delta)
+	ey := float64(cy) + ry*math.Sin(startAngle+delta)
+
+	if len(r.path) == 0 {
+		r.path = append(r.path, fmt.Sprintf("M %s %s", formatFloat(sx), formatFloat(sy)))
+	} else {
+		r.path = append(r.path, fmt.Sprintf("L %s %s", formatFloat(sx), formatFloat(sy)))
+	}
+
+	sweepFlag := 1
+	if delta < 0 {
+		sweepFlag = 0
+	}
+	r.path = append(r.path, fmt.Sprintf("A %s %s 0 0 %d %s %s",
+		formatFloat(rx), formatFloat(ry), sweepFlag, formatFloat(ex), formatFloat(ey)))
+}
+
+// Close finalizes a shape, closing the path.
+func (r *Renderer) Close() {
+	if len(r.path) > 0 {
+		r.path = append(r.path, "Z")
+	}
+}
+
+// Stroke strokes the current path.
+func (r *Renderer) Stroke() {
+	r.drawPath(false, true)
+}
+
+// Fill fills the current path.
+func (r *Renderer) Fill() {
+	r.drawPath(true, false)
+}
+
+// FillStroke fills and strokes the current path.
+func (r *Renderer) FillStroke() {
+	r.drawPath(true, true)
+}
+
+// Circle draws a circle at the given coordinates, with a given radius.
+func (r *Renderer) Circle(radius float64, x, y int) {
+	rs := formatFloat(radius)
+	r.path = append(r.path,
+		fmt.Sprintf("M %s %d", formatFloat(float64(x)-radius), y),
+		fmt.Sprintf("A %s %s 0 1 0 %s %d", rs, rs, formatFloat(float64(x)+radius), y),
+		fmt.Sprintf("A %s %s 0 1 0 %s %d", rs, rs, formatFloat(float64(x)-radius), y),
+		"Z",
+	)
+}
+
+// SetFont sets the current font.
+func (r *Renderer) SetFont(font render.Font) {
+	r.font = font
+}
+
+// SetFontColor sets the current font color.
+func (r *Renderer) SetFontColor(c color.Color) {
+	r.fontColor = c
+}
+
+// SetFontSize sets the current font size.
+func (r *Renderer) SetFontSize(size float64) {
+	r.fontSize = size
+}
+
+// Text draws a text chunk.
+func (r *Renderer) Text(body string, x, y int) {
+	if body == "" {
+		return
+	}
+
+	var attrs []string
+	attrs = append(attrs, fmt.Sprintf(`x="%d" y="%d"`, x, y))
+	if r.textRotation != 0 {
+		attrs = append(attrs, fmt.Sprintf(`transform="rotate(%s,%d,%d)"`,
+			formatFloat(mathutil.RadiansToDegrees(r.textRotation)), x, y))
+	}
+	attrs = append(attrs, fmt.Sprintf(`style="%s"`, r.textStyle()))
+	if r.className != "" {
+		attrs = append(attrs, fmt.Sprintf(`class="%s"`, html.EscapeString(r.className)))
+	}
+
+	r.elements = append(r.elements, fmt.Sprintf("<text %s>%s</text>",
+		strings.Join(attrs, " "), html.EscapeString(body)))
+}
+
+// MeasureText measures the specified text.
+func (r *Renderer) MeasureText(body string) render.Box {
+	size := r.fontSizePixels()
+
+	var width float64
+	for _, c := range body {
+		width += runeWidth(c) * size
+	}
+
+	return render.Box{
+		Right:  int(math.Ceil(width)),
+		Bottom: int(math.Ceil(size)),
+	}
+}
+
+// SetTextRotation sets the rotation of the text.
+func (r *Renderer) SetTextRotation(radians float64) {
+	r.textRotation = radians
+}
+
+// ClearTextRotation clears rotation of the text.
+func (r *Renderer) ClearTextRotation() {
+	r.textRotation = 0
+}
+
+// Save saves the rendered data to the given writer.
+func (r *Renderer) Save(w io.Writer) error {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`,
+		r.width, r.height, r.width, r.height)
+	buf.WriteString("\n")
+	for _, element := range r.elements {
+		buf.WriteString(element)
+		buf.WriteString("\n")
+	}
+	buf.WriteString("</svg>\n")
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func (r *Renderer) drawPath(fill, stroke bool) {
+	if len(r.path) == 0 {
+		return
+	}
+
+	var styles []string
+	if fill && !render.ColorIsZero(r.fillColor) {
+		styles = append(styles, colorStyle("fill", r.fillColor))
+	} else {
+		styles = append(styles, "fill:none")
+	}
+
+	if stroke && r.strokeWidth > 0 && !render.ColorIsZero(r.strokeColor) {
+		styles = append(styles, colorStyle("stroke", r.strokeColor))
+		styles = append(styles, fmt.Sprintf("stroke-width:%s", formatFloat(r.strokeWidth)))
+		if len(r.strokeDashArray) > 0 {
+			var dashes []string
+			for _, v := range r.strokeDashArray {
+				dashes = append(dashes, formatFloat(v))
+			}
+			styles = append(styles, fmt.Sprintf("stroke-dasharray:%s", strings.Join(dashes, ",")))
+		}
+	} else {
+		styles = append(styles, "stroke:none")
+	}
+
+	element := fmt.Sprintf(`<path d="%s" style="%s"`, strings.Join(r.path, " "), strings.Join(styles, ";"))
+	if r.className != "" {
+		element += fmt.Sprintf(` class="%s"`, html.EscapeString(r.className))
+	}
+	r.elements = append(r.elements, element+"/>")
+	r.path = nil
+}
+
+func (r *Renderer) textStyle() string {
+	family := defaultFontFamily
+	if r.font != nil && r.font.String() != "" {
+		family = r.font.String()
+	}
+
+	return strings.Join([]string{
+		colorStyle("fill", r.fontColor),
+		"stroke:none",
+		fmt.Sprintf("font-size:%spx", formatFloat(r.fontSizePixels())),
+		fmt.Sprintf("font-family:%s", html.EscapeString(family)),
+	}, ";")
+}
+
+func (r *Renderer) fontSizePixels() float64 {
+	return r.fontSize * r.dpi / defaultDPI
+}
+
+// colorStyle returns the SVG style declarations of a color for the
+// specified property (fill or stroke).
+func colorStyle(property string, c color.Color) string {
+	if render.ColorIsZero(c) {
+		return property + ":none"
+	}
+
+	rgba := color.NRGBAModel.Convert(c).(color.NRGBA)
+	style := fmt.Sprintf("%s:rgb(%d,%d,%d)", property, rgba.R, rgba.G, rgba.B)
+	if rgba.A < 255 {
+		style += fmt.Sprintf(";%s-opacity:%s", property, formatFloat(float64(rgba.A)/255.0))
+	}
+	return style
+}
+
+// runeWidth returns the approximate width of a rune, relative to the
+// font size, for a proportional sans-serif font.
+func runeWidth(c rune) float64 {
+	switch {
+	case strings.ContainsRune("il.,:;'|!`", c):
+		return 0.28
+	case strings.ContainsRune("fjtrI()[]{} -\"", c):
+		return 0.36
+	case strings.ContainsRune("mwMW@%", c):
+		return 0.86
+	case c >= 'A' && c <= 'Z':
+		return 0.68
+	case c > 0x2E7F:
+		// CJK and other wide characters.
+		return 1.0
+	}
+	return 0.56
+}
+
+func formatFloat(v float64) string {
+	s := fmt.Sprintf("%.2f", v)
+	s = strings.TrimRight(s, "0")
+	s = strings.TrimSuffix(s, ".")
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
@@ -0,0 +1,91 @@
+package svg
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/unidoc/unichart/render"
+)
+
+func TestRendererPath(t *testing.T) {
+	r, err := NewRenderer(100, 50)
+	require.NoError(t, err)
+
+	r.SetStrokeColor(render.ColorBlue)
+	r.SetStrokeWidth(2)
+	r.SetStrokeDashArray([]float64{4, 2})
+	r.MoveTo(0, 0)
+	r.LineTo(10, 20)
+	r.Stroke()
+
+	buf := bytes.NewBuffer(nil)
+	require.NoError(t, r.Save(buf))
+
+	output := buf.String()
+	require.True(t, strings.HasPrefix(output, `<svg xmlns="http://www.w3.org/2000/svg" width="100" height="50"`))
+	require.Contains(t, output, `<path d="M 0 0 L 10 20" style="fill:none;stroke:rgb(0,116,217);stroke-width:2;stroke-dasharray:4,2"/>`)
+}
+
+func TestRendererArcTo(t *testing.T) {
+	r, err := NewRenderer(100, 100)
+	require.NoError(t, err)
+
+	r.SetFillColor(render.ColorRed)
+	r.ArcTo(50, 50, 10, 10, 0, 2*math.Pi)
+	r.Close()
+	r.Fill()
+
+	buf := bytes.NewBuffer(nil)
+	require.NoError(t, r.Save(buf))
+	require.Contains(t, buf.String(), `d="M 60 50 A 10 10 0 0 1 40 50 L 40 50 A 10 10 0 0 1 60 50 Z"`)
+}
+
+func TestRendererText(t *testing.T) {
+	r, err := NewRenderer(100, 100)
+	require.NoError(t, err)
+
+	r.SetFontSize(10)
+	r.SetFontColor(render.ColorBlack)
+	r.SetTextRotation(math.Pi / 2)
+	r.Text("a < b", 10, 20)
+
+	buf := bytes.NewBuffer(nil)
+	require.NoError(t, r.Save(buf))
+	require.Contains(t, buf.String(), `<text x="10" y="20" transform="rotate(90,10,20)"`)
+	require.Contains(t, buf.String(), `>a &lt; b</text>`)
+
+	box := r.MeasureText("hello")
+	require.Equal(t, 10, box.Height())
+	require.True(t, box.Width() > 0)
+	require.True(t, r.MeasureText("hello world").Width() > box.Width())
+}
+
+func TestRendererRichText(t *testing.T) {
+	r, err := NewRenderer(100, 100)
+	require.NoError(t, err)
+
+	style := render.Style{FontSize: 10, FontColor: render.ColorBlack}
+	render.Text.Draw(r, "m<sup>2</sup>", 10, 20, style)
+
+	box := render.Text.Measure(r, "m<sup>2</sup>", style)
+	require.Equal(t, r.MeasureText("m").Width()+r.MeasureText("2").Width()*7/10, box.Width())
+	require.True(t, box.Height() > r.MeasureText("m").Height())
+
+	buf := bytes.NewBuffer(nil)
+	require.NoError(t, r.Save(buf))
+	require.Contains(t, buf.String(), `<text x="10" y="20" style="fill:`)
+	require.Contains(t, buf.String(), `font-size:7px`)
+	require.Contains(t, buf.String(), `>2</text>`)
+
+	lines := render.Text.WrapFit(r, "<b>bold text</b> plain", 40, render.Style{TextWrap: render.TextWrapWord})
+	require.Equal(t, []string{"<b>bold</b>", "<b>text</b>", "plain"}, lines)
+}
+
+func TestNewRendererInvalidSize(t *testing.T) {
+	_, err := NewRenderer(0, 10)
+	require.Error(t, err)
+}
@@ -88,22 +88,29 @@ var (
 	Text = &text{}
 )
 
+// Measure measures text with a given style. The text can contain rich text
+// markup (see ParseRichText).
 func (t text) Measure(r Renderer, text string, style Style) Box {
 	style.GetTextOptions().WriteToRenderer(r)
 	defer r.ResetStyle()
 
+	if IsRichText(text) {
+		return measureRichText(r, ParseRichText(text), style.GetTextOptions())
+	}
 	return r.MeasureText(text)
 }
 
-// Draw draws text with a given style.
+// Draw draws text with a given style, starting at the baseline point
+// (x, y). The text can contain rich text markup (see ParseRichText).
 func (t text) Draw(r Renderer, text string, x, y int, style Style) {
 	style.GetTextOptions().WriteToRenderer(r)
 	defer r.ResetStyle()
 
-	r.Text(text, x, y)
+	t.draw(r, text, x, y, style.GetTextOptions())
 }
 
-// DrawWithin draws the text within a given box.
+// DrawWithin draws the text within a given box. The text can contain rich
+// text markup (see ParseRichText), which is preserved across wrapped lines.
 func (t text) DrawWithin(r Renderer, text string, box Box, style Style) {
 	style.GetTextOptions().WriteToRenderer(r)
 	defer r.ResetStyle()
@@ -124,7 +131,7 @@ func (t text) DrawWithin(r Renderer, text string, box Box, style Style) {
 
 	var tx, ty int
 	for _, line := range lines {
-		lineBox := r.MeasureText(line)
+		lineBox := t.measureLine(r, line, style)
 		switch style.GetTextHorizontalAlign() {
 		case TextHorizontalAlignCenter:
 			tx = box.Left + ((box.Width() - lineBox.Width()) >> 1)
@@ -139,12 +146,25 @@ func (t text) DrawWithin(r Renderer, text string, box Box, style Style) {
 			ty = y
 		}
 
-		r.Text(line, tx, ty)
+		t.draw(r, line, tx, ty, style)
 		y += lineBox.Height() + style.GetTextLineSpacing()
 	}
 }
 
+// WrapFit splits the text into lines which fit within the specified width,
+// according to the text wrap option of the style. The lines of rich text
+// are returned as rich text markup.
 func (t text) WrapFit(r Renderer, value string, width int, style Style) []string {
+	if IsRichText(value) {
+		switch style.TextWrap {
+		case TextWrapRune:
+			return wrapFitRichText(r, value, width, style, false)
+		case TextWrapWord:
+			return wrapFitRichText(r, value, width, style, true)
+		}
+		return []string{value}
+	}
+
 	switch style.TextWrap {
 	case TextWrapRune:
 		return t.WrapFitRune(r, value, width, style)
@@ -226,7 +246,7 @@ func (t text) MeasureLines(r Renderer, lines []string, style Style) Box {
 	style.WriteTextOptionsToRenderer(r)
 	var output Box
 	for index, line := range lines {
-		lineBox := r.MeasureText(line)
+		lineBox := t.measureLine(r, line, style)
 		output.Right = mathutil.MaxInt(lineBox.Right, output.Right)
 		output.Bottom += lineBox.Height()
 		if index < len(lines)-1 {
@@ -236,6 +256,28 @@ func (t text) MeasureLines(r Renderer, lines []string, style Style) Box {
 	return output
 }
 
+// measureLine measures a line of text, assuming the text options of the
+// style have been written to the renderer.
+func (t text) measureLine(r Renderer, line string, style Style) Box {
+	if !IsRichText(line) {
+		return r.MeasureText(line)
+	}
+
+	lineBox := measureRichText(r, ParseRichText(line), style)
+	style.WriteTextOptionsToRenderer(r)
+	return lineBox
+}
+
+// draw draws a line of text, assuming the text options of the style have
+// been written to the renderer.
+func (t text) draw(r Renderer, line string, x, y int, style Style) {
+	if !IsRichText(line) {
+		r.Text(line, x, y)
+		return
+	}
+	drawRichText(r, ParseRichText(line), x, y, style)
+}
+
 func (t text) appendLast(lines []string, text string) []string {
 	if len(lines) == 0 {
 		return []string{text}
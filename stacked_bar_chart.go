@@ -7,7 +7,6 @@ import (
 	"math"
 
 	"github.com/unidoc/unichart/dataset"
-	"github.com/unidoc/unichart/dataset/sequence"
 	"github.com/unidoc/unichart/mathutil"
 	"github.com/unidoc/unichart/render"
 )
@@ -27,10 +26,30 @@ func (sb StackedBar) GetWidth() int {
 	return sb.Width
 }
 
+// StackedBarsFromTable returns a stacked bar for each row of the table,
+// named using the label column. The bar sections are created from the
+// specified value columns, or from all the other columns if none are given.
+func StackedBarsFromTable(t *dataset.Table, labelRef string, valueRefs ...string) ([]StackedBar, error) {
+	labels, groups, _, err := t.StackedValues(labelRef, valueRefs...)
+	if err != nil {
+		return nil, err
+	}
+
+	bars := make([]StackedBar, len(groups))
+	for i, values := range groups {
+		bars[i] = StackedBar{Name: labels[i], Values: values}
+	}
+	return bars, nil
+}
+
 // StackedBarChart is a chart that draws sections of a bar based on percentages.
 type StackedBarChart struct {
-	Title      string
-	TitleStyle render.Style
+	Title         string
+	TitleStyle    render.Style
+	Subtitle      string
+	SubtitleStyle render.Style
+	Caption       string
+	CaptionStyle  render.Style
 
 	Font         render.Font
 	Background   render.Style
@@ -43,6 +62,17 @@ type StackedBarChart struct {
 	BarSpacing   int
 	IsHorizontal bool
 
+	// IsReversed reverses the value axis, so that bar sections are stacked
+	// from the bottom (or left for horizontal bars), and the percentages of
+	// the axis start at the top (or right).
+	IsReversed bool
+
+	// RangePolicy adjusts the range of the value axis, which spans the
+	// percentages of the bars from 0% to 100% by default. The bounds of the
+	// range are fractions, 1 being 100%. Bar sections outside of the range
+	// are cut to the canvas.
+	RangePolicy RangePolicy
+
 	Bars     []StackedBar
 	Elements []render.Renderable
 
@@ -112,6 +142,10 @@ func (sbc StackedBarChart) Render(rp render.RendererProvider, w io.Writer) error
 		return errors.New("please provide at least one bar")
 	}
 
+	if min, max := sbc.getValueRange(); !(max > min) {
+		return fmt.Errorf("invalid data range; cannot be zero")
+	}
+
 	r, err := rp(sbc.Width(), sbc.Height())
 	if err != nil {
 		return err
@@ -120,13 +154,13 @@ func (sbc StackedBarChart) Render(rp render.RendererProvider, w io.Writer) error
 
 	var canvasBox render.Box
 	if sbc.IsHorizontal {
-		canvasBox = sbc.getHorizontalAdjustedCanvasBox(r, sbc.getDefaultCanvasBox())
+		canvasBox = sbc.getHorizontalAdjustedCanvasBox(r, sbc.getDefaultCanvasBox(r))
 		sbc.drawCanvas(r, canvasBox)
 		sbc.drawHorizontalBars(r, canvasBox)
 		sbc.drawHorizontalXAxis(r, canvasBox)
 		sbc.drawHorizontalYAxis(r, canvasBox)
 	} else {
-		canvasBox = sbc.getAdjustedCanvasBox(r, sbc.getDefaultCanvasBox())
+		canvasBox = sbc.getAdjustedCanvasBox(r, sbc.getDefaultCanvasBox(r))
 		sbc.drawCanvas(r, canvasBox)
 		sbc.drawBars(r, canvasBox)
 		sbc.drawXAxis(r, canvasBox)
@@ -166,39 +200,47 @@ func (sbc StackedBarChart) drawBar(r render.Renderer, canvasBox render.Box, xoff
 	bxl := xoffset + barSpacing2
 	bxr := bxl + bar.GetWidth()
 
+	// The sections are stacked from the top of the bar, the first one
+	// covering the values from 100% down.
 	normalizedBarComponents := dataset.Values(bar.Values).Normalize()
 	lenBarComponents := len(normalizedBarComponents)
-	yoffset := canvasBox.Top
+	cursor := 1.0
 
 	for index, bv := range normalizedBarComponents {
-		var barHeight int
+		from, to := cursor, cursor-bv.Value
 		if index == lenBarComponents-1 {
-			// Use remaining space for the last bar component in order to
-			// avoid rounding inconsistencies.
-			barHeight = canvasBox.Bottom - render.DefaultStrokeWidth - yoffset
-		} else {
-			barHeight = int(math.Ceil(bv.Value * float64(canvasBox.Height())))
+			// Stack the last bar component down to zero in order to avoid
+			// rounding inconsistencies.
+			to = 0
 		}
+		cursor = to
 
 		// Draw bar.
 		barStyle := bv.Style.InheritFrom(sbc.styleDefaultsStackedBarValue(index))
 		strokeOffset := int(barStyle.StrokeWidth / 2)
 
+		yfrom := canvasBox.Bottom - sbc.translateValue(from, canvasBox.Height())
+		yto := mathutil.MinInt(canvasBox.Bottom-sbc.translateValue(to, canvasBox.Height()), canvasBox.Bottom-render.DefaultStrokeWidth) - strokeOffset
+		if yto <= yfrom {
+			continue
+		}
+
+		top := sbc.getValueY(canvasBox, yfrom)
+		bottom := sbc.getValueY(canvasBox, yto)
 		barBox := render.Box{
-			Top:    yoffset,
+			Top:    mathutil.MinInt(top, bottom),
 			Left:   bxl,
 			Right:  bxr,
-			Bottom: mathutil.MinInt(yoffset+barHeight, canvasBox.Bottom-render.DefaultStrokeWidth) - strokeOffset,
+			Bottom: mathutil.MaxInt(top, bottom),
 		}
 		barBox.Draw(r, barStyle)
 
 		// Draw label.
 		if len(bv.Label) > 0 {
 			lx := bxl + ((bxr - bxl) / 2)
-			ly := yoffset + (barHeight / 2)
+			ly := sbc.getValueY(canvasBox, yfrom+(yto-yfrom)/2)
 
-			barStyle.WriteToRenderer(r)
-			tb := r.MeasureText(bv.Label)
+			tb := render.Text.Measure(r, bv.Label, barStyle)
 			lx = lx - (tb.Width() / 2)
 			ly = ly + (tb.Height() / 2)
 
@@ -209,11 +251,8 @@ func (sbc StackedBarChart) drawBar(r render.Renderer, canvasBox render.Box, xoff
 				lx = 0
 			}
 
-			r.Text(bv.Label, lx, ly)
+			render.Text.Draw(r, bv.Label, lx, ly, barStyle)
 		}
-
-		// Update Y offset.
-		yoffset += barHeight
 	}
 
 	return bxr
@@ -224,27 +263,37 @@ func (sbc StackedBarChart) drawHorizontalBar(r render.Renderer, canvasBox render
 	boxTop := yoffset + halfBarSpacing
 	boxBottom := boxTop + bar.GetWidth()
 
+	// The sections are stacked from the right of the bar, the first one
+	// covering the values from 100% down.
 	normalizedBarComponents := dataset.Values(bar.Values).Normalize()
 	lenBarComponents := len(normalizedBarComponents)
-	xOffset := canvasBox.Right
+	cursor := 1.0
+
 	for index, bv := range normalizedBarComponents {
-		var barWidth int
+		from, to := cursor, cursor-bv.Value
 		if index == lenBarComponents-1 {
-			// Use remaining space for the last bar component in order to
-			// avoid rounding inconsistencies.
-			barWidth = xOffset - canvasBox.Left - render.DefaultStrokeWidth
-		} else {
-			barWidth = int(math.Ceil(bv.Value * float64(canvasBox.Width())))
+			// Stack the last bar component down to zero in order to avoid
+			// rounding inconsistencies.
+			to = 0
 		}
+		cursor = to
 
 		// Draw bar.
 		barStyle := bv.Style.InheritFrom(sbc.styleDefaultsStackedBarValue(index))
 		strokeOffset := int(barStyle.StrokeWidth / 2)
 
+		xfrom := canvasBox.Left + sbc.translateValue(from, canvasBox.Width())
+		xto := mathutil.MaxInt(canvasBox.Left+sbc.translateValue(to, canvasBox.Width()), canvasBox.Left+render.DefaultStrokeWidth) + strokeOffset
+		if xto >= xfrom {
+			continue
+		}
+
+		left := sbc.getValueX(canvasBox, xto)
+		right := sbc.getValueX(canvasBox, xfrom)
 		barBox := render.Box{
 			Top:    boxTop,
-			Left:   mathutil.MinInt(xOffset-barWidth, canvasBox.Left+render.DefaultStrokeWidth) + strokeOffset,
-			Right:  xOffset,
+			Left:   mathutil.MinInt(left, right),
+			Right:  mathutil.MaxInt(left, right),
 			Bottom: boxBottom,
 		}
 
@@ -252,11 +301,10 @@ func (sbc StackedBarChart) drawHorizontalBar(r render.Renderer, canvasBox render
 
 		// Draw label.
 		if len(bv.Label) > 0 {
-			lx := xOffset - (barWidth / 2)
+			lx := sbc.getValueX(canvasBox, xto+(xfrom-xto)/2)
 			ly := boxTop + ((boxBottom - boxTop) / 2)
 
-			barStyle.WriteToRenderer(r)
-			tb := r.MeasureText(bv.Label)
+			tb := render.Text.Measure(r, bv.Label, barStyle)
 			lx = lx - (tb.Width() >> 1)
 			ly = ly + (tb.Height() >> 1)
 
@@ -267,12 +315,64 @@ func (sbc StackedBarChart) drawHorizontalBar(r render.Renderer, canvasBox render
 				lx = 0
 			}
 
-			r.Text(bv.Label, lx, ly)
+			render.Text.Draw(r, bv.Label, lx, ly, barStyle)
 		}
+	}
+}
+
+// getValueRange returns the bounds of the value axis, as fractions of the
+// bars, adjusted by the range policy.
+func (sbc StackedBarChart) getValueRange() (min, max float64) {
+	round, _ := sbc.RangePolicy.rounding(false)
+	return sbc.RangePolicy.apply(0, 1, round)
+}
+
+// translateValue returns the distance of a value, as a fraction of the
+// bars, from the start of a value axis of the specified length. Values
+// outside of the range are cut to the axis.
+func (sbc StackedBarChart) translateValue(value float64, length int) int {
+	min, max := sbc.getValueRange()
+	value = math.Max(min, math.Min(value, max))
+	return int(math.Round((value - min) / (max - min) * float64(length)))
+}
 
-		// Update X offset.
-		xOffset -= barWidth
+// getValueTicks returns the values of the ticks of the value axis, as
+// fractions of the bars. The ticks are placed at multiples of a step
+// producing at most five intervals, 20% for the default range.
+func (sbc StackedBarChart) getValueTicks() []float64 {
+	min, max := sbc.getValueRange()
+
+	step := 0.01
+	for _, s := range []float64{0.01, 0.02, 0.05, 0.1, 0.2, 0.25, 0.5, 1, 2, 5, 10} {
+		step = s
+		if (max-min)/s <= 5 {
+			break
+		}
+	}
+
+	var ticks []float64
+	for i := int(math.Ceil(min/step - 1e-9)); float64(i)*step <= max+1e-9; i++ {
+		ticks = append(ticks, float64(i)*step)
 	}
+	return ticks
+}
+
+// getValueX returns the horizontal position along the value axis of
+// horizontal bars, mirrored across the canvas if the axis is reversed.
+func (sbc StackedBarChart) getValueX(canvasBox render.Box, x int) int {
+	if sbc.IsReversed {
+		return canvasBox.Left + canvasBox.Right - x
+	}
+	return x
+}
+
+// getValueY returns the vertical position along the value axis of vertical
+// bars, mirrored across the canvas if the axis is reversed.
+func (sbc StackedBarChart) getValueY(canvasBox render.Box, y int) int {
+	if sbc.IsReversed {
+		return canvasBox.Top + canvasBox.Bottom - y
+	}
+	return y
 }
 
 func (sbc StackedBarChart) drawXAxis(r render.Renderer, canvasBox render.Box) {
@@ -321,10 +421,9 @@ func (sbc StackedBarChart) drawHorizontalXAxis(r render.Renderer, canvasBox rend
 		r.LineTo(canvasBox.Left, canvasBox.Bottom+defaultVerticalTickHeight)
 		r.Stroke()
 
-		ticks := sequence.LinearRangeWithStep(0.0, 1.0, 0.2)
-		for _, t := range ticks {
+		for _, t := range sbc.getValueTicks() {
 			axisStyle.GetStrokeOptions().WriteToRenderer(r)
-			tx := canvasBox.Left + int(t*float64(canvasBox.Width()))
+			tx := sbc.getValueX(canvasBox, canvasBox.Left+sbc.translateValue(t, canvasBox.Width()))
 			r.MoveTo(tx, canvasBox.Bottom)
 			r.LineTo(tx, canvasBox.Bottom+defaultVerticalTickHeight)
 			r.Stroke()
@@ -336,7 +435,7 @@ func (sbc StackedBarChart) drawHorizontalXAxis(r render.Renderer, canvasBox rend
 			textX := tx - (textBox.Width() >> 1)
 			textY := canvasBox.Bottom + defaultXAxisMargin + 10
 
-			if t == 1 {
+			if textX+textBox.Width() > canvasBox.Right {
 				textX = canvasBox.Right - textBox.Width()
 			}
 
@@ -357,10 +456,9 @@ func (sbc StackedBarChart) drawYAxis(r render.Renderer, canvasBox render.Box) {
 		r.LineTo(canvasBox.Right+defaultHorizontalTickWidth, canvasBox.Bottom)
 		r.Stroke()
 
-		ticks := sequence.LinearRangeWithStep(0.0, 1.0, 0.2)
-		for _, t := range ticks {
+		for _, t := range sbc.getValueTicks() {
 			axisStyle.GetStrokeOptions().WriteToRenderer(r)
-			ty := canvasBox.Bottom - int(t*float64(canvasBox.Height()))
+			ty := sbc.getValueY(canvasBox, canvasBox.Bottom-sbc.translateValue(t, canvasBox.Height()))
 			r.MoveTo(canvasBox.Right, ty)
 			r.LineTo(canvasBox.Right+defaultHorizontalTickWidth, ty)
 			r.Stroke()
@@ -408,22 +506,12 @@ func (sbc StackedBarChart) drawHorizontalYAxis(r render.Renderer, canvasBox rend
 }
 
 func (sbc StackedBarChart) drawTitle(r render.Renderer) {
-	if len(sbc.Title) > 0 && !sbc.TitleStyle.Hidden {
-		r.SetFont(sbc.TitleStyle.GetFont(sbc.GetFont()))
-		r.SetFontColor(sbc.TitleStyle.GetFontColor(sbc.GetColorPalette().TextColor()))
-		titleFontSize := sbc.TitleStyle.GetFontSize(defaultTitleFontSize)
-		r.SetFontSize(titleFontSize)
-
-		textBox := r.MeasureText(sbc.Title)
-
-		textWidth := textBox.Width()
-		textHeight := textBox.Height()
-
-		titleX := (sbc.Width() >> 1) - (textWidth >> 1)
-		titleY := sbc.TitleStyle.Padding.GetTop(defaultTitleTop) + textHeight
+	sbc.getTitles().draw(r, sbc.Box())
+}
 
-		r.Text(sbc.Title, titleX, titleY)
-	}
+func (sbc StackedBarChart) getTitles() chartTitles {
+	return newChartTitles(sbc.styleDefaultsTitle(),
+		sbc.Title, sbc.TitleStyle, sbc.Subtitle, sbc.SubtitleStyle, sbc.Caption, sbc.CaptionStyle)
 }
 
 func (sbc StackedBarChart) getCanvasStyle() render.Style {
@@ -446,8 +534,8 @@ func (sbc StackedBarChart) GetColorPalette() render.ColorPalette {
 	return render.AlternateColorPalette
 }
 
-func (sbc StackedBarChart) getDefaultCanvasBox() render.Box {
-	return sbc.Box()
+func (sbc StackedBarChart) getDefaultCanvasBox(r render.Renderer) render.Box {
+	return sbc.getTitles().adjust(r, sbc.Box())
 }
 
 func (sbc StackedBarChart) getAdjustedCanvasBox(r render.Renderer, canvasBox render.Box) render.Box {
@@ -481,7 +569,7 @@ func (sbc StackedBarChart) getAdjustedCanvasBox(r render.Renderer, canvasBox ren
 			Top:    canvasBox.Top,
 			Left:   canvasBox.Left,
 			Right:  canvasBox.Left + totalWidth,
-			Bottom: sbc.Height() - xaxisHeight,
+			Bottom: canvasBox.Bottom - xaxisHeight,
 		}
 	}
 	return render.Box{
@@ -560,9 +648,9 @@ func (sbc StackedBarChart) styleDefaultsStackedBarValue(index int) render.Style
 
 func (sbc StackedBarChart) styleDefaultsTitle() render.Style {
 	return sbc.TitleStyle.InheritFrom(render.Style{
-		FontColor:           render.DefaultTextColor,
+		FontColor:           sbc.GetColorPalette().TextColor(),
 		Font:                sbc.GetFont(),
-		FontSize:            sbc.getTitleFontSize(),
+		FontSize:            defaultTitleFontSize,
 		TextHorizontalAlign: render.TextHorizontalAlignCenter,
 		TextVerticalAlign:   render.TextVerticalAlignTop,
 		TextWrap:            render.TextWrapWord,
@@ -583,20 +671,6 @@ func (sbc StackedBarChart) getScaledFontSize() float64 {
 	return 10.0
 }
 
-func (sbc StackedBarChart) getTitleFontSize() float64 {
-	effectiveDimension := mathutil.MinInt(sbc.Width(), sbc.Height())
-	if effectiveDimension >= 2048 {
-		return 48
-	} else if effectiveDimension >= 1024 {
-		return 24
-	} else if effectiveDimension >= 512 {
-		return 18
-	} else if effectiveDimension >= 256 {
-		return 12
-	}
-	return 10
-}
-
 func (sbc StackedBarChart) styleDefaultsAxes() render.Style {
 	return render.Style{
 		StrokeColor:         render.DefaultLineColor,
@@ -0,0 +1,46 @@
+package unichart
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/unidoc/unichart/dataset"
+	"github.com/unidoc/unichart/render/raster"
+)
+
+func TestStackedBarChartRangePolicy(t *testing.T) {
+	sbc := StackedBarChart{
+		Bars: []StackedBar{
+			{Name: "a", Values: []dataset.Value{{Value: 3}, {Value: 1}}},
+		},
+	}
+
+	// The value axis spans the percentages of the bars by default.
+	min, max := sbc.getValueRange()
+	require.Equal(t, 0.0, min)
+	require.Equal(t, 1.0, max)
+	require.InDeltaSlice(t, []float64{0, 0.2, 0.4, 0.6, 0.8, 1}, sbc.getValueTicks(), 1e-9)
+	require.Equal(t, 50, sbc.translateValue(0.5, 100))
+
+	// Clamped ranges zoom on part of the bars.
+	clampMin := 0.5
+	sbc.RangePolicy = RangePolicy{ClampMin: &clampMin}
+	require.InDeltaSlice(t, []float64{0.5, 0.6, 0.7, 0.8, 0.9, 1}, sbc.getValueTicks(), 1e-9)
+	require.Equal(t, 50, sbc.translateValue(0.75, 100))
+	require.Equal(t, 0, sbc.translateValue(0.25, 100))
+	require.NoError(t, sbc.Render(raster.NewRenderer, bytes.NewBuffer(nil)))
+
+	// Padded ranges extend past the percentages of the bars.
+	sbc.RangePolicy = RangePolicy{PadPercent: 10}
+	min, max = sbc.getValueRange()
+	require.InDelta(t, -0.1, min, 1e-9)
+	require.InDelta(t, 1.1, max, 1e-9)
+	sbc.IsHorizontal = true
+	require.NoError(t, sbc.Render(raster.NewRenderer, bytes.NewBuffer(nil)))
+
+	clampMax := 0.2
+	sbc.RangePolicy = RangePolicy{ClampMin: &clampMin, ClampMax: &clampMax}
+	require.Error(t, sbc.Render(raster.NewRenderer, bytes.NewBuffer(nil)))
+}
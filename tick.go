@@ -2,6 +2,7 @@ package unichart
 
 import (
 	"math"
+	"sort"
 	"strconv"
 
 	"github.com/unidoc/unichart/dataset"
@@ -24,6 +25,36 @@ const (
 	TickPositionUnderTick TickPosition = 2
 )
 
+// TickDirection is an enumeration of possible tick mark directions,
+// relative to the canvas.
+type TickDirection int
+
+const (
+	// TickDirectionUnset means to use the default tick direction.
+	TickDirectionUnset TickDirection = 0
+
+	// TickDirectionOutside draws the tick marks outside the canvas.
+	TickDirectionOutside TickDirection = 1
+
+	// TickDirectionInside draws the tick marks inside the canvas.
+	TickDirectionInside TickDirection = 2
+
+	// TickDirectionCross draws the tick marks across the axis line.
+	TickDirectionCross TickDirection = 3
+)
+
+// extent returns the length of a tick mark of the specified length, inside
+// and outside the canvas.
+func (td TickDirection) extent(length int) (inside, outside int) {
+	switch td {
+	case TickDirectionInside:
+		return length, 0
+	case TickDirectionCross:
+		return length >> 1, length - length>>1
+	}
+	return 0, length
+}
+
 // TicksProvider is a type that provides ticks.
 type TicksProvider interface {
 	GetTicks(r render.Renderer, defaults render.Style, vf dataset.ValueFormatter) []Tick
@@ -35,6 +66,112 @@ type Tick struct {
 	Label string
 }
 
+// HistogramTicks returns ticks at the specified histogram bin edges, such
+// as the edges of a dataset.BinnedHistogramSeries, to be used as the X axis
+// ticks of a histogram. When a step greater than 1 is specified, only every
+// step-th edge gets a tick, in order to avoid crowded labels. The last edge
+// always gets a tick, so that the ticks cover all the bins.
+func HistogramTicks(edges []float64, vf dataset.ValueFormatter, steps ...int) []Tick {
+	if vf == nil {
+		vf = dataset.FloatValueFormatter
+	}
+	step := 1
+	if len(steps) > 0 && steps[0] > 1 {
+		step = steps[0]
+	}
+
+	var ticks []Tick
+	for i := 0; i < len(edges); i += step {
+		ticks = append(ticks, Tick{
+			Value: edges[i],
+			Label: vf(edges[i]),
+		})
+	}
+	if last := len(edges) - 1; last > 0 && last%step != 0 {
+		ticks = append(ticks, Tick{
+			Value: edges[last],
+			Label: vf(edges[last]),
+		})
+	}
+	return ticks
+}
+
+// generateMinorTicks generates the minor ticks between the specified major
+// ticks. If a step is specified, the minor ticks are placed at its
+// multiples. Otherwise, count minor ticks are evenly placed between each
+// pair of major ticks, unless the major ticks are decades, as on logarithmic
+// scales, in which case the minor ticks are placed at 2 to 9 times each
+// decade.
+func generateMinorTicks(ticks []Tick, count int, step float64) []Tick {
+	if len(ticks) < 2 || (count <= 0 && step <= 0) {
+		return nil
+	}
+
+	values := make([]float64, len(ticks))
+	for i, t := range ticks {
+		values[i] = t.Value
+	}
+	sort.Float64s(values)
+
+	isDecades := true
+	for i, v := range values {
+		if v <= 0 || (i > 0 && math.Abs(v/values[i-1]-10) > 1e-9) {
+			isDecades = false
+			break
+		}
+	}
+
+	var minor []float64
+	for i := 1; i < len(values) && len(minor) < defaultTickCountSanityCheck; i++ {
+		start, end := values[i-1], values[i]
+		delta := end - start
+		if delta <= 0 {
+			continue
+		}
+		epsilon := delta * 1e-9
+
+		switch {
+		case step > 0:
+			for k := math.Floor(start/step) + 1; k*step < end-epsilon; k++ {
+				if len(minor) == defaultTickCountSanityCheck {
+					break
+				}
+				if k*step > start+epsilon {
+					minor = append(minor, k*step)
+				}
+			}
+		case isDecades:
+			for m := 2.0; m < 10; m++ {
+				minor = append(minor, start*m)
+			}
+		default:
+			for k := 1; k <= count; k++ {
+				minor = append(minor, start+delta*float64(k)/float64(count+1))
+			}
+		}
+	}
+
+	minorTicks := make([]Tick, len(minor))
+	for i, v := range minor {
+		minorTicks[i] = Tick{Value: v}
+	}
+	return minorTicks
+}
+
+// ticksWithin returns the ticks within the bounds of a range.
+func ticksWithin(ra sequence.Range, ticks []Tick) []Tick {
+	min, max := rangeBounds(ra)
+	epsilon := (max - min) * 1e-9
+
+	var within []Tick
+	for _, t := range ticks {
+		if t.Value >= min-epsilon && t.Value <= max+epsilon {
+			within = append(within, t)
+		}
+	}
+	return within
+}
+
 // generateContinuousTicks generates a set of ticks.
 func generateContinuousTicks(r render.Renderer, ra sequence.Range, isVertical bool, style render.Style, vf dataset.ValueFormatter) []Tick {
 	if vf == nil {
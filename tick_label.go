@@ -0,0 +1,309 @@
+package unichart
+
+import (
+	"math"
+	"sort"
+
+	"github.com/unidoc/unichart/mathutil"
+	"github.com/unidoc/unichart/render"
+)
+
+const (
+	// defaultTickLabelSpacing is the minimum distance between tick labels
+	// which are not considered to overlap.
+	defaultTickLabelSpacing = 5
+
+	// tickLabelEllipsis is appended to truncated tick labels.
+	tickLabelEllipsis = "..."
+)
+
+// TickLabelOverlap is a strategy used to resolve overlapping tick labels.
+type TickLabelOverlap int
+
+const (
+	// TickLabelOverlapAllow draws overlapping tick labels as they are.
+	TickLabelOverlapAllow TickLabelOverlap = iota
+
+	// TickLabelOverlapThin only draws every n-th tick label, with the
+	// smallest n for which the labels do not overlap.
+	TickLabelOverlapThin
+
+	// TickLabelOverlapStagger alternates tick labels between two rows
+	// (X axis) or two columns (Y axis).
+	TickLabelOverlapStagger
+
+	// TickLabelOverlapRotate45 rotates tick labels by 45 degrees, so that
+	// they end under their ticks. It only applies to X axes.
+	TickLabelOverlapRotate45
+
+	// TickLabelOverlapRotate90 rotates tick labels by 90 degrees, so that
+	// they end under their ticks. It only applies to X axes.
+	TickLabelOverlapRotate90
+
+	// TickLabelOverlapTruncate truncates tick labels to the space between
+	// their ticks, with an ellipsis. It only applies to X axes.
+	TickLabelOverlapTruncate
+)
+
+var (
+	// defaultXTickLabelOverlap is the default priority order of the
+	// strategies used to resolve overlapping X axis tick labels.
+	defaultXTickLabelOverlap = []TickLabelOverlap{
+		TickLabelOverlapStagger,
+		TickLabelOverlapRotate45,
+		TickLabelOverlapRotate90,
+		TickLabelOverlapThin,
+	}
+
+	// defaultYTickLabelOverlap is the default priority order of the
+	// strategies used to resolve overlapping Y axis tick labels.
+	defaultYTickLabelOverlap = []TickLabelOverlap{
+		TickLabelOverlapThin,
+	}
+)
+
+// tickLabelLayout is the placement of the tick labels of an axis, resolved
+// from overlaps.
+type tickLabelLayout struct {
+	strategy TickLabelOverlap
+
+	// labels holds the label drawn for each tick, which is empty for
+	// thinned labels.
+	labels []string
+
+	// rows holds the row (X axis) or column (Y axis) of each label.
+	rows []int
+
+	// rotation is the rotation of the labels, in degrees.
+	rotation float64
+
+	// width and height are the maximum dimensions of the labels.
+	width  int
+	height int
+}
+
+// newTickLabelLayout returns the layout of the specified labels, drawn at
+// the specified positions along an axis. The strategies are tried in order,
+// and the first one resolving all overlaps is used. If none does, the last
+// one is used. Labels of vertical axes only support the thin and stagger
+// strategies.
+func newTickLabelLayout(r render.Renderer, positions []int, ticks []Tick, style render.Style, isVertical bool,
+	strategies []TickLabelOverlap) tickLabelLayout {
+	ll := tickLabelLayout{
+		labels: make([]string, len(ticks)),
+		rows:   make([]int, len(ticks)),
+	}
+
+	sizes := make([]int, len(ticks))
+	for i, t := range ticks {
+		ll.labels[i] = t.Label
+
+		tb := render.Text.Measure(r, t.Label, style)
+		ll.width = mathutil.MaxInt(ll.width, tb.Width())
+		ll.height = mathutil.MaxInt(ll.height, tb.Height())
+		sizes[i] = tb.Width()
+		if isVertical {
+			sizes[i] = tb.Height()
+		}
+	}
+
+	// Sort the labels along the axis.
+	order := make([]int, len(ticks))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return positions[order[i]] < positions[order[j]]
+	})
+
+	// overlaps returns true if any two consecutive visible labels of a row
+	// overlap, given the size of the labels along the axis.
+	overlaps := func(visible func(rank int) bool, row func(rank int) int, size func(index int) float64) bool {
+		last := map[int]int{}
+		for rank, index := range order {
+			if !visible(rank) || len(ll.labels[index]) == 0 {
+				continue
+			}
+
+			if prev, ok := last[row(rank)]; ok {
+				gap := float64(positions[index]-positions[prev]) - (size(prev)+size(index))/2
+				if gap < defaultTickLabelSpacing {
+					return true
+				}
+			}
+			last[row(rank)] = index
+		}
+		return false
+	}
+	all := func(rank int) bool { return true }
+	single := func(rank int) int { return 0 }
+	alternate := func(rank int) int { return rank % 2 }
+	labelSize := func(index int) float64 { return float64(sizes[index]) }
+
+	if !overlaps(all, single, labelSize) {
+		return ll
+	}
+
+	if len(strategies) == 0 {
+		strategies = defaultXTickLabelOverlap
+		if isVertical {
+			strategies = defaultYTickLabelOverlap
+		}
+	}
+
+	for i, strategy := range strategies {
+		last := i == len(strategies)-1
+
+		switch strategy {
+		case TickLabelOverlapAllow:
+			return ll
+		case TickLabelOverlapThin:
+			for step := 2; step <= len(order); step++ {
+				visible := func(rank int) bool { return rank%step == 0 }
+				if !overlaps(visible, single, labelSize) || step == len(order) {
+					ll.strategy = strategy
+					for rank, index := range order {
+						if !visible(rank) {
+							ll.labels[index] = ""
+						}
+					}
+					return ll
+				}
+			}
+		case TickLabelOverlapStagger:
+			if !overlaps(all, alternate, labelSize) || last {
+				ll.strategy = strategy
+				for rank, index := range order {
+					ll.rows[index] = alternate(rank)
+				}
+				return ll
+			}
+		case TickLabelOverlapRotate45, TickLabelOverlapRotate90:
+			if isVertical {
+				continue
+			}
+
+			// Rotated labels are parallel, so they overlap if the distance
+			// between them, perpendicular to their direction, is smaller
+			// than their height.
+			angle := 45.0
+			if strategy == TickLabelOverlapRotate90 {
+				angle = 90
+			}
+			sin := math.Sin(mathutil.DegreesToRadians(angle))
+			rotatedSize := func(index int) float64 { return float64(ll.height) / sin }
+			if !overlaps(all, single, rotatedSize) || last {
+				ll.strategy = strategy
+				ll.rotation = -angle
+				return ll
+			}
+		case TickLabelOverlapTruncate:
+			if isVertical {
+				continue
+			}
+
+			ll.strategy = strategy
+			for rank, index := range order {
+				available := math.MaxInt32
+				if rank > 0 {
+					available = positions[index] - positions[order[rank-1]]
+				}
+				if rank < len(order)-1 {
+					available = mathutil.MinInt(available, positions[order[rank+1]]-positions[index])
+				}
+				ll.labels[index] = truncateTickLabel(r, ll.labels[index], available-defaultTickLabelSpacing, style)
+			}
+			return ll
+		}
+	}
+
+	return ll
+}
+
+// truncateTickLabel truncates the specified label with an ellipsis, so that
+// it fits within the specified width.
+func truncateTickLabel(r render.Renderer, label string, width int, style render.Style) string {
+	if render.Text.Measure(r, label, style).Width() <= width {
+		return label
+	}
+
+	runes := []rune(render.StripRichText(label))
+	for n := len(runes) - 1; n > 0; n-- {
+		truncated := string(runes[:n]) + tickLabelEllipsis
+		if render.Text.Measure(r, truncated, style).Width() <= width {
+			return truncated
+		}
+	}
+	return ""
+}
+
+// style returns the style the tick labels are drawn with.
+func (ll tickLabelLayout) style(style render.Style) render.Style {
+	if ll.rotation != 0 {
+		style.TextRotationDegrees = ll.rotation
+	}
+	return style
+}
+
+// xHeight returns the height taken by the labels below an X axis.
+func (ll tickLabelLayout) xHeight() int {
+	switch ll.strategy {
+	case TickLabelOverlapStagger:
+		return 2*ll.height + defaultTickLabelSpacing
+	case TickLabelOverlapRotate45:
+		return int(math.Ceil(float64(ll.width+ll.height) * math.Sqrt2 / 2))
+	case TickLabelOverlapRotate90:
+		return ll.width
+	}
+	return ll.height
+}
+
+// xBox returns the bounds of the label of a tick of an X axis, at the
+// horizontal position tx, with labels starting at the vertical position
+// top. It also returns the baseline origin of the label.
+func (ll tickLabelLayout) xBox(r render.Renderer, index, tx, top int, style render.Style) (box render.Box, x, y int) {
+	tb := render.Text.Measure(r, ll.labels[index], style)
+	w, h := float64(tb.Width()), float64(tb.Height())
+
+	switch ll.strategy {
+	case TickLabelOverlapRotate45:
+		// The label ends at the tick, going up to the right.
+		c := math.Sqrt2 / 2
+		ex, ey := float64(tx)+c*h/2, float64(top)+c*h/2
+		x, y = int(math.Round(ex-c*w)), int(math.Round(ey+c*w))
+		box = render.Box{
+			Top:    top,
+			Left:   int(math.Floor(ex - c*w - c*h)),
+			Right:  int(math.Ceil(ex)),
+			Bottom: int(math.Ceil(ey + c*w)),
+		}
+	case TickLabelOverlapRotate90:
+		// The label ends at the tick, going up.
+		x, y = tx+tb.Height()>>1, top+tb.Width()
+		box = render.Box{
+			Top:    top,
+			Left:   tx - tb.Height()>>1,
+			Right:  x,
+			Bottom: y,
+		}
+	default:
+		rowTop := top + ll.rows[index]*(ll.height+defaultTickLabelSpacing)
+		x, y = tx-tb.Width()>>1, rowTop+tb.Height()
+		box = render.Box{
+			Top:    rowTop,
+			Left:   x,
+			Right:  tx + tb.Width()>>1,
+			Bottom: y,
+		}
+	}
+	return box, x, y
+}
+
+// yOffset returns the horizontal offset of the label of a tick of a Y axis,
+// away from the axis.
+func (ll tickLabelLayout) yOffset(index int) int {
+	if ll.rows[index] == 0 {
+		return 0
+	}
+	return ll.width + defaultTickLabelSpacing
+}
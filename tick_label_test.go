@@ -0,0 +1,86 @@
+package unichart
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/unidoc/unichart/dataset/sequence"
+	"github.com/unidoc/unichart/render"
+	"github.com/unidoc/unichart/render/raster"
+)
+
+func TestTickLabelLayout(t *testing.T) {
+	r, err := raster.NewRenderer(400, 200)
+	require.NoError(t, err)
+	style := render.Style{FontSize: 10}
+
+	var ticks []Tick
+	var positions []int
+	for i := 0; i < 8; i++ {
+		ticks = append(ticks, Tick{Value: float64(i), Label: fmt.Sprintf("label %d", i)})
+		positions = append(positions, i*40)
+	}
+	width := render.Text.Measure(r, "label 0", style).Width()
+	require.Greater(t, width, 40-defaultTickLabelSpacing)
+	require.Less(t, width, 80-defaultTickLabelSpacing)
+
+	// Labels which do not overlap are left untouched.
+	ll := newTickLabelLayout(r, positions[:1], ticks[:1], style, false, nil)
+	require.Equal(t, TickLabelOverlapAllow, ll.strategy)
+	require.Equal(t, []string{"label 0"}, ll.labels)
+
+	// Staggered labels fit on two rows.
+	ll = newTickLabelLayout(r, positions, ticks, style, false, nil)
+	require.Equal(t, TickLabelOverlapStagger, ll.strategy)
+	require.Equal(t, []int{0, 1, 0, 1, 0, 1, 0, 1}, ll.rows)
+	require.Equal(t, 2*ll.height+defaultTickLabelSpacing, ll.xHeight())
+
+	ll = newTickLabelLayout(r, positions, ticks, style, false, []TickLabelOverlap{TickLabelOverlapThin})
+	require.Equal(t, TickLabelOverlapThin, ll.strategy)
+	require.Equal(t, []string{"label 0", "", "label 2", "", "label 4", "", "label 6", ""}, ll.labels)
+
+	ll = newTickLabelLayout(r, positions, ticks, style, false, []TickLabelOverlap{TickLabelOverlapRotate90})
+	require.Equal(t, -90.0, ll.rotation)
+	require.Equal(t, width, ll.xHeight())
+
+	ll = newTickLabelLayout(r, positions, ticks, style, false, []TickLabelOverlap{TickLabelOverlapTruncate})
+	for _, label := range ll.labels {
+		require.Contains(t, label, tickLabelEllipsis)
+		require.LessOrEqual(t, render.Text.Measure(r, label, style).Width(), 40-defaultTickLabelSpacing)
+	}
+
+	// Rotation does not apply to vertical axes, which fall back to the
+	// next strategy.
+	ll = newTickLabelLayout(r, []int{0, 5, 10, 15}, ticks[:4], style, true,
+		[]TickLabelOverlap{TickLabelOverlapRotate45, TickLabelOverlapThin})
+	require.Equal(t, TickLabelOverlapThin, ll.strategy)
+	require.Equal(t, 0.0, ll.rotation)
+}
+
+func TestXAxisMeasureTickLabels(t *testing.T) {
+	r, err := raster.NewRenderer(400, 200)
+	require.NoError(t, err)
+
+	var ticks []Tick
+	for i := 0; i < 8; i++ {
+		ticks = append(ticks, Tick{Value: float64(i), Label: fmt.Sprintf("label %d", i)})
+	}
+	canvasBox := render.Box{Left: 20, Right: 300, Top: 0, Bottom: 100}
+	ra := &sequence.ContinuousRange{Min: 0, Max: 7, Domain: canvasBox.Width()}
+	defaults := render.Style{FontSize: 10}
+
+	// The height of the axis reflects the strategy used for the labels.
+	var heights []int
+	for _, strategy := range []TickLabelOverlap{
+		TickLabelOverlapAllow,
+		TickLabelOverlapStagger,
+		TickLabelOverlapRotate90,
+	} {
+		xa := XAxis{TickLabelOverlap: []TickLabelOverlap{strategy}}
+		heights = append(heights, xa.Measure(r, canvasBox, ra, defaults, ticks).Height())
+	}
+	require.Less(t, heights[0], heights[1])
+	require.Less(t, heights[1], heights[2])
+}
@@ -0,0 +1,206 @@
+package unichart
+
+import (
+	"math"
+	"sort"
+
+	"github.com/unidoc/unichart/dataset"
+	"github.com/unidoc/unichart/dataset/sequence"
+)
+
+const (
+	// defaultMaxLocatedTicks is the default maximum number of ticks placed
+	// by the MultipleTickLocator.
+	defaultMaxLocatedTicks = 10
+)
+
+// Interface Assertions.
+var (
+	_ TickLocator = (*FixedStepTickLocator)(nil)
+	_ TickLocator = (*FixedCountTickLocator)(nil)
+	_ TickLocator = (*MultipleTickLocator)(nil)
+	_ TickLocator = (*PercentileTickLocator)(nil)
+	_ TickLocator = (*ExplicitTickLocator)(nil)
+)
+
+// TickLocator is a type that places the ticks of an axis within a range.
+type TickLocator interface {
+	Locate(ra sequence.Range) []float64
+}
+
+// locateTicks returns the ticks placed by the specified locator within the
+// range, labeled using the specified value formatter.
+func locateTicks(tl TickLocator, ra sequence.Range, vf dataset.ValueFormatter) []Tick {
+	if vf == nil {
+		vf = dataset.FloatValueFormatter
+	}
+
+	min, max := rangeBounds(ra)
+	epsilon := (max - min) * 1e-9
+
+	values := tl.Locate(ra)
+	sort.Float64s(values)
+
+	var ticks []Tick
+	for i, v := range values {
+		if v < min-epsilon || v > max+epsilon || (i > 0 && v-values[i-1] <= epsilon) {
+			continue
+		}
+		ticks = append(ticks, Tick{
+			Value: v,
+			Label: vf(v),
+		})
+	}
+	return ticks
+}
+
+// multiplesWithin returns the values offset + k*step, within [min, max].
+func multiplesWithin(min, max, step, offset float64) []float64 {
+	if step <= 0 || math.IsInf(step, 0) || math.IsNaN(step) {
+		return nil
+	}
+
+	var values []float64
+	epsilon := step * 1e-9
+	for k := math.Ceil((min - offset - epsilon) / step); offset+k*step <= max+epsilon; k++ {
+		if len(values) == defaultTickCountSanityCheck {
+			break
+		}
+		values = append(values, offset+k*step)
+	}
+	return values
+}
+
+// rangeBounds returns the smallest and largest values of a range.
+func rangeBounds(ra sequence.Range) (min, max float64) {
+	return math.Min(ra.GetMin(), ra.GetMax()), math.Max(ra.GetMin(), ra.GetMax())
+}
+
+// FixedStepTickLocator places ticks at the multiples of a step, shifted by
+// an offset.
+type FixedStepTickLocator struct {
+	Step   float64
+	Offset float64
+}
+
+// Locate returns the tick values within the range.
+func (l FixedStepTickLocator) Locate(ra sequence.Range) []float64 {
+	min, max := rangeBounds(ra)
+	return multiplesWithin(min, max, l.Step, l.Offset)
+}
+
+// FixedCountTickLocator places a fixed number of evenly spaced ticks,
+// including the bounds of the range.
+type FixedCountTickLocator struct {
+	Count int
+}
+
+// Locate returns the tick values within the range.
+func (l FixedCountTickLocator) Locate(ra sequence.Range) []float64 {
+	min, max := rangeBounds(ra)
+	switch {
+	case l.Count <= 0:
+		return nil
+	case l.Count == 1:
+		return []float64{min}
+	}
+
+	values := make([]float64, l.Count)
+	for i := range values {
+		values[i] = min + (max-min)*float64(i)/float64(l.Count-1)
+	}
+	return values
+}
+
+// MultipleTickLocator places ticks at the multiples of a base value, such as
+// π or 1024. The distance between ticks is the smallest nice multiple of the
+// base (1, 2 or 5 times a power of 10) which places at most MaxTicks ticks.
+type MultipleTickLocator struct {
+	Base     float64
+	MaxTicks int
+}
+
+// GetMaxTicks returns the maximum number of ticks.
+func (l MultipleTickLocator) GetMaxTicks(defaults ...int) int {
+	if l.MaxTicks <= 0 {
+		if len(defaults) > 0 {
+			return defaults[0]
+		}
+		return defaultMaxLocatedTicks
+	}
+	return l.MaxTicks
+}
+
+// Locate returns the tick values within the range.
+func (l MultipleTickLocator) Locate(ra sequence.Range) []float64 {
+	if l.Base <= 0 {
+		return nil
+	}
+
+	min, max := rangeBounds(ra)
+	maxTicks := l.GetMaxTicks()
+
+	for magnitude := 1.0; magnitude < math.MaxFloat64/10; magnitude *= 10 {
+		for _, factor := range []float64{1, 2, 5} {
+			step := l.Base * factor * magnitude
+			if math.Floor(max/step)-math.Ceil(min/step)+1 <= float64(maxTicks) {
+				return multiplesWithin(min, max, step, 0)
+			}
+		}
+	}
+	return nil
+}
+
+// PercentileTickLocator places ticks at percentiles of a set of values,
+// such as the data of a series. Percentiles are specified in the [0, 1]
+// range and default to the minimum, quartiles and maximum.
+type PercentileTickLocator struct {
+	Values      []float64
+	Percentiles []float64
+}
+
+// GetPercentiles returns the percentiles the ticks are placed at.
+func (l PercentileTickLocator) GetPercentiles(defaults ...[]float64) []float64 {
+	if len(l.Percentiles) == 0 {
+		if len(defaults) > 0 {
+			return defaults[0]
+		}
+		return []float64{0, 0.25, 0.5, 0.75, 1}
+	}
+	return l.Percentiles
+}
+
+// Locate returns the tick values within the range.
+func (l PercentileTickLocator) Locate(ra sequence.Range) []float64 {
+	if len(l.Values) == 0 {
+		return nil
+	}
+
+	sorted := append([]float64(nil), l.Values...)
+	sort.Float64s(sorted)
+
+	// Percentiles are linearly interpolated between the closest values.
+	var output []float64
+	for _, p := range l.GetPercentiles() {
+		if p < 0 || p > 1 {
+			continue
+		}
+
+		index := p * float64(len(sorted)-1)
+		lower := int(math.Floor(index))
+		upper := int(math.Ceil(index))
+		output = append(output, sorted[lower]+(sorted[upper]-sorted[lower])*(index-float64(lower)))
+	}
+	return output
+}
+
+// ExplicitTickLocator places ticks at the specified values, which are
+// labeled by the value formatter of the axis.
+type ExplicitTickLocator struct {
+	Values []float64
+}
+
+// Locate returns the tick values within the range.
+func (l ExplicitTickLocator) Locate(ra sequence.Range) []float64 {
+	return append([]float64(nil), l.Values...)
+}
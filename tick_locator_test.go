@@ -0,0 +1,60 @@
+package unichart
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/unidoc/unichart/dataset"
+	"github.com/unidoc/unichart/dataset/sequence"
+	"github.com/unidoc/unichart/render"
+)
+
+func TestTickLocators(t *testing.T) {
+	ra := &sequence.ContinuousRange{Min: 0, Max: 10}
+	values := func(ticks []Tick) []float64 {
+		var v []float64
+		for _, t := range ticks {
+			v = append(v, t.Value)
+		}
+		return v
+	}
+
+	ticks := locateTicks(FixedStepTickLocator{Step: 2.5}, ra, nil)
+	require.Equal(t, []float64{0, 2.5, 5, 7.5, 10}, values(ticks))
+	require.Equal(t, "2.50", ticks[1].Label)
+	require.Equal(t, []float64{1, 4, 7, 10}, values(locateTicks(FixedStepTickLocator{Step: 3, Offset: 1}, ra, nil)))
+
+	require.Equal(t, []float64{0, 5, 10}, values(locateTicks(FixedCountTickLocator{Count: 3}, ra, nil)))
+	require.Empty(t, locateTicks(FixedCountTickLocator{}, ra, nil))
+
+	pi := values(locateTicks(MultipleTickLocator{Base: math.Pi}, ra, nil))
+	require.InDeltaSlice(t, []float64{0, math.Pi, 2 * math.Pi, 3 * math.Pi}, pi, 1e-9)
+
+	kb := &sequence.ContinuousRange{Min: 0, Max: 10000}
+	ticks = locateTicks(MultipleTickLocator{Base: 1024, MaxTicks: 5}, kb, dataset.BytesValueFormatter)
+	require.Equal(t, []float64{0, 2048, 4096, 6144, 8192}, values(ticks))
+	require.Equal(t, "2 KiB", ticks[1].Label)
+
+	ticks = locateTicks(PercentileTickLocator{Values: []float64{1, 2, 3, 4, 5, 6, 7, 8, 9}}, ra, nil)
+	require.Equal(t, []float64{1, 3, 5, 7, 9}, values(ticks))
+
+	// Explicit values outside of the range are dropped.
+	require.Equal(t, []float64{1, 5}, values(locateTicks(ExplicitTickLocator{Values: []float64{5, 1, 11}}, ra, nil)))
+}
+
+func TestAxisTickLocator(t *testing.T) {
+	ra := &sequence.ContinuousRange{Min: 0, Max: 100, Domain: 500}
+	xa := XAxis{TickLocator: FixedCountTickLocator{Count: 5}}
+	ticks := xa.GetTicks(nil, ra, render.Style{}, dataset.IntValueFormatter)
+	require.Len(t, ticks, 5)
+	require.Equal(t, "25", ticks[1].Label)
+
+	// User supplied ticks take precedence.
+	xa.Ticks = []Tick{{Value: 1, Label: "one"}}
+	require.Equal(t, xa.Ticks, xa.GetTicks(nil, ra, render.Style{}, nil))
+
+	ya := YAxis{TickLocator: FixedStepTickLocator{Step: 50}}
+	require.Len(t, ya.GetTicks(nil, ra, render.Style{}, nil), 3)
+}
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/unidoc/unichart/render"
 )
 
 type TickInput struct {
@@ -54,3 +56,58 @@ func TestNiceTicks(t *testing.T) {
 		require.ElementsMatch(t, i.expectedResult, result)
 	}
 }
+
+func TestHistogramTicks(t *testing.T) {
+	edges := []float64{0, 0.5, 1, 1.5, 2}
+
+	ticks := HistogramTicks(edges, nil)
+	require.Len(t, ticks, 5)
+	require.Equal(t, Tick{Value: 1.5, Label: "1.50"}, ticks[3])
+
+	ticks = HistogramTicks(edges, nil, 2)
+	require.Equal(t, []Tick{{0, "0.00"}, {1, "1.00"}, {2, "2.00"}}, ticks)
+
+	// The last edge of an odd number of bins gets a tick.
+	ticks = HistogramTicks([]float64{0, 1, 2, 3}, nil, 2)
+	require.Equal(t, []Tick{{0, "0.00"}, {2, "2.00"}, {3, "3.00"}}, ticks)
+}
+
+func TestGenerateMinorTicks(t *testing.T) {
+	values := func(ticks []Tick) []float64 {
+		var v []float64
+		for _, t := range ticks {
+			v = append(v, t.Value)
+		}
+		return v
+	}
+	major := []Tick{{Value: 0}, {Value: 10}, {Value: 20}}
+
+	require.Empty(t, generateMinorTicks(major, 0, 0))
+	require.Empty(t, generateMinorTicks(major[:1], 4, 0))
+	require.InDeltaSlice(t, []float64{2, 4, 6, 8, 12, 14, 16, 18}, values(generateMinorTicks(major, 4, 0)), 1e-9)
+	require.InDeltaSlice(t, []float64{2.5, 5, 7.5, 12.5, 15, 17.5}, values(generateMinorTicks(major, 0, 2.5)), 1e-9)
+
+	// Decades get sub-ticks at 2 to 9 times each decade.
+	decades := []Tick{{Value: 1}, {Value: 10}, {Value: 100}}
+	minor := values(generateMinorTicks(decades, 1, 0))
+	require.Len(t, minor, 16)
+	require.InDeltaSlice(t, []float64{2, 3, 4}, minor[:3], 1e-9)
+	require.InDelta(t, 90.0, minor[15], 1e-9)
+
+	gl := GenerateMinorGridLines(major, generateMinorTicks(major, 1, 0), render.Style{}, render.Style{})
+	require.Equal(t, []GridLine{{Value: 10}, {Value: 5, IsMinor: true}, {Value: 15, IsMinor: true}}, gl)
+}
+
+func TestTickDirectionExtent(t *testing.T) {
+	inside, outside := TickDirectionUnset.extent(6)
+	require.Equal(t, []int{0, 6}, []int{inside, outside})
+	inside, outside = TickDirectionInside.extent(6)
+	require.Equal(t, []int{6, 0}, []int{inside, outside})
+	inside, outside = TickDirectionCross.extent(7)
+	require.Equal(t, []int{3, 4}, []int{inside, outside})
+
+	// Tick marks longer than default move the labels away from the axis.
+	require.Zero(t, XAxis{}.getTickLabelOffset())
+	require.Equal(t, 10-defaultVerticalTickHeight, XAxis{TickLength: 10}.getTickLabelOffset())
+	require.Zero(t, YAxis{TickLength: 10, TickDirection: TickDirectionInside}.getTickLabelOffset())
+}
@@ -0,0 +1,154 @@
+package unichart
+
+import (
+	"math"
+
+	"github.com/unidoc/unichart/render"
+)
+
+const (
+	// defaultTitleSpacing is the default distance between the title, the
+	// subtitle and the chart content.
+	defaultTitleSpacing = 5
+
+	// defaultSubtitleFontScale is the default font size of subtitles,
+	// relative to the font size of titles.
+	defaultSubtitleFontScale = 0.7
+
+	// defaultCaptionFontSize is the default font size of captions.
+	defaultCaptionFontSize = 10.0
+)
+
+// chartTitles lays out the title and the subtitle at the top of a chart, and
+// the caption at its bottom. Texts are wrapped on words to fit the width of
+// the chart and aligned according to the horizontal alignment of their
+// styles. The area taken by the texts is excluded from the box available
+// for the chart content.
+type chartTitles struct {
+	title    string
+	subtitle string
+	caption  string
+
+	titleStyle    render.Style
+	subtitleStyle render.Style
+	captionStyle  render.Style
+}
+
+// newChartTitles returns the titles of a chart. The subtitle inherits the
+// font, color and alignment of the title, with a smaller font size, while
+// the caption is left aligned with the font size of the axes.
+func newChartTitles(defaults render.Style, title string, titleStyle render.Style,
+	subtitle string, subtitleStyle render.Style, caption string, captionStyle render.Style) chartTitles {
+	final := titleStyle.InheritFrom(defaults.InheritFrom(render.Style{
+		Padding: render.Box{Top: defaultTitleTop, Bottom: defaultTitleSpacing},
+	}))
+
+	ct := chartTitles{
+		titleStyle: final,
+		subtitleStyle: subtitleStyle.InheritFrom(render.Style{
+			Font:                final.Font,
+			FontColor:           final.FontColor,
+			FontSize:            math.Max(final.GetFontSize()*defaultSubtitleFontScale, defaultAxisFontSize),
+			TextHorizontalAlign: final.TextHorizontalAlign,
+			Padding:             render.Box{Bottom: defaultTitleSpacing},
+		}),
+		captionStyle: captionStyle.InheritFrom(render.Style{
+			Font:                final.Font,
+			FontColor:           final.FontColor,
+			FontSize:            defaultCaptionFontSize,
+			TextHorizontalAlign: render.TextHorizontalAlignLeft,
+			Padding:             render.Box{Top: defaultTitleSpacing},
+		}),
+	}
+
+	if !titleStyle.Hidden {
+		ct.title = title
+	}
+	if !subtitleStyle.Hidden {
+		ct.subtitle = subtitle
+	}
+	if !captionStyle.Hidden {
+		ct.caption = caption
+	}
+	return ct
+}
+
+// adjust returns the part of the specified box not taken by the titles.
+func (ct chartTitles) adjust(r render.Renderer, box render.Box) render.Box {
+	header, footer := ct.layout(r, box)
+
+	adjusted := box.Clone()
+	if len(header) > 0 {
+		last := header[len(header)-1]
+		adjusted.Top = last.box.Bottom + last.style.Padding.Bottom
+	}
+	if len(footer) > 0 {
+		first := footer[0]
+		adjusted.Bottom = first.box.Top - first.style.Padding.Top
+	}
+	return adjusted
+}
+
+// draw draws the titles within the specified box.
+func (ct chartTitles) draw(r render.Renderer, box render.Box) {
+	header, footer := ct.layout(r, box)
+	for _, block := range append(header, footer...) {
+		render.Text.DrawWithin(r, block.text, block.box, block.style)
+	}
+}
+
+// titleBlock is a laid out title text.
+type titleBlock struct {
+	text  string
+	style render.Style
+	box   render.Box
+}
+
+// layout returns the blocks drawn at the top and at the bottom of the
+// specified box.
+func (ct chartTitles) layout(r render.Renderer, box render.Box) (header, footer []titleBlock) {
+	measure := func(text string, style render.Style) titleBlock {
+		style.TextWrap = render.TextWrapWord
+		style.TextVerticalAlign = render.TextVerticalAlignTop
+
+		width := box.Width() - style.Padding.Left - style.Padding.Right
+		lines := render.Text.WrapFit(r, text, width, style)
+		linesBox := render.Text.MeasureLines(r, lines, style)
+		r.ResetStyle()
+
+		return titleBlock{
+			text:  text,
+			style: style,
+			box: render.Box{
+				Left:   box.Left + style.Padding.Left,
+				Right:  box.Right - style.Padding.Right,
+				Bottom: linesBox.Height(),
+			},
+		}
+	}
+
+	y := box.Top
+	for _, item := range []struct {
+		text  string
+		style render.Style
+	}{{ct.title, ct.titleStyle}, {ct.subtitle, ct.subtitleStyle}} {
+		if len(item.text) == 0 {
+			continue
+		}
+
+		block := measure(item.text, item.style)
+		y += block.style.Padding.Top
+		block.box.Top, block.box.Bottom = y, y+block.box.Bottom
+		y = block.box.Bottom + block.style.Padding.Bottom
+		header = append(header, block)
+	}
+
+	if len(ct.caption) > 0 {
+		block := measure(ct.caption, ct.captionStyle)
+		height := block.box.Bottom
+		block.box.Bottom = box.Bottom - block.style.Padding.Bottom
+		block.box.Top = block.box.Bottom - height
+		footer = append(footer, block)
+	}
+	return header, footer
+}
@@ -0,0 +1,57 @@
+package unichart
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/unidoc/unichart/render"
+	"github.com/unidoc/unichart/render/raster"
+)
+
+func TestChartTitlesAdjust(t *testing.T) {
+	r, err := raster.NewRenderer(200, 200)
+	require.NoError(t, err)
+
+	box := render.Box{Top: 5, Left: 5, Right: 195, Bottom: 195}
+	defaults := render.Style{FontSize: 10, TextHorizontalAlign: render.TextHorizontalAlignCenter}
+
+	// Without titles the box is left untouched.
+	ct := newChartTitles(defaults, "", render.Style{}, "", render.Style{}, "", render.Style{})
+	require.Equal(t, box, ct.adjust(r, box))
+
+	ct = newChartTitles(defaults, "title", render.Style{}, "", render.Style{}, "caption", render.Style{})
+	lineHeight := r.MeasureText("title").Height()
+	adjusted := ct.adjust(r, box)
+	require.Equal(t, box.Top+defaultTitleTop+lineHeight+defaultTitleSpacing, adjusted.Top)
+	require.Equal(t, box.Bottom-lineHeight-defaultTitleSpacing, adjusted.Bottom)
+
+	// Long titles are wrapped, and push the content further down.
+	long := newChartTitles(defaults, "a long title which does not fit on a single line", render.Style{},
+		"subtitle", render.Style{}, "", render.Style{})
+	header, footer := long.layout(r, box)
+	require.Len(t, header, 2)
+	require.Empty(t, footer)
+	require.True(t, header[0].box.Height() > lineHeight)
+	require.Equal(t, header[0].box.Bottom+defaultTitleSpacing, header[1].box.Top)
+	require.Equal(t, header[1].box.Bottom+defaultTitleSpacing, long.adjust(r, box).Top)
+
+	// Hidden titles take no space.
+	hidden := newChartTitles(defaults, "title", render.Style{Hidden: true}, "", render.Style{}, "", render.Style{})
+	require.Equal(t, box, hidden.adjust(r, box))
+}
+
+func TestChartCanvasBelowTitles(t *testing.T) {
+	c := Chart{
+		Title:    "title",
+		Subtitle: "subtitle",
+		Caption:  "source",
+	}
+	r, err := raster.NewRenderer(c.Width(), c.Height())
+	require.NoError(t, err)
+
+	header, footer := c.getTitles().layout(r, c.Box())
+	canvasBox := c.getDefaultCanvasBox(r)
+	require.True(t, canvasBox.Top > header[1].box.Bottom)
+	require.True(t, canvasBox.Bottom < footer[0].box.Top)
+}
@@ -0,0 +1,57 @@
+package unichart
+
+import (
+	"github.com/unidoc/unichart/mathutil"
+	"github.com/unidoc/unichart/render"
+)
+
+const (
+	// defaultValueLabelSpacing is the vertical space between value labels
+	// nudged in order to avoid overlaps.
+	defaultValueLabelSpacing = 2
+)
+
+// valueLabel is a text label centered on an anchor point of a chart.
+type valueLabel struct {
+	text  string
+	style render.Style
+	x, y  int
+}
+
+// drawValueLabels draws the specified labels, nudging overlapping labels
+// vertically within the bounds. Labels moved away from their anchor points
+// are connected to them by leader lines.
+func drawValueLabels(r render.Renderer, bounds render.Box, labels []valueLabel) {
+	boxes := make([]render.Box, len(labels))
+	for i, l := range labels {
+		tb := render.Text.Measure(r, l.text, l.style)
+
+		left := mathutil.MaxInt(l.x-tb.Width()>>1, 0)
+		top := l.y - tb.Height()>>1
+		boxes[i] = render.Box{
+			Top:    top,
+			Left:   left,
+			Right:  left + tb.Width(),
+			Bottom: top + tb.Height(),
+		}
+	}
+
+	placed := render.PlaceLabels(boxes, bounds, defaultValueLabelSpacing)
+	for i, l := range labels {
+		if placed[i].Top != boxes[i].Top {
+			ly := placed[i].Top
+			if placed[i].Top < boxes[i].Top {
+				ly = placed[i].Bottom
+			}
+
+			r.SetStrokeColor(l.style.GetFontColor())
+			r.SetStrokeWidth(1)
+			r.MoveTo(l.x, l.y)
+			r.LineTo(l.x, ly)
+			r.Stroke()
+			r.ResetStyle()
+		}
+
+		render.Text.Draw(r, l.text, placed[i].Left, placed[i].Bottom, l.style)
+	}
+}
@@ -0,0 +1,569 @@
+package unichart
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"io"
+	"math"
+
+	"github.com/unidoc/unichart/dataset"
+	"github.com/unidoc/unichart/dataset/sequence"
+	"github.com/unidoc/unichart/mathutil"
+	"github.com/unidoc/unichart/render"
+)
+
+const (
+	// defaultViolinWidth is the default pixel width of violins.
+	defaultViolinWidth = 80
+
+	// defaultViolinSpacing is the default minimum pixel spacing between
+	// violins.
+	defaultViolinSpacing = 20
+
+	// defaultViolinPoints is the default number of points at which the
+	// densities of violins are evaluated.
+	defaultViolinPoints = 100
+
+	// defaultViolinFillAlpha is the alpha of the default violin fill color.
+	defaultViolinFillAlpha = 160
+)
+
+// Violin is the distribution of the samples of a category of a violin
+// chart.
+type Violin struct {
+	Label   string
+	Style   render.Style
+	Samples []float64
+}
+
+// ViolinChart is a chart that draws the distribution of the samples of each
+// category as a violin: their kernel density estimate, mirrored around the
+// center of the category. The densities are evaluated between the minimum
+// and the maximum samples of each violin, and each violin is scaled to its
+// maximum width. A box plot of the quartiles, with whiskers extending to the
+// furthest samples within 1.5 IQR, and dashed quartile lines can be drawn
+// inside the violins.
+type ViolinChart struct {
+	Title         string
+	TitleStyle    render.Style
+	Subtitle      string
+	SubtitleStyle render.Style
+	Caption       string
+	CaptionStyle  render.Style
+
+	Font         render.Font
+	Background   render.Style
+	Canvas       render.Style
+	ColorPalette render.ColorPalette
+
+	XAxis render.Style
+	YAxis YAxis
+
+	ViolinWidth   int
+	ViolinSpacing int
+
+	Kernel          dataset.KDEKernel
+	Bandwidth       float64
+	BandwidthMethod dataset.BandwidthMethod
+
+	ShowBoxPlot   bool
+	BoxPlotStyle  render.Style
+	ShowQuartiles bool
+	QuartileStyle render.Style
+
+	Violins  []Violin
+	Elements []render.Renderable
+
+	width  int
+	height int
+	dpi    float64
+}
+
+// DPI returns the DPI for the chart.
+func (vc *ViolinChart) DPI() float64 {
+	if vc.dpi == 0 {
+		return defaultDPI
+	}
+	return vc.dpi
+}
+
+// SetDPI sets the DPI for the chart.
+func (vc *ViolinChart) SetDPI(dpi float64) {
+	vc.dpi = dpi
+}
+
+// GetFont returns the text font.
+func (vc *ViolinChart) GetFont() render.Font {
+	return vc.Font
+}
+
+// Width returns the chart width or the default value.
+func (vc *ViolinChart) Width() int {
+	if vc.width == 0 {
+		return defaultChartWidth
+	}
+	return vc.width
+}
+
+// SetWidth sets the chart width.
+func (vc *ViolinChart) SetWidth(width int) {
+	vc.width = width
+}
+
+// Height returns the chart height or the default value.
+func (vc *ViolinChart) Height() int {
+	if vc.height == 0 {
+		return defaultChartHeight
+	}
+	return vc.height
+}
+
+// SetHeight sets the chart height.
+func (vc *ViolinChart) SetHeight(height int) {
+	vc.height = height
+}
+
+// GetViolinWidth returns the maximum width of the violins.
+func (vc *ViolinChart) GetViolinWidth() int {
+	if vc.ViolinWidth == 0 {
+		return defaultViolinWidth
+	}
+	return vc.ViolinWidth
+}
+
+// GetViolinSpacing returns the minimum spacing between violins.
+func (vc *ViolinChart) GetViolinSpacing() int {
+	if vc.ViolinSpacing == 0 {
+		return defaultViolinSpacing
+	}
+	return vc.ViolinSpacing
+}
+
+// Render renders the chart with the given renderer to the given io.Writer.
+func (vc *ViolinChart) Render(rp render.RendererProvider, w io.Writer) error {
+	if len(vc.Violins) == 0 {
+		return errors.New("please provide at least one violin")
+	}
+
+	r, err := rp(vc.Width(), vc.Height())
+	if err != nil {
+		return err
+	}
+	r.SetDPI(vc.DPI())
+
+	vc.drawBackground(r)
+
+	canvasBox := vc.getDefaultCanvasBox(r)
+	yr := vc.getRanges()
+	if !(yr.GetMax()-yr.GetMin() > 0) {
+		return fmt.Errorf("invalid data range; cannot be zero")
+	}
+	yr.SetDomain(canvasBox.Height())
+
+	var yt []Tick
+	if !vc.YAxis.Style.Hidden {
+		yf := vc.YAxis.GetValueFormatter()
+		yt = vc.YAxis.GetTicks(r, yr, vc.styleDefaultsAxes(), yf)
+
+		// Extend the range to the generated ticks.
+		if len(yt) > 0 {
+			yr.SetMin(math.Min(yr.GetMin(), yt[0].Value))
+			yr.SetMax(math.Max(yr.GetMax(), yt[len(yt)-1].Value))
+		}
+	}
+	canvasBox = vc.getAdjustedCanvasBox(r, canvasBox, yr, yt)
+	yr.SetDomain(canvasBox.Height())
+
+	canvasBox.Draw(r, vc.Canvas.InheritFrom(vc.styleDefaultsCanvas()))
+	if !vc.YAxis.Style.Hidden {
+		vc.YAxis.Render(r, canvasBox, yr, vc.styleDefaultsAxes(), yt)
+	}
+	vc.drawViolins(r, canvasBox, yr)
+	vc.drawXAxis(r, canvasBox)
+	vc.drawTitle(r)
+
+	for _, a := range vc.Elements {
+		a(r, canvasBox, vc.styleDefaultsElements())
+	}
+
+	return r.Save(w)
+}
+
+func (vc *ViolinChart) getRanges() sequence.Range {
+	if vc.YAxis.Range != nil && !vc.YAxis.Range.IsZero() {
+		return vc.YAxis.Range
+	}
+
+	yrange := &sequence.ContinuousRange{Descending: vc.YAxis.Reversed}
+	if len(vc.YAxis.Ticks) > 0 {
+		tickMin, tickMax := math.MaxFloat64, -math.MaxFloat64
+		for _, t := range vc.YAxis.Ticks {
+			tickMin = math.Min(tickMin, t.Value)
+			tickMax = math.Max(tickMax, t.Value)
+		}
+		yrange.SetMin(tickMin)
+		yrange.SetMax(tickMax)
+		return yrange
+	}
+
+	min, max := math.MaxFloat64, -math.MaxFloat64
+	for _, violin := range vc.Violins {
+		for _, sample := range violin.Samples {
+			if mathutil.IsFinite(sample) {
+				min = math.Min(min, sample)
+				max = math.Max(max, sample)
+			}
+		}
+	}
+	yrange.SetMin(min)
+	yrange.SetMax(max)
+	return yrange
+}
+
+func (vc *ViolinChart) drawBackground(r render.Renderer) {
+	render.Box{
+		Right:  vc.Width(),
+		Bottom: vc.Height(),
+	}.Draw(r, vc.Background.InheritFrom(vc.styleDefaultsBackground()))
+}
+
+// getViolinSlots returns the width of the slot of each violin and the width
+// of the violins.
+func (vc *ViolinChart) getViolinSlots(canvasBox render.Box) (slotWidth, violinWidth int) {
+	slotWidth = canvasBox.Width() / len(vc.Violins)
+	spacing := mathutil.MinInt(vc.GetViolinSpacing(), slotWidth>>2)
+	violinWidth = mathutil.MinInt(vc.GetViolinWidth(), slotWidth-spacing)
+	return slotWidth, violinWidth
+}
+
+func (vc *ViolinChart) drawViolins(r render.Renderer, canvasBox render.Box, yr sequence.Range) {
+	slotWidth, violinWidth := vc.getViolinSlots(canvasBox)
+	for index, violin := range vc.Violins {
+		cx := canvasBox.Left + index*slotWidth + slotWidth>>1
+		vc.drawViolin(r, canvasBox, yr, cx, violinWidth, index, violin)
+	}
+}
+
+// violinShape is the geometry of a violin: the half widths of its outline
+// at the evaluated values, and the values of its box plot. The half widths
+// of the quartile lines follow the outline.
+type violinShape struct {
+	values     []float64
+	halfWidths []int
+
+	q1, median, q3          float64
+	quartileHalfWidths      [3]int
+	lowWhisker, highWhisker float64
+}
+
+// getViolinShape returns the shape of a violin of the specified width
+// drawing the specified finite samples. Violins whose samples are all equal
+// have a single value, drawn as a line of the full width.
+func (vc *ViolinChart) getViolinShape(samples []float64, width int) violinShape {
+	var shape violinShape
+	wrapper := sequence.NewWrapper(sequence.ArraySequence(samples))
+	min, max := wrapper.MinMax()
+
+	bandwidth := vc.Bandwidth
+	if bandwidth <= 0 {
+		bandwidth = vc.BandwidthMethod.Bandwidth(samples)
+	}
+
+	var maxDensity float64
+	halfWidth := func(density float64) int {
+		if maxDensity <= 0 {
+			return width >> 1
+		}
+		return int(math.Round(float64(width>>1) * density / maxDensity))
+	}
+
+	if min == max {
+		shape.values, shape.halfWidths = []float64{min}, []int{width >> 1}
+	} else {
+		yvalues, densities := dataset.KernelDensityCurve(samples, vc.Kernel, bandwidth, min, max, defaultViolinPoints)
+		_, maxDensity = mathutil.MinMax(densities...)
+
+		shape.values = yvalues
+		shape.halfWidths = make([]int, len(yvalues))
+		for i, density := range densities {
+			shape.halfWidths[i] = halfWidth(density)
+		}
+	}
+
+	shape.q1, shape.median, shape.q3 = wrapper.Percentile(0.25), wrapper.Median(), wrapper.Percentile(0.75)
+	for i, q := range []float64{shape.q1, shape.median, shape.q3} {
+		shape.quartileHalfWidths[i] = halfWidth(dataset.KernelDensity(samples, vc.Kernel, bandwidth, q))
+	}
+
+	// Whiskers extend to the furthest samples within 1.5 IQR.
+	iqr := shape.q3 - shape.q1
+	shape.lowWhisker, shape.highWhisker = shape.q1, shape.q3
+	for _, sample := range samples {
+		if sample >= shape.q1-1.5*iqr {
+			shape.lowWhisker = math.Min(shape.lowWhisker, sample)
+		}
+		if sample <= shape.q3+1.5*iqr {
+			shape.highWhisker = math.Max(shape.highWhisker, sample)
+		}
+	}
+	return shape
+}
+
+func (vc *ViolinChart) drawViolin(r render.Renderer, canvasBox render.Box, yr sequence.Range, cx, width, index int, violin Violin) {
+	var samples []float64
+	for _, sample := range violin.Samples {
+		if mathutil.IsFinite(sample) {
+			samples = append(samples, sample)
+		}
+	}
+	if len(samples) == 0 {
+		return
+	}
+
+	style := violin.Style.InheritFrom(vc.styleDefaultsViolin(index))
+	shape := vc.getViolinShape(samples, width)
+	translate := func(v float64) int {
+		return canvasBox.Bottom - yr.Translate(v)
+	}
+
+	if len(shape.values) == 1 {
+		y := translate(shape.values[0])
+		style.GetStrokeOptions().WriteToRenderer(r)
+		r.MoveTo(cx-shape.halfWidths[0], y)
+		r.LineTo(cx+shape.halfWidths[0], y)
+		r.Stroke()
+		r.ResetStyle()
+	} else {
+		style.GetFillAndStrokeOptions().WriteToRenderer(r)
+		for i, v := range shape.values {
+			x, y := cx+shape.halfWidths[i], translate(v)
+			if i == 0 {
+				r.MoveTo(x, y)
+				continue
+			}
+			r.LineTo(x, y)
+		}
+		for i := len(shape.values) - 1; i >= 0; i-- {
+			r.LineTo(cx-shape.halfWidths[i], translate(shape.values[i]))
+		}
+		r.Close()
+		r.FillStroke()
+		r.ResetStyle()
+	}
+
+	if vc.ShowQuartiles {
+		quartileStyle := vc.QuartileStyle.InheritFrom(vc.styleDefaultsQuartiles())
+		for i, q := range []float64{shape.q1, shape.median, shape.q3} {
+			hw := shape.quartileHalfWidths[i]
+
+			lineStyle := quartileStyle
+			if q == shape.median {
+				lineStyle.StrokeDashArray = nil
+			}
+			lineStyle.GetStrokeOptions().WriteToRenderer(r)
+			r.MoveTo(cx-hw, translate(q))
+			r.LineTo(cx+hw, translate(q))
+			r.Stroke()
+			r.ResetStyle()
+		}
+	}
+
+	if vc.ShowBoxPlot {
+		boxStyle := vc.BoxPlotStyle.InheritFrom(vc.styleDefaultsBoxPlot())
+		boxWidth := mathutil.MaxInt(width>>3, 2)
+
+		boxStyle.GetStrokeOptions().WriteToRenderer(r)
+		r.MoveTo(cx, translate(shape.lowWhisker))
+		r.LineTo(cx, translate(shape.highWhisker))
+		r.Stroke()
+		r.ResetStyle()
+
+		render.Box{
+			Top:    translate(shape.q3),
+			Left:   cx - boxWidth>>1,
+			Right:  cx + boxWidth>>1,
+			Bottom: translate(shape.q1),
+		}.Draw(r, boxStyle)
+
+		medianStyle := render.Style{
+			FillColor:   vc.GetColorPalette().BackgroundColor(),
+			StrokeColor: vc.GetColorPalette().BackgroundColor(),
+			StrokeWidth: 1,
+		}
+		medianStyle.GetFillAndStrokeOptions().WriteToRenderer(r)
+		r.Circle(math.Max(float64(boxWidth)/3, 1.5), cx, translate(shape.median))
+		r.FillStroke()
+		r.ResetStyle()
+	}
+}
+
+func (vc *ViolinChart) drawXAxis(r render.Renderer, canvasBox render.Box) {
+	if vc.XAxis.Hidden {
+		return
+	}
+
+	axisStyle := vc.XAxis.InheritFrom(vc.styleDefaultsAxes())
+	axisStyle.WriteToRenderer(r)
+
+	r.MoveTo(canvasBox.Left, canvasBox.Bottom)
+	r.LineTo(canvasBox.Right, canvasBox.Bottom)
+	r.Stroke()
+
+	slotWidth, _ := vc.getViolinSlots(canvasBox)
+	for index, violin := range vc.Violins {
+		left := canvasBox.Left + index*slotWidth
+		if len(violin.Label) > 0 {
+			render.Text.DrawWithin(r, violin.Label, render.Box{
+				Top:    canvasBox.Bottom + defaultXAxisMargin,
+				Left:   left,
+				Right:  left + slotWidth,
+				Bottom: vc.Height(),
+			}, axisStyle)
+		}
+
+		axisStyle.WriteToRenderer(r)
+		r.MoveTo(left, canvasBox.Bottom)
+		r.LineTo(left, canvasBox.Bottom+defaultVerticalTickHeight)
+		r.Stroke()
+	}
+}
+
+func (vc *ViolinChart) drawTitle(r render.Renderer) {
+	vc.getTitles().draw(r, vc.box())
+}
+
+func (vc *ViolinChart) getTitles() chartTitles {
+	return newChartTitles(vc.styleDefaultsTitle(),
+		vc.Title, vc.TitleStyle, vc.Subtitle, vc.SubtitleStyle, vc.Caption, vc.CaptionStyle)
+}
+
+func (vc *ViolinChart) getDefaultCanvasBox(r render.Renderer) render.Box {
+	return vc.getTitles().adjust(r, vc.box())
+}
+
+func (vc *ViolinChart) getAdjustedCanvasBox(r render.Renderer, canvasBox render.Box, yr sequence.Range, yticks []Tick) render.Box {
+	axesOuterBox := canvasBox.Clone()
+
+	if !vc.XAxis.Hidden {
+		xaxisHeight := defaultVerticalTickHeight
+		axisStyle := vc.XAxis.InheritFrom(vc.styleDefaultsAxes())
+		axisStyle.WriteToRenderer(r)
+
+		slotWidth, _ := vc.getViolinSlots(canvasBox)
+		for _, violin := range vc.Violins {
+			if len(violin.Label) > 0 {
+				lines := render.Text.WrapFit(r, violin.Label, slotWidth, axisStyle)
+				linesBox := render.Text.MeasureLines(r, lines, axisStyle)
+				xaxisHeight = mathutil.MaxInt(linesBox.Height()+defaultXAxisMargin, xaxisHeight)
+			}
+		}
+
+		axesOuterBox = axesOuterBox.Grow(render.Box{
+			Top:    canvasBox.Top,
+			Left:   canvasBox.Left,
+			Right:  canvasBox.Right,
+			Bottom: canvasBox.Bottom + defaultXAxisMargin + xaxisHeight,
+		})
+	}
+
+	if !vc.YAxis.Style.Hidden {
+		axesBounds := vc.YAxis.Measure(r, canvasBox, yr, vc.styleDefaultsAxes(), yticks)
+		axesOuterBox = axesOuterBox.Grow(axesBounds)
+	}
+
+	return canvasBox.OuterConstrain(vc.getDefaultCanvasBox(r), axesOuterBox)
+}
+
+// box returns the chart bounds as a box.
+func (vc *ViolinChart) box() render.Box {
+	dpr := vc.Background.Padding.GetRight(defaultBackgroundPadding.Right)
+	dpb := vc.Background.Padding.GetBottom(defaultBackgroundPadding.Bottom)
+
+	return render.Box{
+		Top:    vc.Background.Padding.GetTop(defaultBackgroundPadding.Top),
+		Left:   vc.Background.Padding.GetLeft(defaultBackgroundPadding.Left),
+		Right:  vc.Width() - dpr,
+		Bottom: vc.Height() - dpb,
+	}
+}
+
+func (vc *ViolinChart) styleDefaultsBackground() render.Style {
+	return render.Style{
+		FillColor:   vc.GetColorPalette().BackgroundColor(),
+		StrokeColor: vc.GetColorPalette().BackgroundStrokeColor(),
+		StrokeWidth: render.DefaultStrokeWidth,
+	}
+}
+
+func (vc *ViolinChart) styleDefaultsCanvas() render.Style {
+	return render.Style{
+		FillColor:   vc.GetColorPalette().CanvasColor(),
+		StrokeColor: vc.GetColorPalette().CanvasStrokeColor(),
+		StrokeWidth: defaultCanvasStrokeWidth,
+	}
+}
+
+func (vc *ViolinChart) styleDefaultsViolin(index int) render.Style {
+	seriesColor := vc.GetColorPalette().GetSeriesColor(index)
+	fillColor := color.NRGBAModel.Convert(seriesColor).(color.NRGBA)
+	fillColor.A = defaultViolinFillAlpha
+
+	return render.Style{
+		StrokeColor: seriesColor,
+		StrokeWidth: defaultSeriesLineWidth,
+		FillColor:   fillColor,
+	}
+}
+
+func (vc *ViolinChart) styleDefaultsBoxPlot() render.Style {
+	return render.Style{
+		StrokeColor: vc.GetColorPalette().TextColor(),
+		StrokeWidth: defaultSeriesLineWidth,
+		FillColor:   vc.GetColorPalette().TextColor(),
+	}
+}
+
+func (vc *ViolinChart) styleDefaultsQuartiles() render.Style {
+	return render.Style{
+		StrokeColor:     vc.GetColorPalette().TextColor(),
+		StrokeWidth:     defaultSeriesLineWidth,
+		StrokeDashArray: []float64{4, 2},
+	}
+}
+
+func (vc *ViolinChart) styleDefaultsAxes() render.Style {
+	return render.Style{
+		StrokeColor:         vc.GetColorPalette().AxisStrokeColor(),
+		StrokeWidth:         defaultAxisLineWidth,
+		Font:                vc.GetFont(),
+		FontSize:            defaultAxisFontSize,
+		FontColor:           vc.GetColorPalette().TextColor(),
+		TextHorizontalAlign: render.TextHorizontalAlignCenter,
+		TextVerticalAlign:   render.TextVerticalAlignTop,
+		TextWrap:            render.TextWrapWord,
+	}
+}
+
+func (vc *ViolinChart) styleDefaultsTitle() render.Style {
+	return render.Style{
+		Font:                vc.GetFont(),
+		FontColor:           vc.GetColorPalette().TextColor(),
+		FontSize:            defaultTitleFontSize,
+		TextHorizontalAlign: render.TextHorizontalAlignCenter,
+	}
+}
+
+func (vc *ViolinChart) styleDefaultsElements() render.Style {
+	return render.Style{
+		Font: vc.GetFont(),
+	}
+}
+
+// GetColorPalette returns the color palette for the chart.
+func (vc *ViolinChart) GetColorPalette() render.ColorPalette {
+	if vc.ColorPalette != nil {
+		return vc.ColorPalette
+	}
+	return render.AlternateColorPalette
+}
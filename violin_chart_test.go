@@ -0,0 +1,125 @@
+package unichart
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/unidoc/unichart/render"
+	"github.com/unidoc/unichart/render/raster"
+)
+
+func TestViolinChartRender(t *testing.T) {
+	vc := ViolinChart{
+		ShowBoxPlot:   true,
+		ShowQuartiles: true,
+		Violins: []Violin{
+			{Label: "a", Samples: []float64{1, 2, 2, 3, 3, 3, 4, 4, 5}},
+			{Label: "b", Samples: []float64{4, 4, 4}},
+		},
+	}
+
+	buf := bytes.NewBuffer(nil)
+	require.NoError(t, vc.Render(raster.NewRenderer, buf))
+	require.NotZero(t, buf.Len())
+
+	require.Error(t, (&ViolinChart{}).Render(raster.NewRenderer, buf))
+}
+
+func TestViolinChartOutline(t *testing.T) {
+	vc := ViolinChart{
+		Violins: []Violin{
+			{Samples: []float64{1, 2, 2, 3, 3, 3, 4, 4, 5}},
+			{Samples: []float64{0, 10, 10, 10, 20}},
+		},
+	}
+
+	// Violins are narrowed to fit their slots, keeping a spacing.
+	slotWidth, violinWidth := vc.getViolinSlots(render.Box{Right: 200})
+	require.Equal(t, 100, slotWidth)
+	require.Equal(t, 80, violinWidth)
+	_, violinWidth = vc.getViolinSlots(render.Box{Right: 100})
+	require.Equal(t, 38, violinWidth)
+
+	for _, violin := range vc.Violins {
+		shape := vc.getViolinShape(violin.Samples, violinWidth)
+		require.Len(t, shape.values, defaultViolinPoints)
+		require.Equal(t, violin.Samples[0], shape.values[0])
+		require.Equal(t, violin.Samples[len(violin.Samples)-1], shape.values[len(shape.values)-1])
+
+		// Each violin is scaled to its maximum width, reached at its mode.
+		widest := 0
+		for i, hw := range shape.halfWidths {
+			require.GreaterOrEqual(t, hw, 0)
+			if hw > shape.halfWidths[widest] {
+				widest = i
+			}
+		}
+		require.Equal(t, violinWidth>>1, shape.halfWidths[widest])
+		mode, spread := violin.Samples[len(violin.Samples)/2], shape.values[len(shape.values)-1]-shape.values[0]
+		require.InDelta(t, mode, shape.values[widest], spread/10)
+
+		// The outline of symmetric samples is symmetric.
+		for i, hw := range shape.halfWidths {
+			require.InDelta(t, hw, shape.halfWidths[len(shape.halfWidths)-1-i], 1)
+		}
+		require.Less(t, shape.halfWidths[0], violinWidth>>1)
+	}
+}
+
+func TestViolinChartBoxPlot(t *testing.T) {
+	vc := ViolinChart{}
+	shape := vc.getViolinShape([]float64{1, 2, 2, 3, 3, 3, 4, 4, 12}, 80)
+	require.Equal(t, 2.0, shape.q1)
+	require.Equal(t, 3.0, shape.median)
+	require.Equal(t, 4.0, shape.q3)
+
+	// Whiskers leave out the samples beyond 1.5 IQR.
+	require.Equal(t, 1.0, shape.lowWhisker)
+	require.Equal(t, 4.0, shape.highWhisker)
+
+	// Quartile lines follow the outline, and the median is at the mode.
+	require.InDelta(t, 40, shape.quartileHalfWidths[1], 1)
+	require.Less(t, shape.quartileHalfWidths[0], 40)
+	require.Less(t, shape.quartileHalfWidths[2], 40)
+	require.Greater(t, shape.quartileHalfWidths[0], 0)
+}
+
+func TestViolinChartConstantSamples(t *testing.T) {
+	vc := ViolinChart{}
+	shape := vc.getViolinShape([]float64{4, 4, 4}, 80)
+
+	// Constant samples are drawn as a line of the full width.
+	require.Equal(t, []float64{4}, shape.values)
+	require.Equal(t, []int{40}, shape.halfWidths)
+	require.Equal(t, [3]int{40, 40, 40}, shape.quartileHalfWidths)
+	require.Equal(t, 4.0, shape.q1)
+	require.Equal(t, 4.0, shape.median)
+	require.Equal(t, 4.0, shape.q3)
+	require.Equal(t, 4.0, shape.lowWhisker)
+	require.Equal(t, 4.0, shape.highWhisker)
+}
+
+func TestViolinChartReversedAxis(t *testing.T) {
+	vc := ViolinChart{
+		YAxis: YAxis{Reversed: true},
+		Violins: []Violin{
+			{Samples: []float64{0, 5, 10}},
+		},
+	}
+
+	yr := vc.getRanges()
+	require.True(t, yr.IsDescending())
+	require.Equal(t, 0.0, yr.GetMin())
+	require.Equal(t, 10.0, yr.GetMax())
+
+	// The largest values are drawn at the origin of reversed ranges.
+	yr.SetDomain(100)
+	require.Equal(t, 100, yr.Translate(0))
+	require.Equal(t, 0, yr.Translate(10))
+
+	vc.YAxis.Ticks = []Tick{{Value: 0}, {Value: 20}}
+	require.True(t, vc.getRanges().IsDescending())
+	require.NoError(t, vc.Render(raster.NewRenderer, bytes.NewBuffer(nil)))
+}
@@ -14,13 +14,42 @@ type XAxis struct {
 	Name      string
 	NameStyle render.Style
 
+	// AxisType specifies if the axis is drawn under (primary) or above
+	// (secondary) the canvas.
+	AxisType dataset.XAxisType
+
 	Style          render.Style
 	ValueFormatter dataset.ValueFormatter
 	Range          sequence.Range
-
-	TickStyle    render.Style
-	Ticks        []Tick
-	TickPosition TickPosition
+	RangePolicy    RangePolicy
+	TickLocator    TickLocator
+
+	// Reversed draws the values of the axis in decreasing order. It applies
+	// to ranges created by the chart; custom ranges are reversed through
+	// their own settings, such as sequence.ContinuousRange.Descending.
+	Reversed bool
+
+	// Transform derives the values of a secondary axis from the values of
+	// the primary axis, such as a unit conversion. It must be monotonic.
+	// When set, the axis shows the transformed range of the primary axis,
+	// and series bound to it are drawn on the primary axis.
+	Transform func(v float64) float64
+
+	TickStyle     render.Style
+	Ticks         []Tick
+	TickPosition  TickPosition
+	TickDirection TickDirection
+	TickLength    int
+
+	MinorTickStyle  render.Style
+	MinorTickCount  int
+	MinorTickStep   float64
+	MinorTickLength int
+
+	// TickLabelOverlap holds the strategies used to resolve overlapping
+	// tick labels, in priority order. Labels with an explicit rotation are
+	// drawn as they are.
+	TickLabelOverlap []TickLabelOverlap
 
 	GridLines      []GridLine
 	GridMajorStyle render.Style
@@ -56,15 +85,52 @@ func (xa XAxis) GetTickPosition(defaults ...TickPosition) TickPosition {
 	return xa.TickPosition
 }
 
+// GetTickDirection returns the direction of the tick marks.
+func (xa XAxis) GetTickDirection(defaults ...TickDirection) TickDirection {
+	if xa.TickDirection == TickDirectionUnset {
+		if len(defaults) > 0 {
+			return defaults[0]
+		}
+		return TickDirectionOutside
+	}
+	return xa.TickDirection
+}
+
+// GetTickLength returns the length of the tick marks.
+func (xa XAxis) GetTickLength(defaults ...int) int {
+	if xa.TickLength <= 0 {
+		if len(defaults) > 0 {
+			return defaults[0]
+		}
+		return defaultVerticalTickHeight
+	}
+	return xa.TickLength
+}
+
+// GetMinorTickLength returns the length of the minor tick marks.
+func (xa XAxis) GetMinorTickLength(defaults ...int) int {
+	if xa.MinorTickLength <= 0 {
+		if len(defaults) > 0 {
+			return defaults[0]
+		}
+		return xa.GetTickLength() >> 1
+	}
+	return xa.MinorTickLength
+}
+
 // GetTicks returns the ticks for a series.
 // The coalesce priority is:
 // 	- User Supplied Ticks (i.e. Ticks array on the axis itself).
+// 	- Located ticks (i.e. if the axis has a tick locator).
 // 	- Range ticks (i.e. if the range provides ticks).
 //	- Generating continuous ticks based on minimum spacing and canvas width.
 func (xa XAxis) GetTicks(r render.Renderer, ra sequence.Range, defaults render.Style, vf dataset.ValueFormatter) []Tick {
 	if len(xa.Ticks) > 0 {
 		return xa.Ticks
 	}
+	if xa.TickLocator != nil {
+		return locateTicks(xa.TickLocator, ra, vf)
+	}
 	if tp, isTickProvider := ra.(TicksProvider); isTickProvider {
 		return tp.GetTicks(r, defaults, vf)
 	}
@@ -73,33 +139,67 @@ func (xa XAxis) GetTicks(r render.Renderer, ra sequence.Range, defaults render.S
 	return generateContinuousTicks(r, ra, false, tickStyle, vf)
 }
 
+// GetMinorTicks returns the minor ticks between the specified ticks, based
+// on the minor tick step or count of the axis.
+func (xa XAxis) GetMinorTicks(ticks []Tick) []Tick {
+	return generateMinorTicks(ticks, xa.MinorTickCount, xa.MinorTickStep)
+}
+
 // GetGridLines returns the gridlines for the axis.
 func (xa XAxis) GetGridLines(ticks []Tick) []GridLine {
 	if len(xa.GridLines) > 0 {
 		return xa.GridLines
 	}
+	if minorTicks := xa.GetMinorTicks(ticks); len(minorTicks) > 0 {
+		return GenerateMinorGridLines(ticks, minorTicks, xa.GridMajorStyle, xa.GridMinorStyle)
+	}
 	return GenerateGridLines(ticks, xa.GridMajorStyle, xa.GridMinorStyle)
 }
 
-// Measure returns the bounds of the axis.
-func (xa XAxis) Measure(r render.Renderer, canvasBox render.Box, ra sequence.Range, defaults render.Style, ticks []Tick) render.Box {
-	tickStyle := xa.TickStyle.InheritFrom(xa.Style.InheritFrom(defaults))
+// getTickLabelOffset returns the distance the tick labels are moved away
+// from the axis, in order to make room for tick marks longer than default.
+func (xa XAxis) getTickLabelOffset() int {
+	_, outside := xa.GetTickDirection().extent(xa.GetTickLength())
+	_, minorOutside := xa.GetTickDirection().extent(xa.GetMinorTickLength())
+	return mathutil.MaxInt(0, mathutil.MaxInt(outside, minorOutside)-defaultVerticalTickHeight)
+}
+
+// getTickLabelLayout returns the layout of the tick labels drawn under the
+// ticks.
+func (xa XAxis) getTickLabelLayout(r render.Renderer, canvasBox render.Box, ra sequence.Range, style render.Style, ticks []Tick) tickLabelLayout {
+	positions := make([]int, len(ticks))
+	for index, t := range ticks {
+		positions[index] = canvasBox.Left + ra.Translate(t.Value)
+	}
+	return newTickLabelLayout(r, positions, ticks, style, false, xa.TickLabelOverlap)
+}
 
+// measureTickLabels returns the horizontal bounds and the height of the tick
+// labels.
+func (xa XAxis) measureTickLabels(r render.Renderer, canvasBox render.Box, ra sequence.Range, tickStyle render.Style, ticks []Tick) (left, right, height int) {
 	tp := xa.GetTickPosition()
+	ll := xa.getTickLabelLayout(r, canvasBox, ra, tickStyle.GetTextOptions(), ticks)
 
 	var ltx, rtx int
 	var tx int
-	var left, right, bottom = math.MaxInt32, 0, 0
+	left = math.MaxInt32
 	for index, t := range ticks {
 		v := t.Value
 
 		tx = canvasBox.Left + ra.Translate(v)
 		switch tp {
 		case TickPositionUnderTick, TickPositionUnset:
+			if tickStyle.TextRotationDegrees == 0 {
+				lb, _, _ := ll.xBox(r, index, tx, 0, tickStyle.GetTextOptions())
+				ltx, rtx = lb.Left, lb.Right
+				height = mathutil.MaxInt(height, lb.Bottom)
+				break
+			}
+
 			tb := render.Text.Measure(r, t.Label, tickStyle.GetTextOptions())
 			ltx = tx - tb.Width()>>1
 			rtx = tx + tb.Width()>>1
-			bottom = mathutil.MaxInt(bottom, tb.Height())
+			height = mathutil.MaxInt(height, tb.Height())
 		case TickPositionBetweenTicks:
 			if index > 0 {
 				ltx = ra.Translate(ticks[index-1].Value)
@@ -107,24 +207,41 @@ func (xa XAxis) Measure(r render.Renderer, canvasBox render.Box, ra sequence.Ran
 
 				finalTickStyle := tickStyle.InheritFrom(render.Style{TextHorizontalAlign: render.TextHorizontalAlignCenter})
 				ftb := render.Text.MeasureLines(r, render.Text.WrapFit(r, t.Label, tx-ltx, finalTickStyle), finalTickStyle)
-				bottom = mathutil.MaxInt(bottom, ftb.Height())
+				height = mathutil.MaxInt(height, ftb.Height())
 			}
 		}
 
 		left = mathutil.MinInt(left, ltx)
 		right = mathutil.MaxInt(right, rtx)
 	}
+	return left, right, height
+}
+
+// Measure returns the bounds of the axis.
+func (xa XAxis) Measure(r render.Renderer, canvasBox render.Box, ra sequence.Range, defaults render.Style, ticks []Tick) render.Box {
+	tickStyle := xa.TickStyle.InheritFrom(xa.Style.InheritFrom(defaults))
+	left, right, height := xa.measureTickLabels(r, canvasBox, ra, tickStyle, ticks)
 
 	if !xa.NameStyle.Hidden && len(xa.Name) > 0 {
 		tb := render.Text.Measure(r, xa.Name, xa.NameStyle.InheritFrom(defaults))
-		bottom += defaultXAxisMargin + tb.Height()
+		height += defaultXAxisMargin + tb.Height()
+	}
+
+	height += defaultXAxisMargin + xa.getTickLabelOffset()
+	if xa.AxisType == dataset.XAxisSecondary {
+		return render.Box{
+			Top:    canvasBox.Top - height,
+			Left:   left,
+			Right:  right,
+			Bottom: canvasBox.Top,
+		}
 	}
 
 	return render.Box{
 		Top:    canvasBox.Bottom,
 		Left:   left,
 		Right:  right,
-		Bottom: canvasBox.Bottom + defaultXAxisMargin + bottom,
+		Bottom: canvasBox.Bottom + height,
 	}
 }
 
@@ -132,12 +249,35 @@ func (xa XAxis) Measure(r render.Renderer, canvasBox render.Box, ra sequence.Ran
 func (xa XAxis) Render(r render.Renderer, canvasBox render.Box, ra sequence.Range, defaults render.Style, ticks []Tick) {
 	tickStyle := xa.TickStyle.InheritFrom(xa.Style.InheritFrom(defaults))
 
+	// Secondary axes are drawn above the canvas, with their tick marks and
+	// labels going up.
+	axisY, outward := canvasBox.Bottom, 1
+	labelTop := canvasBox.Bottom + defaultXAxisMargin + xa.getTickLabelOffset()
+	if xa.AxisType == dataset.XAxisSecondary {
+		_, _, height := xa.measureTickLabels(r, canvasBox, ra, tickStyle, ticks)
+		axisY, outward = canvasBox.Top, -1
+		labelTop = canvasBox.Top - defaultXAxisMargin - xa.getTickLabelOffset() - height
+	}
+
 	tickStyle.GetStrokeOptions().WriteToRenderer(r)
-	r.MoveTo(canvasBox.Left, canvasBox.Bottom)
-	r.LineTo(canvasBox.Right, canvasBox.Bottom)
+	r.MoveTo(canvasBox.Left, axisY)
+	r.LineTo(canvasBox.Right, axisY)
 	r.Stroke()
 
 	tp := xa.GetTickPosition()
+	ll := xa.getTickLabelLayout(r, canvasBox, ra, tickStyle, ticks)
+
+	minorTickStyle := xa.MinorTickStyle.InheritFrom(tickStyle)
+	minorTickStyle.GetStrokeOptions().WriteToRenderer(r)
+	inside, outside := xa.GetTickDirection().extent(xa.GetMinorTickLength())
+	for _, t := range xa.GetMinorTicks(ticks) {
+		tx := canvasBox.Left + ra.Translate(t.Value)
+		r.MoveTo(tx, axisY-outward*inside)
+		r.LineTo(tx, axisY+outward*outside)
+		r.Stroke()
+	}
+
+	inside, outside = xa.GetTickDirection().extent(xa.GetTickLength())
 
 	var tx, ty int
 	var maxTextHeight int
@@ -148,8 +288,8 @@ func (xa XAxis) Render(r render.Renderer, canvasBox render.Box, ra sequence.Rang
 		tx = canvasBox.Left + lx
 
 		tickStyle.GetStrokeOptions().WriteToRenderer(r)
-		r.MoveTo(tx, canvasBox.Bottom)
-		r.LineTo(tx, canvasBox.Bottom+defaultVerticalTickHeight)
+		r.MoveTo(tx, axisY-outward*inside)
+		r.LineTo(tx, axisY+outward*outside)
 		r.Stroke()
 
 		tickWithAxisStyle := xa.TickStyle.InheritFrom(xa.Style.InheritFrom(defaults))
@@ -158,11 +298,13 @@ func (xa XAxis) Render(r render.Renderer, canvasBox render.Box, ra sequence.Rang
 		switch tp {
 		case TickPositionUnderTick, TickPositionUnset:
 			if tickStyle.TextRotationDegrees == 0 {
-				tx = tx - tb.Width()>>1
-				ty = canvasBox.Bottom + defaultXAxisMargin + tb.Height()
-			} else {
-				ty = canvasBox.Bottom + (2 * defaultXAxisMargin)
+				lb, lx, ly := ll.xBox(r, index, tx, labelTop, tickWithAxisStyle)
+				render.Text.Draw(r, ll.labels[index], lx, ly, ll.style(tickWithAxisStyle))
+				maxTextHeight = mathutil.MaxInt(maxTextHeight, lb.Bottom-labelTop)
+				break
 			}
+
+			ty = labelTop + defaultXAxisMargin
 			render.Text.Draw(r, t.Label, tx, ty, tickWithAxisStyle)
 			maxTextHeight = mathutil.MaxInt(maxTextHeight, tb.Height())
 		case TickPositionBetweenTicks:
@@ -174,8 +316,8 @@ func (xa XAxis) Render(r render.Renderer, canvasBox render.Box, ra sequence.Rang
 				render.Text.DrawWithin(r, t.Label, render.Box{
 					Left:   ltx,
 					Right:  tx,
-					Top:    canvasBox.Bottom + defaultXAxisMargin,
-					Bottom: canvasBox.Bottom + defaultXAxisMargin,
+					Top:    labelTop,
+					Bottom: labelTop,
 				}, finalTickStyle)
 
 				ftb := render.Text.MeasureLines(r, render.Text.WrapFit(r, t.Label, tx-ltx, finalTickStyle), finalTickStyle)
@@ -188,7 +330,10 @@ func (xa XAxis) Render(r render.Renderer, canvasBox render.Box, ra sequence.Rang
 	if !xa.NameStyle.Hidden && len(xa.Name) > 0 {
 		tb := render.Text.Measure(r, xa.Name, nameStyle)
 		tx := canvasBox.Right - (canvasBox.Width()>>1 + tb.Width()>>1)
-		ty := canvasBox.Bottom + defaultXAxisMargin + maxTextHeight + defaultXAxisMargin + tb.Height()
+		ty := labelTop + maxTextHeight + defaultXAxisMargin + tb.Height()
+		if xa.AxisType == dataset.XAxisSecondary {
+			ty = labelTop - defaultXAxisMargin
+		}
 		render.Text.Draw(r, xa.Name, tx, ty, nameStyle)
 	}
 
@@ -16,15 +16,48 @@ type YAxis struct {
 	NameStyle render.Style
 	Style     render.Style
 
-	Zero      GridLine
-	AxisType  dataset.YAxisType
+	Zero     GridLine
+	AxisType dataset.YAxisType
+
+	// Ascending is not used.
+	//
+	// Deprecated: Use Reversed to change the direction of the axis.
 	Ascending bool
 
+	// ID identifies an additional axis of a chart, which series reference
+	// through their YAxis field. It is not used by the primary and secondary
+	// axes.
+	ID dataset.YAxisType
+
+	// Offset is the distance between the canvas and the axis line, used to
+	// stack axes next to each other.
+	Offset int
+
 	ValueFormatter dataset.ValueFormatter
 	Range          sequence.Range
-
-	TickStyle render.Style
-	Ticks     []Tick
+	RangePolicy    RangePolicy
+	TickLocator    TickLocator
+
+	// Reversed draws the values of the axis in decreasing order. It applies
+	// to ranges created by the chart; custom ranges are reversed through
+	// their own settings, such as sequence.ContinuousRange.Descending.
+	Reversed bool
+
+	TickStyle     render.Style
+	Ticks         []Tick
+	TickDirection TickDirection
+	TickLength    int
+
+	MinorTickStyle  render.Style
+	MinorTickCount  int
+	MinorTickStep   float64
+	MinorTickLength int
+
+	// TickLabelOverlap holds the strategies used to resolve overlapping
+	// tick labels, in priority order. Only the thin and stagger strategies
+	// apply to the Y axis. Labels with an explicit rotation are drawn as
+	// they are.
+	TickLabelOverlap []TickLabelOverlap
 
 	GridLines      []GridLine
 	GridMajorStyle render.Style
@@ -59,15 +92,52 @@ func (ya YAxis) GetTickStyle() render.Style {
 	return ya.TickStyle
 }
 
+// GetTickDirection returns the direction of the tick marks.
+func (ya YAxis) GetTickDirection(defaults ...TickDirection) TickDirection {
+	if ya.TickDirection == TickDirectionUnset {
+		if len(defaults) > 0 {
+			return defaults[0]
+		}
+		return TickDirectionOutside
+	}
+	return ya.TickDirection
+}
+
+// GetTickLength returns the length of the tick marks.
+func (ya YAxis) GetTickLength(defaults ...int) int {
+	if ya.TickLength <= 0 {
+		if len(defaults) > 0 {
+			return defaults[0]
+		}
+		return defaultHorizontalTickWidth
+	}
+	return ya.TickLength
+}
+
+// GetMinorTickLength returns the length of the minor tick marks.
+func (ya YAxis) GetMinorTickLength(defaults ...int) int {
+	if ya.MinorTickLength <= 0 {
+		if len(defaults) > 0 {
+			return defaults[0]
+		}
+		return ya.GetTickLength() >> 1
+	}
+	return ya.MinorTickLength
+}
+
 // GetTicks returns the ticks for a series.
 // The coalesce priority is:
 // 	- User Supplied Ticks (i.e. Ticks array on the axis itself).
+// 	- Located ticks (i.e. if the axis has a tick locator).
 // 	- Range ticks (i.e. if the range provides ticks).
 //	- Generating continuous ticks based on minimum spacing and canvas width.
 func (ya YAxis) GetTicks(r render.Renderer, ra sequence.Range, defaults render.Style, vf dataset.ValueFormatter) []Tick {
 	if len(ya.Ticks) > 0 {
 		return ya.Ticks
 	}
+	if ya.TickLocator != nil {
+		return locateTicks(ya.TickLocator, ra, vf)
+	}
 	if tp, isTickProvider := ra.(TicksProvider); isTickProvider {
 		return tp.GetTicks(r, defaults, vf)
 	}
@@ -76,42 +146,77 @@ func (ya YAxis) GetTicks(r render.Renderer, ra sequence.Range, defaults render.S
 	return generateContinuousTicks(r, ra, true, tickStyle, vf)
 }
 
+// GetMinorTicks returns the minor ticks between the specified ticks, based
+// on the minor tick step or count of the axis.
+func (ya YAxis) GetMinorTicks(ticks []Tick) []Tick {
+	return generateMinorTicks(ticks, ya.MinorTickCount, ya.MinorTickStep)
+}
+
 // GetGridLines returns the gridlines for the axis.
 func (ya YAxis) GetGridLines(ticks []Tick) []GridLine {
 	if len(ya.GridLines) > 0 {
 		return ya.GridLines
 	}
+	if minorTicks := ya.GetMinorTicks(ticks); len(minorTicks) > 0 {
+		return GenerateMinorGridLines(ticks, minorTicks, ya.GridMajorStyle, ya.GridMinorStyle)
+	}
 	return GenerateGridLines(ticks, ya.GridMajorStyle, ya.GridMinorStyle)
 }
 
+// getTickLabelOffset returns the distance the tick labels are moved away
+// from the axis, in order to make room for tick marks longer than default.
+func (ya YAxis) getTickLabelOffset() int {
+	_, outside := ya.GetTickDirection().extent(ya.GetTickLength())
+	_, minorOutside := ya.GetTickDirection().extent(ya.GetMinorTickLength())
+	return mathutil.MaxInt(0, mathutil.MaxInt(outside, minorOutside)-defaultHorizontalTickWidth)
+}
+
+// getTickLabelLayout returns the layout of the tick labels drawn next to the
+// ticks.
+func (ya YAxis) getTickLabelLayout(r render.Renderer, canvasBox render.Box, ra sequence.Range, style render.Style, ticks []Tick) tickLabelLayout {
+	strategies := ya.TickLabelOverlap
+	if style.TextRotationDegrees != 0 {
+		strategies = []TickLabelOverlap{TickLabelOverlapAllow}
+	}
+
+	positions := make([]int, len(ticks))
+	for index, t := range ticks {
+		positions[index] = canvasBox.Bottom - ra.Translate(t.Value)
+	}
+	return newTickLabelLayout(r, positions, ticks, style, true, strategies)
+}
+
 // Measure returns the bounds of the axis.
 func (ya YAxis) Measure(r render.Renderer, canvasBox render.Box, ra sequence.Range, defaults render.Style, ticks []Tick) render.Box {
 	var tx int
 	if ya.AxisType == dataset.YAxisPrimary {
-		tx = canvasBox.Right + defaultYAxisMargin
+		tx = canvasBox.Right + ya.Offset + defaultYAxisMargin + ya.getTickLabelOffset()
 	} else if ya.AxisType == dataset.YAxisSecondary {
-		tx = canvasBox.Left - defaultYAxisMargin
+		tx = canvasBox.Left - ya.Offset - defaultYAxisMargin - ya.getTickLabelOffset()
 	}
 
-	ya.TickStyle.InheritFrom(ya.Style.InheritFrom(defaults)).WriteToRenderer(r)
+	tickStyle := ya.TickStyle.InheritFrom(ya.Style.InheritFrom(defaults))
+	ll := ya.getTickLabelLayout(r, canvasBox, ra, tickStyle, ticks)
+
 	var minx, maxx, miny, maxy = math.MaxInt32, 0, math.MaxInt32, 0
 	var maxTextHeight int
-	for _, t := range ticks {
+	for index, t := range ticks {
 		v := t.Value
 		ly := canvasBox.Bottom - ra.Translate(v)
 
-		tb := r.MeasureText(t.Label)
+		tb := render.Text.Measure(r, ll.labels[index], tickStyle)
 		tbh2 := tb.Height() >> 1
-		finalTextX := tx
+		columnOffset := ll.yOffset(index)
+		finalTextX := tx + columnOffset
 		if ya.AxisType == dataset.YAxisSecondary {
-			finalTextX = tx - tb.Width()
+			finalTextX = tx - columnOffset - tb.Width()
 		}
 
 		maxTextHeight = mathutil.MaxInt(tb.Height(), maxTextHeight)
 
 		if ya.AxisType == dataset.YAxisPrimary {
-			minx = canvasBox.Right
-			maxx = mathutil.MaxInt(maxx, tx+tb.Width())
+			minx = canvasBox.Right + ya.Offset
+			maxx = mathutil.MaxInt(maxx, tx+columnOffset+tb.Width())
 		} else if ya.AxisType == dataset.YAxisSecondary {
 			minx = mathutil.MinInt(minx, finalTextX)
 			maxx = mathutil.MaxInt(maxx, tx)
@@ -122,7 +227,11 @@ func (ya YAxis) Measure(r render.Renderer, canvasBox render.Box, ra sequence.Ran
 	}
 
 	if !ya.NameStyle.Hidden && len(ya.Name) > 0 {
-		maxx += (defaultYAxisMargin + maxTextHeight)
+		if ya.AxisType == dataset.YAxisSecondary {
+			minx -= defaultYAxisMargin + maxTextHeight
+		} else {
+			maxx += defaultYAxisMargin + maxTextHeight
+		}
 	}
 
 	return render.Box{
@@ -133,39 +242,57 @@ func (ya YAxis) Measure(r render.Renderer, canvasBox render.Box, ra sequence.Ran
 	}
 }
 
-// Render renders the axis.
-func (ya YAxis) Render(r render.Renderer, canvasBox render.Box, ra sequence.Range, defaults render.Style, ticks []Tick) {
-	tickStyle := ya.TickStyle.InheritFrom(ya.Style.InheritFrom(defaults))
-	tickStyle.WriteToRenderer(r)
+// getLineX returns the horizontal position of the axis line.
+func (ya YAxis) getLineX(canvasBox render.Box) int {
+	if ya.AxisType == dataset.YAxisSecondary {
+		return canvasBox.Left - ya.Offset
+	}
+	return canvasBox.Right + ya.Offset
+}
 
-	sw := tickStyle.GetStrokeWidth(defaults.StrokeWidth)
+// renderTicks renders the major and minor tick marks of the axis, along with
+// the tick labels, and returns the width taken by the labels.
+func (ya YAxis) renderTicks(r render.Renderer, canvasBox render.Box, ra sequence.Range, tickStyle render.Style, ticks []Tick) int {
+	lx := ya.getLineX(canvasBox)
+	tx := lx + defaultYAxisMargin + ya.getTickLabelOffset()
+	outward := 1
+	if ya.AxisType == dataset.YAxisSecondary {
+		tx = lx - defaultYAxisMargin - ya.getTickLabelOffset()
+		outward = -1
+	}
 
-	var lx int
-	var tx int
-	if ya.AxisType == dataset.YAxisPrimary {
-		lx = canvasBox.Right
-		tx = lx + defaultYAxisMargin
-	} else if ya.AxisType == dataset.YAxisSecondary {
-		lx = canvasBox.Left
-		tx = lx - defaultYAxisMargin
+	minorTickStyle := ya.MinorTickStyle.InheritFrom(tickStyle)
+	minorTickStyle.GetStrokeOptions().WriteToRenderer(r)
+	inside, outside := ya.GetTickDirection().extent(ya.GetMinorTickLength())
+	for _, t := range ya.GetMinorTicks(ticks) {
+		ly := canvasBox.Bottom - ra.Translate(t.Value)
+		r.MoveTo(lx-outward*inside, ly)
+		r.LineTo(lx+outward*outside, ly)
+		r.Stroke()
 	}
 
+	inside, outside = ya.GetTickDirection().extent(ya.GetTickLength())
+	ll := ya.getTickLabelLayout(r, canvasBox, ra, tickStyle, ticks)
+	tickStyle.WriteToRenderer(r)
+
 	var maxTextWidth int
 	var finalTextX, finalTextY int
-	for _, t := range ticks {
-		v := t.Value
+	for index := range ticks {
+		v := ticks[index].Value
 		ly := canvasBox.Bottom - ra.Translate(v)
 
-		tb := render.Text.Measure(r, t.Label, tickStyle)
+		label := ll.labels[index]
+		tb := render.Text.Measure(r, label, tickStyle)
 
-		if tb.Width() > maxTextWidth {
-			maxTextWidth = tb.Width()
+		columnOffset := ll.yOffset(index)
+		if columnOffset+tb.Width() > maxTextWidth {
+			maxTextWidth = columnOffset + tb.Width()
 		}
 
 		if ya.AxisType == dataset.YAxisSecondary {
-			finalTextX = tx - tb.Width()
+			finalTextX = tx - columnOffset - tb.Width()
 		} else {
-			finalTextX = tx
+			finalTextX = tx + columnOffset
 		}
 
 		if tickStyle.TextRotationDegrees == 0 {
@@ -180,16 +307,24 @@ func (ya YAxis) Render(r render.Renderer, canvasBox render.Box, ra sequence.Rang
 			continue
 		}
 
-		r.MoveTo(lx, ly)
-		if ya.AxisType == dataset.YAxisPrimary {
-			r.LineTo(lx+defaultHorizontalTickWidth, ly)
-		} else if ya.AxisType == dataset.YAxisSecondary {
-			r.LineTo(lx-defaultHorizontalTickWidth, ly)
-		}
+		r.MoveTo(lx-outward*inside, ly)
+		r.LineTo(lx+outward*outside, ly)
 		r.Stroke()
 
-		render.Text.Draw(r, t.Label, finalTextX, finalTextY, tickStyle)
+		render.Text.Draw(r, label, finalTextX, finalTextY, tickStyle)
 	}
+	return maxTextWidth
+}
+
+// Render renders the axis.
+func (ya YAxis) Render(r render.Renderer, canvasBox render.Box, ra sequence.Range, defaults render.Style, ticks []Tick) {
+	tickStyle := ya.TickStyle.InheritFrom(ya.Style.InheritFrom(defaults))
+	tickStyle.WriteToRenderer(r)
+
+	sw := tickStyle.GetStrokeWidth(defaults.StrokeWidth)
+	lx := ya.getLineX(canvasBox)
+	offset := ya.getTickLabelOffset()
+	maxTextWidth := ya.renderTicks(r, canvasBox, ra, tickStyle, ticks)
 
 	nameStyle := ya.NameStyle.InheritFrom(defaults.InheritFrom(render.Style{TextRotationDegrees: 90}))
 	if !ya.NameStyle.Hidden && len(ya.Name) > 0 {
@@ -198,9 +333,12 @@ func (ya YAxis) Render(r render.Renderer, canvasBox render.Box, ra sequence.Rang
 
 		var tx int
 		if ya.AxisType == dataset.YAxisPrimary {
-			tx = canvasBox.Right + int(sw) + defaultYAxisMargin + maxTextWidth + defaultYAxisMargin
+			tx = lx + int(sw) + defaultYAxisMargin + offset + maxTextWidth + defaultYAxisMargin
 		} else if ya.AxisType == dataset.YAxisSecondary {
-			tx = canvasBox.Left - (defaultYAxisMargin + int(sw) + maxTextWidth + defaultYAxisMargin)
+			tx = lx - (defaultYAxisMargin + offset + int(sw) + maxTextWidth + defaultYAxisMargin + tb.Height())
+			if nameStyle.TextRotationDegrees == 0 {
+				tx = lx - (defaultYAxisMargin + offset + int(sw) + maxTextWidth + defaultYAxisMargin + tb.Width())
+			}
 		}
 
 		var ty int
@@ -238,9 +376,9 @@ func (ya YAxis) RenderAxisLine(r render.Renderer, canvasBox render.Box, ra seque
 
 	var lx int
 	if ya.AxisType == dataset.YAxisPrimary {
-		lx = canvasBox.Right
+		lx = canvasBox.Right + ya.Offset
 	} else if ya.AxisType == dataset.YAxisSecondary {
-		lx = canvasBox.Left
+		lx = canvasBox.Left - ya.Offset
 	}
 
 	r.SetStrokeWidth(sw)